@@ -0,0 +1,129 @@
+package cron
+
+import (
+	"math/big"
+	"time"
+)
+
+// ActiveSeconds returns the seconds of the minute at which the schedule runs,
+// in ascending order.
+func (s *SpecSchedule) ActiveSeconds() []int {
+	return activeValues(s.Second, seconds)
+}
+
+// ActiveMinutes returns the minutes of the hour at which the schedule runs, in
+// ascending order.
+func (s *SpecSchedule) ActiveMinutes() []int {
+	return activeValues(s.Minute, minutes)
+}
+
+// ActiveHours returns the hours of the day at which the schedule runs, in
+// ascending order.
+func (s *SpecSchedule) ActiveHours() []int {
+	return activeValues(s.Hour, hours)
+}
+
+// ActiveDays returns the days of the month, from 1 to 31, given in the
+// schedule's day of month field, in ascending order. Days counted from the end
+// of the month are returned by ActiveLastDays instead.
+func (s *SpecSchedule) ActiveDays() []int {
+	return activeValues(s.Dom, dom)
+}
+
+// ActiveLastDays returns the days of the month given in the schedule's day of
+// month field as counted back from its last day, in ascending order: 0 for the
+// last day ("L"), 1 for the day before ("1L"), and so on.
+func (s *SpecSchedule) ActiveLastDays() []int {
+	var days []int
+	for bit := 55; bit >= 48; bit-- {
+		if s.Dom.Bit(bit) > 0 {
+			days = append(days, 55-bit)
+		}
+	}
+	return days
+}
+
+// ActiveMonths returns the months in which the schedule runs, in order.
+func (s *SpecSchedule) ActiveMonths() []time.Month {
+	var active []time.Month
+	for _, v := range activeValues(s.Month, months) {
+		active = append(active, time.Month(v))
+	}
+	return active
+}
+
+// ActiveWeekdays returns the days of the week given in the schedule's day of
+// week field, from Sunday. Last weekdays of the month are returned by
+// ActiveLastWeekdays instead.
+func (s *SpecSchedule) ActiveWeekdays() []time.Weekday {
+	var weekdays []time.Weekday
+	for _, v := range activeValues(s.Dow, dow) {
+		weekdays = append(weekdays, time.Weekday(v))
+	}
+	return weekdays
+}
+
+// ActiveLastWeekdays returns the days of the week whose last occurrence in the
+// month is given in the schedule's day of week field ("5L"), from Sunday.
+func (s *SpecSchedule) ActiveLastWeekdays() []time.Weekday {
+	var weekdays []time.Weekday
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if s.Dow.Bit(49+int(d)) > 0 {
+			weekdays = append(weekdays, d)
+		}
+	}
+	return weekdays
+}
+
+// ActiveYears returns the calendar years in which the schedule runs, in
+// ascending order.
+func (s *SpecSchedule) ActiveYears() []int {
+	active := activeValues(s.Year, years)
+	for i := range active {
+		active[i] += minYear
+	}
+	return active
+}
+
+// activeValues returns the values of the field that are set, ignoring any
+// flags beyond its bounds.
+func activeValues(bits *big.Int, r bounds) []int {
+	var values []int
+	for v := r.min; v <= r.max; v++ {
+		if bits.Bit(int(v)) > 0 {
+			values = append(values, int(v))
+		}
+	}
+	return values
+}
+
+// Slice returns the position of every bit set in the given field of the
+// schedule, one of Millisecond, Second, Minute, Hour, Dom, Month, Dow, Year or
+// Week, in ascending order, or nil for any other option. Unlike the Active
+// methods it includes the bits beyond the field's values, such as those of
+// last days in the day of month (48 to 55), and the bit marking a field given
+// as "*" (160), for code that works with the bits directly. The meaning of
+// each bit is left to the caller: bit 55 of the day of month is its last day,
+// and years are counted from 1970. A field that isn't set is given as it
+// behaves: milliseconds as 0 alone, and weeks of the year as "*".
+func (s *SpecSchedule) Slice(field ParseOption) []uint {
+	var bits *big.Int
+	if field == Millisecond {
+		bits = s.milliseconds()
+	}
+	for i, place := range places {
+		if place == field {
+			bits = s.fields()[i]
+		}
+	}
+	if bits == nil {
+		return nil
+	}
+	var positions []uint
+	for bit := 0; bit < bits.BitLen(); bit++ {
+		if bits.Bit(bit) > 0 {
+			positions = append(positions, uint(bit))
+		}
+	}
+	return positions
+}
@@ -0,0 +1,95 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestActiveValues(t *testing.T) {
+	sched, err := quartzParser.Parse("*/20 0,30 9-11 1,15,L,2L * MON,5L 2030-2032")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sched.(*SpecSchedule)
+	tests := []struct {
+		name             string
+		actual, expected interface{}
+	}{
+		{"seconds", s.ActiveSeconds(), []int{0, 20, 40}},
+		{"minutes", s.ActiveMinutes(), []int{0, 30}},
+		{"hours", s.ActiveHours(), []int{9, 10, 11}},
+		{"days", s.ActiveDays(), []int{1, 15}},
+		{"last days", s.ActiveLastDays(), []int{0, 2}},
+		{"months", s.ActiveMonths(), []time.Month{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+		{"weekdays", s.ActiveWeekdays(), []time.Weekday{time.Monday}},
+		{"last weekdays", s.ActiveLastWeekdays(), []time.Weekday{time.Friday}},
+		{"years", s.ActiveYears(), []int{2030, 2031, 2032}},
+	}
+	for _, c := range tests {
+		if !reflect.DeepEqual(c.actual, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, c.actual)
+		}
+	}
+
+	// The ClampDom flags are not days.
+	clamped, _ := NewParser(Minute | Hour | Dom | Month | Dow | ClampDom).Parse("0 0 30,31 * *")
+	if days := clamped.(*SpecSchedule).ActiveDays(); !reflect.DeepEqual(days, []int{30, 31}) {
+		t.Errorf("expected [30 31], got %v", days)
+	}
+	if days := clamped.(*SpecSchedule).ActiveLastDays(); days != nil {
+		t.Errorf("expected no last days, got %v", days)
+	}
+}
+
+func TestSlice(t *testing.T) {
+	sched, err := quartzParser.Parse("*/20 0,30 9-11 1,15,L,2L * MON,5L 2030-2032")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sched.(*SpecSchedule)
+	tests := []struct {
+		field    ParseOption
+		expected []uint
+	}{
+		{Millisecond, []uint{0}},
+		{Second, []uint{0, 20, 40}},
+		{Dom, []uint{1, 15, 53, 55}},
+		{Dow, []uint{1, 54}},
+		{Year, []uint{60, 61, 62}},
+		{TwoDigitYears, nil},
+	}
+	for _, c := range tests {
+		if actual := s.Slice(c.field); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("field %d: expected %v, got %v", c.field, c.expected, actual)
+		}
+	}
+	if months := s.Slice(Month); len(months) != 13 || months[12] != maxBits {
+		t.Errorf("expected every month and the * bit, got %v", months)
+	}
+	if weeks := s.Slice(Week); len(weeks) != 54 || weeks[0] != 1 || weeks[53] != maxBits {
+		t.Errorf("expected every week and the * bit, got %v", weeks)
+	}
+
+	// Without wildcards or special values, Slice agrees with the Active methods.
+	s = mustParse(t, "5,10 9-11 1,15 1-6 1-5").(*SpecSchedule)
+	asUint := func(values interface{}) []uint {
+		v := reflect.ValueOf(values)
+		positions := make([]uint, v.Len())
+		for i := range positions {
+			positions[i] = uint(v.Index(i).Int())
+		}
+		return positions
+	}
+	for field, active := range map[ParseOption]interface{}{
+		Minute: s.ActiveMinutes(),
+		Hour:   s.ActiveHours(),
+		Dom:    s.ActiveDays(),
+		Month:  s.ActiveMonths(),
+		Dow:    s.ActiveWeekdays(),
+	} {
+		if actual, expected := s.Slice(field), asUint(active); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("field %d: expected %v, got %v", field, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package cron
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// WarningCode identifies a kind of Warning. The codes are stable, so they may
+// be mapped to messages of your own; WarningCodes lists them all.
+type WarningCode string
+
+const (
+	// WarnNeverFires means the schedule has no activation within five years.
+	WarnNeverFires WarningCode = "never-fires"
+
+	// WarnPastYears means every year in the year field has passed.
+	WarnPastYears WarningCode = "past-years"
+
+	// WarnImpossibleDate means no month in the month field has any of the
+	// days in the day of month field, e.g. the 31st of February.
+	WarnImpossibleDate WarningCode = "impossible-date"
+
+	// WarnLeapYearsOnly means the only date the schedule can match is
+	// February 29th, so it fires only in leap years.
+	WarnLeapYearsOnly WarningCode = "leap-years-only"
+
+	// WarnEverySecond means the schedule fires every second, at least for a
+	// while, which is rarely intended.
+	WarnEverySecond WarningCode = "every-second"
+
+	// WarnDomOrDow means both the day of month and day of week are
+	// restricted, so the schedule fires on days matching either one rather
+	// than both.
+	WarnDomOrDow WarningCode = "dom-or-dow"
+
+	// WarnSecondsDropped means ToStandard dropped a second other than 0.
+	WarnSecondsDropped WarningCode = "seconds-dropped"
+
+	// WarnYearsDropped means ToStandard dropped a restriction to some years.
+	WarnYearsDropped WarningCode = "years-dropped"
+
+	// WarnWeeksDropped means ToStandard dropped a restriction to some weeks
+	// of the year.
+	WarnWeeksDropped WarningCode = "weeks-dropped"
+
+	// WarnDatesDropped means ToStandard dropped the dates the schedule
+	// excludes.
+	WarnDatesDropped WarningCode = "dates-dropped"
+
+	// WarnLocationDropped means ToStandard dropped the schedule's location,
+	// which must be given to the system running the spec some other way.
+	WarnLocationDropped WarningCode = "location-dropped"
+
+	// WarnLastDaysWidened means ToStandard replaced last days or weekdays of
+	// the month (L) with every day they could fall on.
+	WarnLastDaysWidened WarningCode = "last-days-widened"
+
+	// WarnWeekdaysWidened means ToStandard replaced nearest weekdays (W) or
+	// nth weekdays of the month (#) with every day they could fall on.
+	WarnWeekdaysWidened WarningCode = "weekdays-widened"
+
+	// WarnDomAndDowWidened means ToStandard replaced a requirement to match
+	// both day fields with a requirement to match either.
+	WarnDomAndDowWidened WarningCode = "dom-and-dow-widened"
+)
+
+// WarningCodes returns every code Analyze and ToStandard may report.
+func WarningCodes() []WarningCode {
+	return []WarningCode{
+		WarnNeverFires,
+		WarnPastYears,
+		WarnImpossibleDate,
+		WarnLeapYearsOnly,
+		WarnEverySecond,
+		WarnDomOrDow,
+		WarnSecondsDropped,
+		WarnYearsDropped,
+		WarnWeeksDropped,
+		WarnDatesDropped,
+		WarnLocationDropped,
+		WarnLastDaysWidened,
+		WarnWeekdaysWidened,
+		WarnDomAndDowWidened,
+	}
+}
+
+// Severity ranks how likely a Warning is to indicate a mistake.
+type Severity int
+
+const (
+	// SeverityInfo flags behavior that may be surprising but is often
+	// intended.
+	SeverityInfo Severity = iota
+
+	// SeverityWarning flags a schedule that is probably not what was meant.
+	SeverityWarning
+
+	// SeverityCritical flags a schedule that never fires.
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Warning describes a legal but suspicious part of a spec, or something lost in
+// converting a schedule.
+type Warning struct {
+	Code     WarningCode
+	Message  string
+	Severity Severity
+	Field    string // the field involved, as in ParseError, or "" for the whole spec
+}
+
+// Analyze parses the spec and reports anything about it that is legal but
+// likely to be a mistake, such as a date that never occurs. Specs of 5, 6 and
+// 7 fields are taken to be standard, to start with seconds, and to also end
+// with a year, respectively. It returns an error if the spec does not parse.
+func Analyze(spec string) ([]Warning, error) {
+	return analyze(spec, time.Now())
+}
+
+// analyze does the work of Analyze, as of the given time.
+func analyze(spec string, now time.Time) ([]Warning, error) {
+	p, err := parserForFieldCount(spec)
+	if err != nil {
+		return nil, err
+	}
+	sched, err := p.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := sched.(*SpecSchedule)
+	if !ok {
+		return nil, nil
+	}
+
+	var (
+		warnings []Warning
+		never    bool // whether a warning already explains why it never fires
+	)
+	warn := func(code WarningCode, severity Severity, field, format string, args ...interface{}) {
+		warnings = append(warnings, Warning{
+			Code:     code,
+			Message:  fmt.Sprintf(format, args...),
+			Severity: severity,
+			Field:    field,
+		})
+		never = never || severity == SeverityCritical
+	}
+
+	if p.options&Second > 0 && isAll(s.Second, seconds) {
+		warn(WarnEverySecond, SeverityWarning, "second", "fires every second")
+	}
+
+	switch dowStar := s.Dow.Bit(maxBits) == 1; {
+	case !s.bothDays():
+		warn(WarnDomOrDow, SeverityInfo, "dow",
+			"fires on days matching either the day of month or the day of week, not both")
+	case dowStar && !hasFlags(s.Dom, dom):
+		possible, leapOnly := possibleDates(s)
+		switch {
+		case !possible:
+			warn(WarnImpossibleDate, SeverityCritical, "dom",
+				"none of the days of the month occur in the months given")
+		case leapOnly:
+			warn(WarnLeapYearsOnly, SeverityWarning, "dom", "fires only on February 29th, in leap years")
+		}
+	}
+
+	if !isAll(s.Year, years) && lastYear(s) < now.In(s.EffectiveLocation()).Year() {
+		warn(WarnPastYears, SeverityCritical, "year", "every year given has passed")
+	}
+
+	if !never && s.Next(now).IsZero() {
+		warn(WarnNeverFires, SeverityCritical, "", "does not fire within five years")
+	}
+	return warnings, nil
+}
+
+// hasFlags reports whether any bits other than the star bit and the DomAndDow
+// flag are set beyond the field's values, such as those for L.
+func hasFlags(bits *big.Int, r bounds) bool {
+	flags := new(big.Int).Rsh(bits, r.max+1)
+	flags.SetBit(flags, maxBits-int(r.max+1), 0)
+	flags.SetBit(flags, domAndDowBit-int(r.max+1), 0)
+	return flags.Sign() != 0
+}
+
+// possibleDates reports whether any day of the month occurs in any of the
+// months, and whether February 29th is the only such date.
+func possibleDates(s *SpecSchedule) (possible, leapOnly bool) {
+	leapOnly = true
+	for m := months.min; m <= months.max; m++ {
+		if s.Month.Bit(int(m)) == 0 {
+			continue
+		}
+		// Days in the month of a leap year.
+		days := uint(time.Date(2000, time.Month(m)+1, 0, 0, 0, 0, 0, time.UTC).Day())
+		for d := dom.min; d <= days; d++ {
+			if s.Dom.Bit(int(d)) == 0 {
+				continue
+			}
+			possible = true
+			leapOnly = leapOnly && m == 2 && d == 29
+		}
+	}
+	return possible, possible && leapOnly
+}
+
+// lastYear returns the last year in the schedule's year field.
+func lastYear(s *SpecSchedule) int {
+	for y := int(years.max); y >= int(years.min); y-- {
+		if s.Year.Bit(y) == 1 {
+			return minYear + y
+		}
+	}
+	return 0
+}
@@ -0,0 +1,86 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAnalyze(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		spec     string
+		expected []WarningCode
+	}{
+		{"0 9 * * MON-FRI", nil},
+		{"0 0 0 * * *", nil},
+		{"@daily", nil},
+		{"@every 1h", nil},
+		{"0 0 0 31 2 *", []WarningCode{WarnImpossibleDate}},
+		{"0 0 30,31 2 *", []WarningCode{WarnImpossibleDate}},
+		{"0 0 31 4,6,9,11 *", []WarningCode{WarnImpossibleDate}},
+		{"0 0 0 31 1,2 *", nil},
+		{"0 0 L 2 *", nil},
+		{"0 0 0 29 2 *", []WarningCode{WarnLeapYearsOnly}},
+		{"0 0 0 29-31 2 *", []WarningCode{WarnLeapYearsOnly}},
+		{"0 0 0 1 1 * 2020,2024", []WarningCode{WarnPastYears}},
+		{"0 0 0 1 1 * 2040", []WarningCode{WarnNeverFires}},
+		{"0 0 0 1 1 * 2027", nil},
+		{"* * * * * * *", []WarningCode{WarnEverySecond}},
+		{"* 0 12 * * *", []WarningCode{WarnEverySecond}},
+		{"0 0 1 * MON", []WarningCode{WarnDomOrDow}},
+		{"0 0 0 31 2 * 2020", []WarningCode{WarnImpossibleDate, WarnPastYears}},
+	}
+
+	for _, c := range tests {
+		warnings, err := analyze(c.spec, now)
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		var codes []WarningCode
+		for _, w := range warnings {
+			codes = append(codes, w.Code)
+		}
+		if !reflect.DeepEqual(codes, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.spec, c.expected, warnings)
+		}
+	}
+}
+
+func TestAnalyzeWarning(t *testing.T) {
+	warnings, err := Analyze("0 0 0 31 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Warning{{
+		Code:     WarnImpossibleDate,
+		Message:  "none of the days of the month occur in the months given",
+		Severity: SeverityCritical,
+		Field:    "dom",
+	}}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("expected %v, got %v", expected, warnings)
+	}
+	if warnings[0].Severity.String() != "critical" {
+		t.Errorf("expected critical, got %s", warnings[0].Severity)
+	}
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "* * * * * * * *", "61 * * * *"} {
+		if _, err := Analyze(spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+}
+
+func TestWarningCodes(t *testing.T) {
+	seen := make(map[WarningCode]bool)
+	for _, code := range WarningCodes() {
+		if code == "" || seen[code] {
+			t.Errorf("bad or duplicate code %q", code)
+		}
+		seen[code] = true
+	}
+}
@@ -0,0 +1,195 @@
+package cron
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// awsParser parses EventBridge cron expressions once their day fields are
+// rewritten and a seconds field added.
+var awsParser = NewParser(Second | Minute | Hour | Dom | Month | Dow | Year)
+
+// ParseAWS parses an Amazon EventBridge schedule expression: either
+// "cron(minutes hours day-of-month month day-of-week year)", which becomes a
+// SpecSchedule in UTC, or "rate(value unit)", with a unit of minutes, hours or
+// days, which becomes a ConstantDelaySchedule.
+//
+// The day fields are as in ParseQuartz: exactly one of them must be "?", and
+// the day of week is numbered from 1 (Sunday) to 7 (Saturday). "L", "W" and
+// "#" are accepted as in EventBridge. Years after 2099 are an error.
+func ParseAWS(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	switch {
+	case strings.HasPrefix(expr, "rate(") && strings.HasSuffix(expr, ")"):
+		return parseAWSRate(expr[len("rate(") : len(expr)-1])
+	case strings.HasPrefix(expr, "cron(") && strings.HasSuffix(expr, ")"):
+		return parseAWSCron(expr[len("cron(") : len(expr)-1])
+	}
+	return nil, fmt.Errorf("expected cron(...) or rate(...), found: %s", expr)
+}
+
+// awsUnits holds the duration of each unit of a rate expression, which is
+// singular only for a value of 1.
+var awsUnits = map[string]time.Duration{
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+}
+
+func parseAWSRate(rate string) (Schedule, error) {
+	fields := strings.Fields(rate)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("expected a value and a unit, found: rate(%s)", rate)
+	}
+	value, err := strconv.Atoi(fields[0])
+	if err != nil || value <= 0 {
+		return nil, fmt.Errorf("rate must be a positive whole number: %s", fields[0])
+	}
+	unit := fields[1]
+	if value != 1 {
+		if !strings.HasSuffix(unit, "s") {
+			return nil, fmt.Errorf("rate of %d needs a plural unit: %s", value, unit)
+		}
+		unit = unit[:len(unit)-1]
+	}
+	d, ok := awsUnits[unit]
+	if !ok {
+		return nil, fmt.Errorf("unrecognized rate unit: %s", fields[1])
+	}
+	return Every(time.Duration(value) * d), nil
+}
+
+func parseAWSCron(cron string) (Schedule, error) {
+	fields := strings.Fields(cron)
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("expected exactly 6 fields, found %d: cron(%s)", len(fields), cron)
+	}
+	if err := fromQuartzDays(fields, 2, 4); err != nil {
+		return nil, err
+	}
+	return awsParser.Parse("TZ=UTC 0 " + strings.Join(fields, " "))
+}
+
+// ToAWS returns the EventBridge cron expression for the schedule, in the form
+// ParseAWS accepts. It returns an error if the schedule can't be expressed:
+// if it isn't in UTC; if it runs at any second but the first of the minute;
+// if it restricts weeks of the year or excludes dates; if it clamps days of
+// the month, or runs a number of days before the last; or if it runs on days
+// matching either the day of month or the day of week, as cron's OR rule
+// does, or both, as DomAndDow does, since EventBridge requires one of them to
+// be "?".
+func ToAWS(s *SpecSchedule) (string, error) {
+	if name := s.LocationName(); name != "UTC" && name != "Etc/UTC" {
+		return "", fmt.Errorf("EventBridge schedules are in UTC, not %s", name)
+	}
+	if s.subSecond() || countBits(s.Second, seconds) != 1 || s.Second.Bit(0) == 0 {
+		return "", fmt.Errorf("EventBridge schedules can't run at seconds past the minute: %s", s)
+	}
+	if s.WeekOfYear != nil && !isAll(s.WeekOfYear, weeks) {
+		return "", fmt.Errorf("EventBridge schedules can't restrict weeks of the year: %s", s)
+	}
+	if len(s.excluded) > 0 {
+		return "", fmt.Errorf("EventBridge schedules can't exclude dates: %s", s)
+	}
+	fields := s.fields()
+
+	domField, err := toAWSDom(fields[3])
+	if err != nil {
+		return "", err
+	}
+	dowField := toAWSDow(fields[5])
+	domStar, dowStar := fields[3].Bit(maxBits) > 0, fields[5].Bit(maxBits) > 0
+	switch {
+	case fields[3].Bit(domAndDowBit) > 0:
+		return "", fmt.Errorf("EventBridge schedules can't run on a day of month and a day of week: %s", s)
+	case dowStar:
+		dowField = "?"
+	case domStar:
+		domField = "?"
+	case domField == "*":
+		dowField = "?"
+	case dowField == "*":
+		domField, dowField = "*", "?"
+	default:
+		return "", fmt.Errorf("EventBridge schedules can't run on a day of month or a day of week: %s", s)
+	}
+
+	tokens := []string{
+		toAWSField(fields[1], minutes),
+		toAWSField(fields[2], hours),
+		domField,
+		toAWSField(fields[4], months),
+		dowField,
+		toAWSField(fields[6], years),
+	}
+	return "cron(" + strings.Join(tokens, " ") + ")", nil
+}
+
+// toAWSField formats a field with no special values.
+func toAWSField(field *big.Int, r bounds) string {
+	token := FormatField(field, r.min, r.max, r.names)
+	if strings.HasPrefix(token, "*/") {
+		token = FormatField(new(big.Int).SetBit(new(big.Int), int(r.min), 1), r.min, r.max, r.names) + token[1:]
+	}
+	return token
+}
+
+// toAWSDom formats the day of month field. Of its special values, EventBridge
+// shares L, the last day, and W, the nearest weekday.
+func toAWSDom(field *big.Int) (string, error) {
+	days := new(big.Int).Set(field)
+	for v := 29; v <= 31; v++ {
+		if days.Bit(domClampBit(v)) > 0 {
+			return "", fmt.Errorf("EventBridge schedules can't clamp days of the month: day %d", v)
+		}
+	}
+	if lastBits(days)&0x7F != 0 {
+		return "", fmt.Errorf("EventBridge schedules can't run days before the last of the month")
+	}
+	days, weekdays := splitWeekdays(Dom, days)
+	last := days.Bit(55) > 0
+	days.SetBit(days, 55, 0)
+	days.SetBit(days, maxBits, 0)
+
+	var tokens []string
+	if days.Sign() > 0 {
+		tokens = append(tokens, toAWSField(days, dom))
+	}
+	if last {
+		tokens = append(tokens, "L")
+	}
+	for _, weekday := range weekdays {
+		tokens = append(tokens, strings.ToUpper(weekday))
+	}
+	return strings.Join(tokens, ","), nil
+}
+
+// toAWSDow formats the day of week field, renumbered from 1 (Sunday) to 7,
+// with the last ("6L") and nth ("6#3") weekdays of the month after the rest.
+func toAWSDow(field *big.Int) string {
+	days := new(big.Int)
+	var special []string
+	for w := 0; w <= 6; w++ {
+		if field.Bit(w) > 0 {
+			days.SetBit(days, w+1, 1)
+		}
+		if field.Bit(49+w) > 0 {
+			special = append(special, strconv.Itoa(w+1)+"L")
+		}
+	}
+	for n := 1; n <= 5; n++ {
+		for w := 0; w <= 6; w++ {
+			if field.Bit(nthWeekdayBit(n, w)) > 0 {
+				special = append(special, fmt.Sprintf("%d#%d", w+1, n))
+			}
+		}
+	}
+	var tokens []string
+	if days.Sign() > 0 {
+		tokens = append(tokens, toAWSField(days, bounds{1, 7, nil}))
+	}
+	return strings.Join(append(tokens, special...), ",")
+}
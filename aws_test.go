@@ -0,0 +1,183 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAWS(t *testing.T) {
+	utc := func(day, hour, min int) time.Time {
+		return time.Date(2024, 3, day, hour, min, 0, 0, time.UTC)
+	}
+	// From is Friday 2024-03-01 at 00:00 UTC.
+	from := utc(1, 0, 0)
+
+	// The examples of the EventBridge documentation, and some more.
+	tests := []struct {
+		expr     string
+		expected []time.Time
+	}{
+		// Run at 10:00 am (UTC) every day.
+		{"cron(0 10 * * ? *)", []time.Time{utc(1, 10, 0), utc(2, 10, 0), utc(3, 10, 0)}},
+		// Run at 12:15 pm (UTC) every day.
+		{"cron(15 12 * * ? *)", []time.Time{utc(1, 12, 15), utc(2, 12, 15)}},
+		// Run at 6:00 pm (UTC) every Monday through Friday.
+		{"cron(0 18 ? * MON-FRI *)", []time.Time{utc(1, 18, 0), utc(4, 18, 0), utc(5, 18, 0)}},
+		// Run at 8:00 am (UTC) every 1st day of the month.
+		{"cron(0 8 1 * ? *)", []time.Time{utc(1, 8, 0), time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC)}},
+		// Run every 15 minutes.
+		{"cron(0/15 * * * ? *)", []time.Time{utc(1, 0, 15), utc(1, 0, 30), utc(1, 0, 45), utc(1, 1, 0)}},
+		// Run every 10 minutes Monday through Friday.
+		{"cron(0/10 * ? * MON-FRI *)", []time.Time{utc(1, 0, 10), utc(1, 0, 20)}},
+		// Run every 5 minutes Monday through Friday between 8:00 am and 5:55 pm (UTC).
+		{"cron(0/5 8-17 ? * MON-FRI *)", []time.Time{utc(1, 8, 0), utc(1, 8, 5)}},
+		// Days of the week are numbered from Sunday.
+		{"cron(0 9 ? * 2,7 *)", []time.Time{utc(2, 9, 0), utc(4, 9, 0), utc(9, 9, 0)}},
+		{"cron(0 9 ? * 1-2 *)", []time.Time{utc(3, 9, 0), utc(4, 9, 0), utc(10, 9, 0)}},
+		// The last day of the month, and the last Friday of the month.
+		{"cron(0 12 L * ? 2024)", []time.Time{utc(31, 12, 0), time.Date(2024, 4, 30, 12, 0, 0, 0, time.UTC)}},
+		{"cron(0 12 ? * 6L 2024-2025)", []time.Time{utc(29, 12, 0), time.Date(2024, 4, 26, 12, 0, 0, 0, time.UTC)}},
+		// L alone in the day of week is Saturday.
+		{"cron(0 12 ? * L *)", []time.Time{utc(2, 12, 0), utc(9, 12, 0)}},
+		// Run at 9:00 am (UTC) on the first Monday of the month.
+		{"cron(0 9 ? * 2#1 *)", []time.Time{utc(4, 9, 0), time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)}},
+		// The weekday nearest the 16th (a Saturday), and the last weekday.
+		{"cron(0 9 16W * ? *)", []time.Time{utc(15, 9, 0), time.Date(2024, 4, 16, 9, 0, 0, 0, time.UTC)}},
+		{"cron(0 9 LW * ? *)", []time.Time{utc(29, 9, 0), time.Date(2024, 4, 30, 9, 0, 0, 0, time.UTC)}},
+	}
+	for _, c := range tests {
+		sched, err := ParseAWS(c.expr)
+		if err != nil {
+			t.Errorf("%s: %v", c.expr, err)
+			continue
+		}
+		next := from
+		for _, expected := range c.expected {
+			if next = sched.Next(next); !next.Equal(expected) {
+				t.Errorf("%s: expected %v, got %v", c.expr, expected, next)
+				break
+			}
+		}
+	}
+
+	for expr, expected := range map[string]time.Duration{
+		"rate(1 minute)":   time.Minute,
+		"rate(5 minutes)":  5 * time.Minute,
+		"rate(1 hour)":     time.Hour,
+		"rate(12 hours)":   12 * time.Hour,
+		"rate(7 days)":     7 * 24 * time.Hour,
+		" rate(1 day)   ":  24 * time.Hour,
+		"rate( 2  hours )": 2 * time.Hour,
+	} {
+		sched, err := ParseAWS(expr)
+		if err != nil {
+			t.Errorf("%s: %v", expr, err)
+			continue
+		}
+		if sched != Every(expected) {
+			t.Errorf("%s: expected every %v, got %v", expr, expected, sched)
+		}
+	}
+}
+
+func TestParseAWSErrors(t *testing.T) {
+	for _, expr := range []string{
+		"0 10 * * ? *",
+		"cron(0 10 * * ?)",
+		"cron(0 10 * * * *)",
+		"cron(0 10 ? * ? *)",
+		"cron(0 10 ? * 0 *)",
+		"cron(0 10 ? * 8 *)",
+		"cron(0 9 ? * 2#6 *)",
+		"cron(0 9 32W * ? *)",
+		"cron(0 9 * * ? 2150)",
+		"cron(60 9 * * ? *)",
+		"rate(0 minutes)",
+		"rate(-5 minutes)",
+		"rate(1 minutes)",
+		"rate(5 minute)",
+		"rate(5 seconds)",
+		"rate(5)",
+	} {
+		if _, err := ParseAWS(expr); err == nil {
+			t.Errorf("%s: expected an error", expr)
+		}
+	}
+}
+
+func TestToAWS(t *testing.T) {
+	for _, expr := range []string{
+		"cron(0 10 * * ? *)",
+		"cron(15 12 * * ? *)",
+		"cron(0 18 ? * 2-6 *)",
+		"cron(0 8 1 * ? *)",
+		"cron(0/15 * * * ? *)",
+		"cron(0/5 8-17 ? * 2-6 *)",
+		"cron(0 12 L * ? 2024)",
+		"cron(0 12 1,15,L 1/3 ? 2024-2030)",
+		"cron(0 12 ? * 6L *)",
+		"cron(30 6 ? * 1,7 *)",
+		"cron(0 9 ? * 2#1,6#3 *)",
+		"cron(0 9 1,15W,LW * ? *)",
+	} {
+		sched, err := ParseAWS(expr)
+		if err != nil {
+			t.Fatalf("%s: %v", expr, err)
+		}
+		aws, err := ToAWS(sched.(*SpecSchedule))
+		if err != nil {
+			t.Errorf("%s: %v", expr, err)
+			continue
+		}
+		if aws != expr {
+			t.Errorf("expected %s, got %s", expr, aws)
+		}
+	}
+
+	// Standard specs in UTC translate too.
+	for spec, expected := range map[string]string{
+		"TZ=UTC 0 9 * * mon-fri": "cron(0 9 ? * 2-6 *)",
+		"TZ=UTC */20 * 1 * *":    "cron(0/20 * 1 * ? *)",
+		"TZ=UTC 0 0 * * *":       "cron(0 0 * * ? *)",
+		"TZ=UTC 0 0 1-31 * 0,6":  "cron(0 0 * * ? *)",
+		"TZ=UTC 0 0 l * *":       "cron(0 0 L * ? *)",
+		"TZ=Etc/UTC 0 0 1 1 *":   "cron(0 0 1 1 ? *)",
+		"TZ=UTC 0 0 * * 5l,sat":  "cron(0 0 ? * 7,6L *)",
+		"TZ=UTC 0 0 1,2,3 */2 *": "cron(0 0 1-3 1/2 ? *)",
+	} {
+		aws, err := ToAWS(mustParse(t, spec).(*SpecSchedule))
+		if err != nil {
+			t.Errorf("%s: %v", spec, err)
+			continue
+		}
+		if aws != expected {
+			t.Errorf("%s: expected %s, got %s", spec, expected, aws)
+		}
+	}
+
+	withSeconds, err := NewParser(Second | Minute | Hour | Dom | Month | Dow).Parse("TZ=UTC 30 0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	both, err := NewParser(Minute | Hour | Dom | Month | Dow | DomAndDow).Parse("TZ=UTC 0 9 1-7 * MON")
+	if err != nil {
+		t.Fatal(err)
+	}
+	excluded, err := excludeParser.Parse("TZ=UTC 0 9 * * * !2024-12-25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sched := range []Schedule{
+		mustParse(t, "0 9 * * *"),
+		mustParse(t, "TZ=America/New_York 0 9 * * *"),
+		mustParse(t, "TZ=UTC 0 9 1 * mon"),
+		mustParse(t, "TZ=UTC 0 9 3l * *"),
+		excluded,
+		withSeconds,
+		both,
+	} {
+		if aws, err := ToAWS(sched.(*SpecSchedule)); err == nil {
+			t.Errorf("%s: expected an error, got %s", sched, aws)
+		}
+	}
+}
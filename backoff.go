@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// BackoffSchedule waits longer after each activation, by a constant factor up
+// to a maximum, until it is reset. It suits jobs that retry something until it
+// works: run by a Cron, it is an OutcomeSchedule, so a successful run resets
+// it and a failed one lets the delay keep growing.
+//
+// Each call to Next lengthens the delay, and a reset by one entry's success
+// would shorten another's, so entries mustn't share one.
+type BackoffSchedule struct {
+	base, max time.Duration
+	factor    float64
+
+	mu       sync.Mutex
+	attempt  int       // number of activations since the last reset
+	lastFire time.Time // the last activation, or zero after a reset
+}
+
+// ExponentialBackoffSchedule returns a schedule whose first activation is base
+// after the time given to Next, and each one after that factor times further
+// from the last, up to maxDelay. It panics if base is less than a second,
+// factor is not greater than 1 or maxDelay is less than base.
+func ExponentialBackoffSchedule(base time.Duration, factor float64, maxDelay time.Duration) *BackoffSchedule {
+	if base < time.Second {
+		panic("cron: backoff base delay must be at least a second")
+	}
+	if !(factor > 1) {
+		panic("cron: backoff factor must be greater than 1")
+	}
+	if maxDelay < base {
+		panic("cron: maximum backoff delay must be at least the base delay")
+	}
+	return &BackoffSchedule{base: base, max: maxDelay, factor: factor}
+}
+
+// Next returns the next activation, the current delay after the later of t and
+// the last activation, and lengthens the delay for next time.
+func (s *BackoffSchedule) Next(t time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delay := s.max
+	if d := float64(s.base) * math.Pow(s.factor, float64(s.attempt)); d < float64(s.max) {
+		delay = time.Duration(d)
+		s.attempt++
+	}
+	if s.lastFire.After(t) {
+		t = s.lastFire
+	}
+	s.lastFire = t.Add(delay)
+	return s.lastFire
+}
+
+// NextOK is like Next; there is always a next activation.
+func (s *BackoffSchedule) NextOK(t time.Time) (time.Time, bool) {
+	return s.Next(t), true
+}
+
+// Latest returns the zero time, as activations depend on when Next is called.
+func (s *BackoffSchedule) Latest(t time.Time) time.Time {
+	return time.Time{}
+}
+
+// Reset starts a new cycle, so that the next activation is the base delay
+// after the time given to Next.
+func (s *BackoffSchedule) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempt = 0
+	s.lastFire = time.Time{}
+}
+
+// NotifyFailure continues the current cycle. The delay already grows with each
+// call to Next, so there is nothing more to do; it exists so that a failure
+// leaves the backoff in place where a success would reset it.
+func (s *BackoffSchedule) NotifyFailure() {}
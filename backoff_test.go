@@ -0,0 +1,114 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackoffSchedule(t *testing.T) {
+	sched := ExponentialBackoffSchedule(time.Second, 2, time.Minute)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	expected := []int{1, 2, 4, 8, 16, 32, 60, 60, 60}
+	prev := start
+	for i, seconds := range expected {
+		next := sched.Next(prev)
+		if got := next.Sub(prev); got != time.Duration(seconds)*time.Second {
+			t.Errorf("interval %d: expected %ds, got %v", i, seconds, got)
+		}
+		prev = next
+	}
+
+	// A failure leaves the backoff in place; a reset starts again from the
+	// base delay, after the time given.
+	sched.NotifyFailure()
+	if got := sched.Next(prev).Sub(prev); got != time.Minute {
+		t.Errorf("after a failure: expected 1m, got %v", got)
+	}
+	sched.Reset()
+	later := prev.Add(time.Hour)
+	for i, seconds := range expected[:3] {
+		next := sched.Next(later)
+		if got := next.Sub(later); got != time.Duration(seconds)*time.Second {
+			t.Errorf("after reset, interval %d: expected %ds, got %v", i, seconds, got)
+		}
+		later = next
+	}
+}
+
+func TestBackoffScheduleFromLastActivation(t *testing.T) {
+	sched := ExponentialBackoffSchedule(time.Second, 3, time.Hour)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := sched.Next(start)
+	if second := sched.Next(start); !second.Equal(first.Add(3 * time.Second)) {
+		t.Errorf("expected the delay to follow the last activation, got %v", second)
+	}
+}
+
+func TestBackoffScheduleArguments(t *testing.T) {
+	for _, c := range []struct {
+		base, max time.Duration
+		factor    float64
+	}{
+		{time.Second, time.Minute, 1},
+		{time.Second, time.Minute, 0.5},
+		{time.Minute, time.Second, 2},
+		{time.Millisecond, time.Second, 2},
+		{0, time.Second, 2},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%v, %v, %v: expected a panic", c.base, c.factor, c.max)
+				}
+			}()
+			ExponentialBackoffSchedule(c.base, c.factor, c.max)
+		}()
+	}
+}
+
+func TestBackoffScheduleInCron(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := newFakeClock(start)
+	var (
+		started = make(chan struct{})
+		results = make(chan error)
+	)
+	cron := New(WithChain(), WithLocation(time.UTC))
+	cron.clock = fc
+	cron.Schedule(ExponentialBackoffSchedule(time.Second, 2, time.Minute), fallibleFunc(func() error {
+		started <- struct{}{}
+		return <-results
+	}))
+	cron.Start()
+	defer cron.Stop()
+
+	// Three failures back off from 1s; a success resets to 1s.
+	failed := errors.New("failed")
+	timer := <-fc.timers
+	for i, c := range []struct {
+		delay  time.Duration
+		result error
+	}{
+		{time.Second, failed},
+		{2 * time.Second, failed},
+		{4 * time.Second, failed},
+		{8 * time.Second, nil},
+		{time.Second, nil},
+	} {
+		if expected := fc.Now().Add(c.delay); !timer.deadline.Equal(expected) {
+			t.Fatalf("run %d: expected it at %v, got %v", i, expected, timer.deadline)
+		}
+		fc.advance(timer)
+		<-started
+		results <- c.result
+		timer = <-fc.timers
+	}
+}
+
+// fallibleFunc adapts a func to a FallibleJob.
+type fallibleFunc func() error
+
+func (f fallibleFunc) Run()          { f() }
+func (f fallibleFunc) RunErr() error { return f() }
@@ -10,6 +10,10 @@ import (
 // JobWrapper decorates the given Job with some behavior.
 type JobWrapper func(Job) Job
 
+// EntryJobWrapper decorates the job of a particular entry. It is given a
+// snapshot of the entry with its ID, Schedule, and Job already assigned.
+type EntryJobWrapper func(Entry, Job) Job
+
 // Chain is a sequence of JobWrappers that decorates submitted jobs with
 // cross-cutting behaviors like logging or synchronization.
 type Chain struct {
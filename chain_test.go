@@ -41,6 +41,43 @@ func TestChain(t *testing.T) {
 	}
 }
 
+func TestCronChainOrder(t *testing.T) {
+	var (
+		nums []int
+		ids  []EntryID
+	)
+	recordID := func(e Entry, j Job) Job {
+		ids = append(ids, e.ID)
+		return FuncJob(func() {
+			appendingJob(&nums, 3).Run()
+			j.Run()
+		})
+	}
+	cron := New(
+		WithChain(appendingWrapper(&nums, 1)),
+		WithEntryWrappers(recordID))
+	if err := cron.Use(appendingWrapper(&nums, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	cron.AddJob("@every 1h", appendingJob(&nums, 5), WithEntryChain(appendingWrapper(&nums, 4)))
+	id, _ := cron.AddJob("@every 1h", appendingJob(&nums, 7), WithReplacedChain(appendingWrapper(&nums, 6)))
+
+	for _, e := range cron.Entries() {
+		e.WrappedJob.Run()
+	}
+	if !reflect.DeepEqual(nums, []int{1, 2, 3, 4, 5, 6, 7}) {
+		t.Error("unexpected order of calls:", nums)
+	}
+	if !reflect.DeepEqual(ids, []EntryID{id - 1}) {
+		t.Error("expected entry wrapper to be given the entry ID, got", ids)
+	}
+
+	if err := cron.Use(appendingWrapper(&nums, 8)); err == nil {
+		t.Error("expected an error adding wrappers after entries")
+	}
+}
+
 func TestChainRecover(t *testing.T) {
 	panickingJob := FuncJob(func() {
 		panic("panickingJob panics")
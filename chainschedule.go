@@ -0,0 +1,77 @@
+package cron
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ChainedSchedule activates once for each of its schedules in turn, e.g. to
+// roll a change out to canary, then staging, then production. Its next
+// activation is that of the current schedule, and once that has fallen due, a
+// Cron moves on to the next, removing the entry with the reason
+// RemovedExhausted after the last unless WithExhaustedRemoval(false) says
+// otherwise.
+//
+// The Cron moves the chain on when the entry runs, so entries sharing one
+// would each skip the schedules the others used; give each its own.
+type ChainedSchedule struct {
+	schedules []Schedule
+	index     int64 // of the current schedule, read and written atomically
+}
+
+// ScheduleChain returns a schedule that activates at the next activation of
+// each of the given schedules in turn.
+func ScheduleChain(schedules ...Schedule) *ChainedSchedule {
+	return &ChainedSchedule{schedules: schedules}
+}
+
+// Next returns the current schedule's next activation after the given time,
+// or the zero time once every schedule has been used. A schedule with no next
+// activation is used up, so the chain moves on to the one after it.
+func (s *ChainedSchedule) Next(t time.Time) time.Time {
+	for {
+		i := atomic.LoadInt64(&s.index)
+		if i >= int64(len(s.schedules)) {
+			return time.Time{}
+		}
+		if next := s.schedules[i].Next(t); !next.IsZero() {
+			return next
+		}
+		atomic.CompareAndSwapInt64(&s.index, i, i+1)
+	}
+}
+
+// NextOK is like Next, but reports whether there is a next activation rather
+// than returning the zero time.
+func (s *ChainedSchedule) NextOK(t time.Time) (time.Time, bool) {
+	next := s.Next(t)
+	return next, !next.IsZero()
+}
+
+// Latest returns the zero time: which activations came before t depends on
+// when the chain moved on from each schedule.
+func (s *ChainedSchedule) Latest(t time.Time) time.Time {
+	return time.Time{}
+}
+
+// Reset starts the chain over from its first schedule. A Cron running it
+// picks this up the next time it computes the entry's next activation.
+func (s *ChainedSchedule) Reset() {
+	atomic.StoreInt64(&s.index, 0)
+}
+
+// Exhausted reports whether every schedule in the chain has been used.
+func (s *ChainedSchedule) Exhausted() bool {
+	return atomic.LoadInt64(&s.index) >= int64(len(s.schedules))
+}
+
+// advance moves on to the next schedule, once the current one's activation
+// has fallen due.
+func (s *ChainedSchedule) advance() {
+	for {
+		i := atomic.LoadInt64(&s.index)
+		if i >= int64(len(s.schedules)) || atomic.CompareAndSwapInt64(&s.index, i, i+1) {
+			return
+		}
+	}
+}
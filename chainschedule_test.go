@@ -0,0 +1,122 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduleChain(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	phase := func(n int) time.Time { return start.Add(time.Duration(n) * time.Minute) }
+	fc := newFakeClock(start)
+	var (
+		mu      sync.Mutex
+		ran     []time.Time
+		removed = make(chan RemovalReason, 1)
+	)
+	cron := New(WithLocation(time.UTC), WithLogger(DiscardLogger),
+		WithEntryHooks(EntryHooks{OnRemoved: func(e Entry, reason RemovalReason) { removed <- reason }}))
+	cron.clock = fc
+	chain := ScheduleChain(timesSchedule{phase(1)}, timesSchedule{phase(2)}, timesSchedule{phase(3)})
+	cron.Schedule(chain, FuncJob(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		ran = append(ran, fc.Now())
+	}))
+	cron.Start()
+	defer cron.Stop()
+
+	runs := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(ran)
+	}
+	timer := <-fc.timers
+	for i := 1; i <= 3; i++ {
+		if !timer.deadline.Equal(phase(i)) {
+			t.Fatalf("expected phase %d at %v, got %v", i, phase(i), timer.deadline)
+		}
+		fc.advance(timer)
+		waitFor(t, func() bool { return runs() == i })
+		// The run loop checks whether the entry is exhausted once the run is
+		// over.
+		timer = <-fc.timers
+	}
+	if reason := <-removed; reason != RemovedExhausted {
+		t.Errorf("expected the entry to be removed as exhausted, got %s", reason)
+	}
+	if entries := cron.Entries(); len(entries) != 0 {
+		t.Errorf("expected no entries, got %v", entries)
+	}
+	mu.Lock()
+	if !equalTimes(ran, []time.Time{phase(1), phase(2), phase(3)}) {
+		t.Errorf("expected each phase to run once in order, got %v", ran)
+	}
+	mu.Unlock()
+
+	if !chain.Exhausted() || !chain.Next(start).IsZero() {
+		t.Error("expected the chain to be exhausted")
+	}
+	chain.Reset()
+	if next := chain.Next(start); !next.Equal(phase(1)) {
+		t.Errorf("expected the chain to start over, got %v", next)
+	}
+}
+
+func TestScheduleChainConcurrentReset(t *testing.T) {
+	chain := ScheduleChain(Every(time.Minute), Every(time.Hour))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				chain.advance()
+				chain.Next(time.Now())
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				chain.Reset()
+			}
+		}()
+	}
+	wg.Wait()
+	chain.Reset()
+	chain.advance()
+	now := time.Now()
+	if next := chain.Next(now); !next.Equal(Every(time.Hour).Next(now)) {
+		t.Errorf("expected the second schedule, got %v", next)
+	}
+}
+
+func TestScheduleChainSkipsSpentSchedules(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	past := timesSchedule{start.Add(-time.Hour)}
+	chain := ScheduleChain(past, timesSchedule{start.Add(time.Minute)}, past)
+	if next := chain.Next(start); !next.Equal(start.Add(time.Minute)) {
+		t.Errorf("expected the spent first schedule to be skipped, got %v", next)
+	}
+
+	// Once the last schedule with an activation has run, the entry goes.
+	removed := make(chan RemovalReason, 1)
+	cron, fc := newFakeCron(start,
+		WithEntryHooks(EntryHooks{OnRemoved: func(e Entry, reason RemovalReason) { removed <- reason }}))
+	cron.Schedule(chain, FuncJob(func() {}))
+	cron.Start()
+	defer cron.Stop()
+	fc.advance(<-fc.timers)
+	select {
+	case reason := <-removed:
+		if reason != RemovedExhausted {
+			t.Errorf("expected the entry to be removed as exhausted, got %s", reason)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the entry to be removed")
+	}
+	if !chain.Exhausted() {
+		t.Error("expected the chain to be exhausted")
+	}
+}
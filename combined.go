@@ -0,0 +1,89 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// CombinedSchedule is the union of a list of specs: it activates whenever any
+// of them does, and only once when several activate at the same time. It is
+// encoded in JSON as the array of its specs.
+type CombinedSchedule struct {
+	exprs []string
+	union ScheduleComposite
+}
+
+// ParseMultiple parses each spec as ParseStandard does, and returns their
+// union. An error names the spec that failed to parse and its position in
+// the list, counting from 1.
+func ParseMultiple(exprs ...string) (*CombinedSchedule, error) {
+	c := &CombinedSchedule{
+		exprs: append([]string(nil), exprs...),
+		union: ScheduleComposite{Mode: OrMode, Schedules: make([]Schedule, len(exprs))},
+	}
+	for i, expr := range exprs {
+		sched, err := ParseStandard(expr)
+		if err != nil {
+			return nil, fmt.Errorf("expression %d ('%s'): %w", i+1, expr, err)
+		}
+		c.union.Schedules[i] = sched
+	}
+	return c, nil
+}
+
+// MustParseMultiple is like ParseMultiple but panics if a spec cannot be
+// parsed. It simplifies the initialization of global variables.
+func MustParseMultiple(exprs ...string) *CombinedSchedule {
+	c, err := ParseMultiple(exprs...)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Expressions returns the specs the schedule was parsed from, in order.
+func (c *CombinedSchedule) Expressions() []string {
+	return append([]string(nil), c.exprs...)
+}
+
+// Next returns the earliest activation of any spec after the given time, or
+// the zero time if none has one.
+func (c *CombinedSchedule) Next(t time.Time) time.Time {
+	return c.union.Next(t)
+}
+
+// NextOK is like Next, but reports whether an activation was found.
+func (c *CombinedSchedule) NextOK(t time.Time) (time.Time, bool) {
+	return c.union.NextOK(t)
+}
+
+// Latest returns the latest activation of any spec at or before the given
+// time, or the zero time if none has one.
+func (c *CombinedSchedule) Latest(t time.Time) time.Time {
+	return c.union.Latest(t)
+}
+
+// MarshalJSON encodes the schedule as the array of its specs.
+func (c *CombinedSchedule) MarshalJSON() ([]byte, error) {
+	exprs := c.exprs
+	if exprs == nil {
+		exprs = []string{}
+	}
+	return json.Marshal(exprs)
+}
+
+// UnmarshalJSON decodes an array of specs, and parses them as ParseMultiple
+// does.
+func (c *CombinedSchedule) UnmarshalJSON(data []byte) error {
+	var exprs []string
+	if err := json.Unmarshal(data, &exprs); err != nil {
+		return err
+	}
+	parsed, err := ParseMultiple(exprs...)
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
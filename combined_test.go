@@ -0,0 +1,96 @@
+package cron
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMultiple(t *testing.T) {
+	c, err := ParseMultiple("TZ=UTC 0 9 * * mon-fri", "TZ=UTC 30 10 * * sat,sun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC) // a Friday
+	for _, expected := range []time.Time{
+		time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 2, 10, 30, 0, 0, time.UTC),
+		time.Date(2024, 3, 3, 10, 30, 0, 0, time.UTC),
+		time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+	} {
+		if from = c.Next(from); !from.Equal(expected) {
+			t.Errorf("expected %v, got %v", expected, from)
+		}
+	}
+	if latest, expected := c.Latest(from.Add(-time.Second)), time.Date(2024, 3, 3, 10, 30, 0, 0, time.UTC); !latest.Equal(expected) {
+		t.Errorf("expected latest %v, got %v", expected, latest)
+	}
+
+	// Activations shared by several specs are yielded once.
+	overlap := MustParseMultiple("0 0 * * *", "0 0,12 * * *")
+	midnight := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	if next := overlap.Next(midnight); !next.Equal(midnight.Add(12 * time.Hour)) {
+		t.Errorf("expected noon, got %v", next)
+	}
+}
+
+func TestParseMultipleErrors(t *testing.T) {
+	_, err := ParseMultiple("0 9 * * *", "bad", "0 61 * * *")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.HasPrefix(err.Error(), "expression 2 ('bad'): ") {
+		t.Errorf("expected the second expression to be named, got %v", err)
+	}
+
+	// The parser's error is wrapped.
+	_, cause := ParseStandard("61 9 * * *")
+	_, err = ParseMultiple("0 9 * * *", "61 9 * * *")
+	if errors.Unwrap(err) == nil || errors.Unwrap(err).Error() != cause.Error() {
+		t.Errorf("expected %v to wrap %v", err, cause)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustParseMultiple to panic")
+		}
+	}()
+	MustParseMultiple("0 9 * * *", "bad")
+}
+
+func TestCombinedScheduleJSON(t *testing.T) {
+	exprs := []string{"0 9 * * mon-fri", "@every 90m", "CRON_TZ=Asia/Tokyo 0 0 1 * *"}
+	c := MustParseMultiple(exprs...)
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `["0 9 * * mon-fri","@every 90m","CRON_TZ=Asia/Tokyo 0 0 1 * *"]`; string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	var decoded CombinedSchedule
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got := decoded.Expressions(); strings.Join(got, "|") != strings.Join(exprs, "|") {
+		t.Errorf("expected %v, got %v", exprs, got)
+	}
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		next, expected := decoded.Next(from), c.Next(from)
+		if !next.Equal(expected) {
+			t.Errorf("expected %v, got %v", expected, next)
+		}
+		from = expected
+	}
+
+	if err := json.Unmarshal([]byte(`["0 9 * * *", "bad"]`), &decoded); err == nil {
+		t.Error("expected an error decoding a bad spec")
+	}
+	if data, _ := json.Marshal(MustParseMultiple()); string(data) != "[]" {
+		t.Errorf("expected an empty array, got %s", data)
+	}
+}
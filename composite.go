@@ -0,0 +1,110 @@
+package cron
+
+import "time"
+
+// CompositeMode determines how a ScheduleComposite combines its members.
+type CompositeMode int
+
+const (
+	// AndMode activates only at times when every member activates.
+	AndMode CompositeMode = iota
+
+	// OrMode activates whenever any member activates. Members that activate
+	// at the same instant make a single activation, so stepping through the
+	// composite with Next or Latest never yields the same time twice.
+	OrMode
+)
+
+// ScheduleComposite combines arbitrary schedules at run time.
+//
+// In AndMode, unlike combining the fields of two SpecSchedules bit by bit,
+// each member is consulted in turn, so the result respects every member's own
+// matching rules. For example, "0 0 1 * *" and "0 0 * * MON" combine to
+// midnight on a Monday the 1st, whereas ANDing their fields leaves neither
+// day field a wildcard, which cron reads as "the 1st or a Monday".
+type ScheduleComposite struct {
+	Mode      CompositeMode
+	Schedules []Schedule
+}
+
+// Next returns the next activation after the given time, or the zero time if
+// there is none. In OrMode, it is the earliest of the members' next
+// activations. In AndMode, the search gives up after five years.
+func (s ScheduleComposite) Next(t time.Time) time.Time {
+	if len(s.Schedules) == 0 {
+		return time.Time{}
+	}
+	if s.Mode == OrMode {
+		var next time.Time
+		for _, sched := range s.Schedules {
+			if n := sched.Next(t); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+				next = n
+			}
+		}
+		return next
+	}
+
+	// Leapfrog: move the candidate to each member's next activation at or after
+	// it, until all of them agree.
+	yearLimit := t.Year() + 5
+	next := s.Schedules[0].Next(t)
+	for !next.IsZero() && next.Year() <= yearLimit {
+		agreed := true
+		for _, sched := range s.Schedules {
+			n := sched.Next(next.Add(-time.Nanosecond))
+			if n.IsZero() {
+				return time.Time{}
+			}
+			if !n.Equal(next) {
+				next, agreed = n, false
+			}
+		}
+		if agreed {
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+// NextOK is like Next, but reports whether an activation was found.
+func (s ScheduleComposite) NextOK(t time.Time) (time.Time, bool) {
+	next := s.Next(t)
+	return next, !next.IsZero()
+}
+
+// Latest returns the latest activation at or before the given time, or the
+// zero time if there is none. In OrMode, it is the latest of the members'
+// latest activations. In AndMode, the search gives up after five years.
+func (s ScheduleComposite) Latest(t time.Time) time.Time {
+	if len(s.Schedules) == 0 {
+		return time.Time{}
+	}
+	if s.Mode == OrMode {
+		var latest time.Time
+		for _, sched := range s.Schedules {
+			if l := sched.Latest(t); l.After(latest) {
+				latest = l
+			}
+		}
+		return latest
+	}
+
+	yearLimit := t.Year() - 5
+	latest := s.Schedules[0].Latest(t)
+	for !latest.IsZero() && latest.Year() >= yearLimit {
+		agreed := true
+		for _, sched := range s.Schedules {
+			l := sched.Latest(latest)
+			if l.IsZero() {
+				return time.Time{}
+			}
+			if !l.Equal(latest) {
+				latest, agreed = l, false
+			}
+		}
+		if agreed {
+			return latest
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,101 @@
+package cron
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestScheduleComposite(t *testing.T) {
+	first, _ := ParseStandard("0 0 1 * *")
+	monday, _ := ParseStandard("0 0 * * mon")
+	a, b := first.(*SpecSchedule), monday.(*SpecSchedule)
+
+	// ANDing the fields drops both day wildcards, which turns the day check
+	// into "the 1st or a Monday".
+	and := func(x, y *big.Int) *big.Int { return new(big.Int).And(x, y) }
+	structural := &SpecSchedule{
+		Second: and(a.Second, b.Second), Minute: and(a.Minute, b.Minute),
+		Hour: and(a.Hour, b.Hour), Dom: and(a.Dom, b.Dom),
+		Month: and(a.Month, b.Month), Dow: and(a.Dow, b.Dow),
+		Year: and(a.Year, b.Year), Location: time.Local,
+	}
+	composite := ScheduleComposite{Mode: AndMode, Schedules: []Schedule{a, b}}
+
+	from := time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)
+	if next, expected := structural.Next(from), time.Date(2024, 1, 8, 0, 0, 0, 0, time.Local); !next.Equal(expected) {
+		t.Errorf("structural: expected %v, got %v", expected, next)
+	}
+	// The next Monday the 1st is in April.
+	expected := time.Date(2024, 4, 1, 0, 0, 0, 0, time.Local)
+	if next := composite.Next(from); !next.Equal(expected) {
+		t.Errorf("and: expected %v, got %v", expected, next)
+	}
+	if latest := composite.Latest(expected.Add(time.Hour)); !latest.Equal(expected) {
+		t.Errorf("and: expected latest %v, got %v", expected, latest)
+	}
+
+	// Members of different types.
+	daily := EveryNDays(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), 3, 0, 0, 0)
+	mixed := ScheduleComposite{Mode: AndMode, Schedules: []Schedule{monday, daily}}
+	if next, expected := mixed.Next(from), time.Date(2024, 1, 22, 0, 0, 0, 0, time.Local); !next.Equal(expected) {
+		t.Errorf("mixed: expected %v, got %v", expected, next)
+	}
+
+	never := ScheduleComposite{Mode: AndMode, Schedules: []Schedule{first, mustParse(t, "0 0 2 * *")}}
+	if next := never.Next(from); !next.IsZero() {
+		t.Errorf("expected no activation, got %v", next)
+	}
+
+	or := ScheduleComposite{Mode: OrMode, Schedules: []Schedule{first, monday}}
+	for _, expected := range []time.Time{
+		time.Date(2024, 1, 8, 0, 0, 0, 0, time.Local),
+		time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local),
+		time.Date(2024, 1, 22, 0, 0, 0, 0, time.Local),
+		time.Date(2024, 1, 29, 0, 0, 0, 0, time.Local),
+		time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local),
+	} {
+		if from = or.Next(from); !from.Equal(expected) {
+			t.Errorf("or: expected %v, got %v", expected, from)
+		}
+	}
+}
+
+func mustParse(t *testing.T, spec string) Schedule {
+	t.Helper()
+	sched, err := ParseStandard(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sched
+}
+
+func TestScheduleCompositeOrTies(t *testing.T) {
+	daily := mustParse(t, "0 0 * * *")
+	twice := mustParse(t, "0 0,12 * * *")
+	or := ScheduleComposite{Mode: OrMode, Schedules: []Schedule{daily, twice, daily}}
+
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	var expected []time.Time
+	for day := 0; day < 5; day++ {
+		midnight := start.AddDate(0, 0, day)
+		expected = append(expected, midnight, midnight.Add(12*time.Hour))
+	}
+
+	var forward []time.Time
+	for next := or.Next(start.Add(-time.Nanosecond)); len(forward) < len(expected); next = or.Next(next) {
+		forward = append(forward, next)
+	}
+	if !equalTimes(forward, expected) {
+		t.Errorf("next: expected %v, got %v", expected, forward)
+	}
+
+	// Latest is inclusive, so step back from just before each activation.
+	var backward []time.Time
+	for latest := or.Latest(expected[len(expected)-1]); len(backward) < len(expected); latest = or.Latest(latest.Add(-time.Nanosecond)) {
+		backward = append([]time.Time{latest}, backward...)
+	}
+	if !equalTimes(backward, expected) {
+		t.Errorf("latest: expected %v, got %v", expected, backward)
+	}
+}
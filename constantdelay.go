@@ -26,6 +26,17 @@ func (schedule ConstantDelaySchedule) Next(t time.Time) time.Time {
 	return t.Add(schedule.Delay - time.Duration(t.Nanosecond())*time.Nanosecond)
 }
 
+// NextOK returns the next time this should be run, which always exists.
+func (schedule ConstantDelaySchedule) NextOK(t time.Time) (time.Time, bool) {
+	return schedule.Next(t), true
+}
+
 func (schedule ConstantDelaySchedule) Latest(t time.Time) time.Time {
 	return time.Time{}
 }
+
+// IsPeriodic reports whether activations are evenly spaced, which they
+// always are.
+func (schedule ConstantDelaySchedule) IsPeriodic() bool {
+	return true
+}
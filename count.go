@@ -0,0 +1,166 @@
+package cron
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultBetweenLimit caps the number of activations Between and MissedSince
+// return, so that a mistakenly broad window, such as fifty years of a
+// schedule that runs every second, can't exhaust memory. BetweenLimit takes
+// another cap.
+const DefaultBetweenLimit = 1000000
+
+// ErrTruncated is returned by Between, BetweenLimit and MissedSince, along
+// with the first activations up to the cap, when the window holds more.
+var ErrTruncated = errors.New("cron: too many activations, result truncated")
+
+// Between returns the activations at or after start and before end, in order.
+// If there are more than DefaultBetweenLimit, it returns the first
+// DefaultBetweenLimit of them and ErrTruncated.
+func (s *SpecSchedule) Between(start, end time.Time) ([]time.Time, error) {
+	return s.BetweenLimit(start, end, DefaultBetweenLimit)
+}
+
+// BetweenLimit is like Between, but caps the result at limit activations
+// rather than DefaultBetweenLimit. Zero or less means no cap.
+func (s *SpecSchedule) BetweenLimit(start, end time.Time, limit int) ([]time.Time, error) {
+	var times []time.Time
+	for t := s.Next(start.Add(-time.Nanosecond)); !t.IsZero() && t.Before(end); t = s.Next(t) {
+		if limit > 0 && len(times) == limit {
+			return times, ErrTruncated
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
+
+// MissedSince returns the activations after lastRun and up to and including
+// now, in order, for catching up after downtime: a caller may run each of
+// them, or only the last. Like Between, it returns ErrTruncated with the
+// first DefaultBetweenLimit of them if there are more.
+func (s *SpecSchedule) MissedSince(lastRun, now time.Time) ([]time.Time, error) {
+	return s.Between(lastRun.Add(time.Nanosecond), now.Add(time.Nanosecond))
+}
+
+// ResolveOnWake handles waking from sleep, as on a mobile device, given the
+// activation that was pending when the device slept and the time it woke. It
+// reports whether target has passed and so should fire now, and returns the
+// next activation after now to wait for. A zero target never fires. Any
+// activations between target and now are skipped; use MissedSince to run
+// those too.
+func (s *SpecSchedule) ResolveOnWake(target, now time.Time) (fire bool, next time.Time) {
+	return !target.IsZero() && !now.Before(target), s.Next(now)
+}
+
+// PrevN returns the n latest activations at or before t, latest first: the
+// times given by calling Latest(t), then Latest again from just before each
+// result, but without searching afresh for each. Once an activation is found,
+// the others on the same day are read off the time of day fields, so only
+// the last activation of each earlier day is searched for. Days on which the
+// clocks change are stepped through one activation at a time. Fewer than n
+// times are returned if the schedule runs out.
+func (s *SpecSchedule) PrevN(t time.Time, n int) []time.Time {
+	loc := s.EffectiveLocation()
+	if loc == time.Local {
+		loc = t.Location()
+	}
+	millis, maxMilli := s.milliseconds(), 0
+	if s.Millisecond != nil {
+		maxMilli = 999
+	}
+	var times []time.Time
+	for latest := s.Latest(t); !latest.IsZero() && len(times) < n; {
+		l := latest.In(loc)
+		year, month, day := l.Date()
+		midnight := time.Date(year, month, day, 0, 0, 0, 0, loc)
+		if time.Date(year, month, day+1, 0, 0, 0, 0, loc).Sub(midnight) != 24*time.Hour {
+			times = append(times, latest)
+			latest = s.Latest(latest.Add(-s.granularity()))
+			continue
+		}
+
+		// Every time of day up to the activation found, latest first.
+		for hour := l.Hour(); hour >= 0 && len(times) < n; hour-- {
+			if s.Hour.Bit(hour) == 0 {
+				continue
+			}
+			lastHour := hour == l.Hour()
+			for minute := upTo(lastHour, l.Minute(), 59); minute >= 0 && len(times) < n; minute-- {
+				if s.Minute.Bit(minute) == 0 {
+					continue
+				}
+				lastMinute := lastHour && minute == l.Minute()
+				for second := upTo(lastMinute, l.Second(), 59); second >= 0 && len(times) < n; second-- {
+					if s.Second.Bit(second) == 0 {
+						continue
+					}
+					lastSecond := lastMinute && second == l.Second()
+					for milli := upTo(lastSecond, l.Nanosecond()/int(time.Millisecond), maxMilli); milli >= 0 && len(times) < n; milli-- {
+						if millis.Bit(milli) > 0 {
+							at := time.Date(year, month, day, hour, minute, second, milli*int(time.Millisecond), loc)
+							times = append(times, at.In(t.Location()))
+						}
+					}
+				}
+			}
+		}
+		latest = s.Latest(midnight.Add(-time.Nanosecond))
+	}
+	return times
+}
+
+// upTo returns v if bounded, and max otherwise.
+func upTo(bounded bool, v, max int) int {
+	if bounded {
+		return v
+	}
+	return max
+}
+
+// Count returns the number of activations Between would return, without
+// keeping them. It computes each one, so it takes time in proportion to the
+// result.
+func (s *SpecSchedule) Count(start, end time.Time) int {
+	n := 0
+	for t := s.Next(start.Add(-time.Nanosecond)); !t.IsZero() && t.Before(end); t = s.Next(t) {
+		n++
+	}
+	return n
+}
+
+// Density returns the fraction of the seconds from start to end at which the
+// schedule activates: 1 for a schedule that runs every second, and 1/86400
+// for one that runs once a day. It is a fraction of time, between 0 and 1,
+// rather than a rate; for activations per hour, divide Count by the hours in
+// the window instead.
+//
+// It returns 0 if end is not after start. For a window shorter than a second,
+// it returns 1 if the second containing start is an activation and 0
+// otherwise.
+func (s *SpecSchedule) Density(start, end time.Time) float64 {
+	if !end.After(start) {
+		return 0
+	}
+	if end.Sub(start) < time.Second {
+		second := start.Truncate(time.Second)
+		if s.Next(second.Add(-time.Nanosecond)).Equal(second) {
+			return 1
+		}
+		return 0
+	}
+	density := float64(s.Count(start, end)) / end.Sub(start).Seconds()
+	if density > 1 {
+		return 1
+	}
+	return density
+}
+
+// ActivationsPerYear returns the number of activations in the given calendar
+// year, in the schedule's location. Like Count, it computes each one rather
+// than collecting them with Between, so that dense schedules don't allocate a
+// year's worth of times.
+func (s *SpecSchedule) ActivationsPerYear(refYear int) int {
+	start := time.Date(refYear, time.January, 1, 0, 0, 0, 0, s.EffectiveLocation())
+	return s.Count(start, start.AddDate(1, 0, 0))
+}
@@ -0,0 +1,300 @@
+package cron
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestCount(t *testing.T) {
+	sched := mustParse(t, "TZ=UTC 0 * * * *").(*SpecSchedule)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		start, end time.Time
+		expected   int
+	}{
+		{start, start.Add(24 * time.Hour), 24},
+		{start, start.Add(time.Hour), 1},
+		{start.Add(time.Second), start.Add(time.Hour), 0},
+		{start, start.Add(time.Hour + time.Nanosecond), 2},
+		{start, start, 0},
+		{start.Add(time.Hour), start, 0},
+	}
+	for _, c := range tests {
+		if got := sched.Count(c.start, c.end); got != c.expected {
+			t.Errorf("%v to %v: expected %d, got %d", c.start, c.end, c.expected, got)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	sched := mustParse(t, "TZ=UTC 0 9,17 * * *").(*SpecSchedule)
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	expected := []time.Time{
+		start,
+		start.Add(8 * time.Hour),
+		start.Add(24 * time.Hour),
+	}
+	got, err := sched.Between(start, start.Add(32*time.Hour))
+	if err != nil || len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range got {
+		if !got[i].Equal(expected[i]) {
+			t.Errorf("%d: expected %v, got %v", i, expected[i], got[i])
+		}
+	}
+	if got, err := sched.Between(start, start); got != nil || err != nil {
+		t.Errorf("expected nil for an empty window, got %v, %v", got, err)
+	}
+}
+
+func TestBetweenTruncated(t *testing.T) {
+	sched, err := NewParser(Second | Minute | Hour | Dom | Month | Dow).Parse("TZ=UTC * * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	every := sched.(*SpecSchedule)
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	got, err := every.BetweenLimit(start, start.AddDate(50, 0, 0), 1000)
+	if err != ErrTruncated {
+		t.Errorf("expected ErrTruncated, got %v", err)
+	}
+	if len(got) != 1000 {
+		t.Fatalf("expected 1000 activations, got %d", len(got))
+	}
+	if !got[0].Equal(start) || !got[len(got)-1].Equal(start.Add(999*time.Second)) {
+		t.Errorf("expected the first activations, got %v to %v", got[0], got[len(got)-1])
+	}
+
+	// Exactly limit activations fit.
+	if got, err := every.BetweenLimit(start, start.Add(1000*time.Second), 1000); err != nil || len(got) != 1000 {
+		t.Errorf("expected 1000 activations, got %d, %v", len(got), err)
+	}
+	if got, err := every.MissedSince(start, start.AddDate(0, 0, 12)); err != ErrTruncated || len(got) != DefaultBetweenLimit {
+		t.Errorf("expected ErrTruncated from MissedSince, got %d, %v", len(got), err)
+	}
+
+	if got, err := every.BetweenLimit(start, start.Add(2*time.Hour), 0); err != nil || len(got) != 7200 {
+		t.Errorf("expected 7200 activations with no cap, got %d, %v", len(got), err)
+	}
+}
+
+func TestPrevN(t *testing.T) {
+	withSeconds := NewParser(Second | Minute | Hour | Dom | Month | Dow)
+	withMillis := NewParser(Millisecond | Second | Minute | Hour | Dom | Month | Dow)
+	tests := []struct {
+		parser ScheduleParser
+		spec   string
+	}{
+		{standardParser, "TZ=UTC */15 9-17 * * mon-fri"},
+		{standardParser, "TZ=America/New_York 30 1-3 * * *"},
+		{standardParser, "TZ=Australia/Lord_Howe */10 1-2 * * *"},
+		{standardParser, "0 0 29 2 *"},
+		{excludeParser, "TZ=UTC 0 12 l * * !2024-01-31"},
+		{withSeconds, "TZ=Europe/London */20 */7 0-3 * * *"},
+		{withMillis, "TZ=UTC 0,500 * * 12 1 * *"},
+	}
+	froms := []time.Time{
+		time.Date(2024, 3, 11, 12, 7, 0, 0, time.UTC),
+		time.Date(2024, 11, 3, 7, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 7, 2, 30, 0, 0, time.UTC),
+		time.Date(2024, 10, 27, 2, 0, 20, 0, time.UTC),
+		time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC),
+	}
+	for _, c := range tests {
+		sched, err := c.parser.Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := sched.(*SpecSchedule)
+		for _, from := range froms {
+			var expected []time.Time
+			for latest := s.Latest(from); !latest.IsZero() && len(expected) < 200; latest = s.Latest(latest.Add(-s.granularity())) {
+				expected = append(expected, latest)
+			}
+			if got := s.PrevN(from, 200); !equalTimes(got, expected) {
+				t.Errorf("%s from %v: expected %v, got %v", c.spec, from, expected, got)
+			}
+		}
+	}
+
+	sched := mustParse(t, "TZ=UTC 0 9 * * *").(*SpecSchedule)
+	if got := sched.PrevN(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 0); len(got) != 0 {
+		t.Errorf("expected nothing for n = 0, got %v", got)
+	}
+	only := MustParseWithLocation("0 9 1 1 *", time.UTC)
+	only.Year = new(big.Int).SetBit(new(big.Int), 2024-minYear, 1)
+	got := only.PrevN(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 5)
+	if expected := []time.Time{time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)}; !equalTimes(got, expected) {
+		t.Errorf("expected %v once the schedule runs out, got %v", expected, got)
+	}
+}
+
+func BenchmarkPrevN(b *testing.B) {
+	sched, err := NewParser(Second | Minute | Hour | Dom | Month | Dow).Parse("TZ=UTC */10 * 9-17 * * mon-fri")
+	if err != nil {
+		b.Fatal(err)
+	}
+	s := sched.(*SpecSchedule)
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	b.Run("PrevN", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s.PrevN(from, 1000)
+		}
+	})
+	b.Run("Latest", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			latest := s.Latest(from)
+			for j := 1; j < 1000; j++ {
+				latest = s.Latest(latest.Add(-time.Second))
+			}
+		}
+	})
+}
+
+func TestActivationsPerYear(t *testing.T) {
+	tests := []struct {
+		spec     string
+		year     int
+		expected int
+	}{
+		{"0 0 * * *", 2023, 365},
+		{"0 0 * * *", 2024, 366},
+		{"0 0 29 2 *", 2024, 1},
+		{"0 0 29 2 *", 2023, 0},
+		{"0 0 1 * *", 2025, 12},
+		{"0 9 * * MON", 2024, 53},
+		{"TZ=America/New_York 0 * * * *", 2024, 366 * 24},
+	}
+	for _, c := range tests {
+		sched := mustParse(t, c.spec).(*SpecSchedule)
+		if got := sched.ActivationsPerYear(c.year); got != c.expected {
+			t.Errorf("%s in %d: expected %d, got %d", c.spec, c.year, c.expected, got)
+		}
+	}
+
+	// The year is taken in the schedule's location: midnight on New Year's
+	// Day in Tokyo is still the previous year in UTC.
+	tokyo := mustParse(t, "TZ=Asia/Tokyo 0 0 1 1 *").(*SpecSchedule)
+	if got := tokyo.ActivationsPerYear(2024); got != 1 {
+		t.Errorf("expected 1 in Tokyo, got %d", got)
+	}
+}
+
+func TestDensity(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	week := start.AddDate(0, 0, 7)
+	tests := []struct {
+		spec     string
+		expected float64
+	}{
+		{"TZ=UTC * * * * * *", 1},
+		{"TZ=UTC 0 * * * * *", 1.0 / 60},
+		{"TZ=UTC 0 0 * * * *", 1.0 / 3600},
+		{"TZ=UTC 0 0 0 * * *", 1.0 / 86400},
+		{"TZ=UTC 0 0 0 * * MON", 1.0 / (7 * 86400)},
+		{"TZ=UTC 0 0 0 30 2 *", 0},
+	}
+	for _, c := range tests {
+		sched, err := secondParser.Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := sched.(*SpecSchedule).Density(start, week); got != c.expected {
+			t.Errorf("%s: expected %g, got %g", c.spec, c.expected, got)
+		}
+	}
+}
+
+func TestDensityBounds(t *testing.T) {
+	sched, _ := secondParser.Parse("TZ=UTC * * * * * *")
+	spec := sched.(*SpecSchedule)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		start, end time.Time
+		expected   float64
+	}{
+		{start, start, 0},
+		{start.Add(time.Second), start, 0},
+		{start, start.Add(500 * time.Millisecond), 1},
+		{start.Add(500 * time.Millisecond), start.Add(2500 * time.Millisecond), 1},
+		{start.Add(500 * time.Millisecond), start.Add(3 * time.Second), 2 / 2.5},
+	}
+	for _, c := range tests {
+		if got := spec.Density(c.start, c.end); got != c.expected {
+			t.Errorf("%v to %v: expected %g, got %g", c.start, c.end, c.expected, got)
+		}
+	}
+
+	daily := mustParse(t, "TZ=UTC 0 0 * * *").(*SpecSchedule)
+	if got := daily.Density(start.Add(time.Hour), start.Add(time.Hour+time.Millisecond)); got != 0 {
+		t.Errorf("expected 0 within a second that isn't an activation, got %g", got)
+	}
+
+	for _, s := range []string{"* * * * *", "*/7 9-17 * * MON-FRI", "0 0 1 * *"} {
+		sched := mustParse(t, s).(*SpecSchedule)
+		for _, d := range []time.Duration{time.Second, 90 * time.Second, time.Hour, 40 * 24 * time.Hour} {
+			if got := sched.Density(start, start.Add(d)); got < 0 || got > 1 {
+				t.Errorf("%s over %v: density %g out of range", s, d, got)
+			}
+		}
+	}
+}
+
+func TestMissedSince(t *testing.T) {
+	sched := mustParse(t, "TZ=UTC 0 9 * * *").(*SpecSchedule)
+	lastRun := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		now      time.Time
+		expected int
+	}{
+		{lastRun, 0},
+		{lastRun.Add(23 * time.Hour), 0},
+		{lastRun.Add(24 * time.Hour), 1},
+		{lastRun.Add(4*24*time.Hour - time.Nanosecond), 3},
+		{lastRun.Add(4 * 24 * time.Hour), 4},
+		{lastRun.Add(-time.Hour), 0},
+	}
+	for _, c := range tests {
+		missed, err := sched.MissedSince(lastRun, c.now)
+		if err != nil || len(missed) != c.expected {
+			t.Errorf("%v: expected %d, got %v", c.now, c.expected, missed)
+			continue
+		}
+		for i, m := range missed {
+			if expected := lastRun.AddDate(0, 0, i+1); !m.Equal(expected) {
+				t.Errorf("%v: expected %v, got %v", c.now, expected, m)
+			}
+		}
+	}
+}
+
+func TestResolveOnWake(t *testing.T) {
+	sched := mustParse(t, "TZ=UTC 0 9 * * *").(*SpecSchedule)
+	target := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		now  time.Time
+		fire bool
+		next time.Time
+	}{
+		// Woke before the target: keep waiting for it.
+		{target.Add(-time.Hour), false, target},
+		{target.Add(-time.Nanosecond), false, target},
+		// Woke at or after the target: fire it, then wait for the next one.
+		{target, true, target.AddDate(0, 0, 1)},
+		{target.Add(2 * time.Hour), true, target.AddDate(0, 0, 1)},
+		// Slept through several activations: fire once, skip the rest.
+		{target.AddDate(0, 0, 3).Add(time.Minute), true, target.AddDate(0, 0, 4)},
+	}
+	for _, c := range tests {
+		fire, next := sched.ResolveOnWake(target, c.now)
+		if fire != c.fire || !next.Equal(c.next) {
+			t.Errorf("%v: expected %v and %v, got %v and %v", c.now, c.fire, c.next, fire, next)
+		}
+	}
+
+	if fire, _ := sched.ResolveOnWake(time.Time{}, target); fire {
+		t.Error("expected a zero target not to fire")
+	}
+}
@@ -2,6 +2,8 @@ package cron
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sort"
 	"sync"
 	"time"
@@ -15,8 +17,10 @@ type Cron struct {
 	chain     Chain
 	stop      chan struct{}
 	add       chan *Entry
-	remove    chan EntryID
+	remove    chan removal
+	reload    chan relocation
 	snapshot  chan chan []Entry
+	wakeup    chan struct{}
 	running   bool
 	logger    Logger
 	runningMu sync.Mutex
@@ -24,6 +28,37 @@ type Cron struct {
 	parser    ScheduleParser
 	nextID    EntryID
 	jobWaiter sync.WaitGroup
+	clock     clock
+
+	entryWrappers []EntryJobWrapper
+	groups        map[string]*Group
+
+	maxConcurrency int
+	queueSize      int
+	overflow       OverflowPolicy
+	tenancy        *Tenancy
+	dispatch       *dispatcher // or nil if jobs run without limit
+	tenantMu       sync.Mutex
+	tenantEntries  map[string]int
+
+	hooks EntryHooks
+
+	// failureThreshold and overrunThreshold are the streaks at which Health
+	// reports an entry as failing or overrunning.
+	failureThreshold int
+	overrunThreshold int
+
+	waitMu  sync.Mutex
+	changed chan struct{}   // closed when entries may have been exhausted
+	runErrs []error         // kept while waiters > 0
+	waiters int             // callers of Wait
+	jobCtx  context.Context // passed to ContextJobs, or nil for Background
+
+	drainTimeout   time.Duration
+	startupStagger time.Duration
+	statsWindow    time.Duration
+
+	removeExhausted bool
 }
 
 // ScheduleParser is an interface for schedule spec parsers that return a Schedule
@@ -36,6 +71,44 @@ type Job interface {
 	Run()
 }
 
+// FallibleJob is a Job that reports failure. Cron calls RunErr in place of Run
+// and keeps the error, e.g. to pass to a SelfScheduler.
+type FallibleJob interface {
+	Job
+	RunErr() error
+}
+
+// ContextJob is a FallibleJob that takes a context. Cron calls RunContext in
+// place of RunErr, with the context given to RunWithContext, or
+// context.Background if the Cron was started some other way.
+type ContextJob interface {
+	FallibleJob
+	RunContext(ctx context.Context) error
+}
+
+// SelfScheduler may be implemented by a Job that decides at run time when it
+// should next run. After each invocation, NextRun is given the time that
+// invocation was scheduled for and the error it returned (if it is a
+// FallibleJob, or if it panicked). If it returns true, the entry's next
+// activation becomes the returned time, or now if that has passed; otherwise
+// the entry's Schedule is used as usual. The override applies to a single
+// activation only.
+type SelfScheduler interface {
+	NextRun(lastScheduled time.Time, lastErr error) (time.Time, bool)
+}
+
+// OutcomeSchedule is a Schedule that adapts to how its entry's runs turn out,
+// such as a BackoffSchedule. After each run, Cron calls Reset if it succeeded
+// or NotifyFailure if it failed (returned an error as a FallibleJob, or
+// panicked), then asks the schedule for the next activation after the time
+// the run finished. While a run is in progress the entry's Next is the zero
+// time, as for a FixedDelaySchedule.
+type OutcomeSchedule interface {
+	Schedule
+	Reset()
+	NotifyFailure()
+}
+
 // Schedule describes a job's duty cycle.
 type Schedule interface {
 	// Next returns the next activation time, later than the given time.
@@ -46,6 +119,23 @@ type Schedule interface {
 	Latest(time.Time) time.Time
 }
 
+// nextOKer is implemented by schedules that report whether they have a next
+// activation, as NextOK does for any schedule.
+type nextOKer interface {
+	NextOK(time.Time) (time.Time, bool)
+}
+
+// NextOK returns the schedule's next activation after t, and whether there is
+// one, rather than the zero time. Schedules with a NextOK method of their own
+// answer for themselves; for others it is whether Next is non-zero.
+func NextOK(s Schedule, t time.Time) (time.Time, bool) {
+	if s, ok := s.(nextOKer); ok {
+		return s.NextOK(t)
+	}
+	next := s.Next(t)
+	return next, !next.IsZero()
+}
+
 // EntryID identifies an entry within a Cron instance
 type EntryID int
 
@@ -72,6 +162,55 @@ type Entry struct {
 	// It is kept around so that user code that needs to get at the job later,
 	// e.g. via Entries() can do so.
 	Job Job
+
+	// Running is the number of invocations of the job in flight at the time
+	// of the snapshot.
+	Running int
+
+	// Name is the name given to the entry by WithName, if any.
+	Name string
+
+	// Stagger is how long WithStartupStagger put off the entry's first run
+	// since the Cron was last started, or 0.
+	Stagger time.Duration
+
+	// Group is the name of the group the entry was added to, if any.
+	Group string
+	group *Group
+
+	// Tenant is the tenant the entry belongs to, if the Cron has tenants.
+	Tenant string
+
+	// Payload is the value passed to the job on each run, for jobs added
+	// with AddTyped.
+	Payload interface{}
+
+	// runs tracks the job's invocations and limits their concurrency.
+	runs *runState
+
+	// stats keeps statistics of the job's runs, and runStats is a copy of
+	// them taken for a snapshot.
+	stats    *statsRecorder
+	runStats EntryStats
+
+	// chain holds wrappers given for this entry alone, and replaceChain is set
+	// if they are to be used instead of the Cron's wrappers.
+	chain        []JobWrapper
+	replaceChain bool
+
+	// removeExhausted is set if the entry is to be removed once its schedule
+	// has no further activations.
+	removeExhausted bool
+
+	// maxRuns is the number of times the job may be started before the entry
+	// is removed, or 0 for no limit, and starts the number it has been.
+	maxRuns int
+	starts  int
+
+	// planned is when the run loop first computed Next, and sla the longest
+	// Health expects to go between runs, or 0 to work it out.
+	planned time.Time
+	sla     time.Duration
 }
 
 // Valid returns true if this is not the zero entry.
@@ -112,6 +251,18 @@ func (s byTime) Less(i, j int) bool {
 //     Description: Wrap submitted jobs to customize behavior.
 //     Default:     A chain that recovers panics and logs them to stderr.
 //
+//   Entry wrappers
+//     Description: Wrap submitted jobs with knowledge of their entry.
+//     Default:     None.
+//
+//   Concurrency
+//     Description: The number of jobs that may run at once.
+//     Default:     No limit.
+//
+//   Tenancy
+//     Description: Quotas and fair scheduling between tenants.
+//     Default:     None.
+//
 // See "cron.With*" to modify the default behavior.
 func New(opts ...Option) *Cron {
 	c := &Cron{
@@ -120,16 +271,34 @@ func New(opts ...Option) *Cron {
 		add:       make(chan *Entry),
 		stop:      make(chan struct{}),
 		snapshot:  make(chan chan []Entry),
-		remove:    make(chan EntryID),
+		wakeup:    make(chan struct{}, 1),
+		remove:    make(chan removal),
+		reload:    make(chan relocation),
 		running:   false,
 		runningMu: sync.Mutex{},
 		logger:    DefaultLogger,
 		location:  time.Local,
 		parser:    standardParser,
+		clock:     realClock{},
+
+		failureThreshold: defaultFailureThreshold,
+		overrunThreshold: defaultOverrunThreshold,
+		drainTimeout:     defaultDrainTimeout,
+		statsWindow:      defaultStatsWindow,
+		groups:           make(map[string]*Group),
+		changed:          make(chan struct{}),
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.maxConcurrency > 0 || c.tenancy != nil {
+		tenantMax := 0
+		if c.tenancy != nil {
+			tenantMax = c.tenancy.MaxConcurrent
+		}
+		c.dispatch = newDispatcher(c.maxConcurrency, tenantMax, c.queueSize, c.overflow)
+		c.tenantEntries = make(map[string]int)
+	}
 	return c
 }
 
@@ -142,39 +311,137 @@ func (f FuncJob) Run() { f() }
 // AddFunc adds a func to the Cron to be run on the given schedule.
 // The spec is parsed using the time zone of this Cron instance as the default.
 // An opaque ID is returned that can be used to later remove it.
-func (c *Cron) AddFunc(spec string, cmd func()) (EntryID, error) {
-	return c.AddJob(spec, FuncJob(cmd))
+func (c *Cron) AddFunc(spec string, cmd func(), opts ...EntryOption) (EntryID, error) {
+	return c.AddJob(spec, FuncJob(cmd), opts...)
 }
 
 // AddJob adds a Job to the Cron to be run on the given schedule.
 // The spec is parsed using the time zone of this Cron instance as the default.
 // An opaque ID is returned that can be used to later remove it.
-func (c *Cron) AddJob(spec string, cmd Job) (EntryID, error) {
+func (c *Cron) AddJob(spec string, cmd Job, opts ...EntryOption) (EntryID, error) {
 	schedule, err := c.parser.Parse(spec)
 	if err != nil {
 		return 0, err
 	}
-	return c.Schedule(schedule, cmd), nil
+	c.runningMu.Lock()
+	e, err := c.schedule(schedule, cmd, opts...)
+	c.runningMu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	c.entryAdded(e)
+	return e.ID, nil
 }
 
+// ErrExhausted is returned on adding an entry that is to be removed once its
+// schedule has no further activations (see WithRemoveExhausted), if it has
+// none already. Only schedules known to have no state, such as SpecSchedule,
+// are checked, as asking others would change their activations; those are
+// removed by the run loop as soon as it finds they have none.
+var ErrExhausted = errors.New("cron: schedule has no activations to come")
+
 // Schedule adds a Job to the Cron to be run on the given schedule.
-// The job is wrapped with the configured Chain.
-func (c *Cron) Schedule(schedule Schedule, cmd Job) EntryID {
+// The job is wrapped with the configured Chain. It returns the zero EntryID,
+// adding nothing, if the entry's tenant has reached its quota, or it would be
+// removed as exhausted at once.
+func (c *Cron) Schedule(schedule Schedule, cmd Job, opts ...EntryOption) EntryID {
 	c.runningMu.Lock()
-	defer c.runningMu.Unlock()
-	c.nextID++
+	e, err := c.schedule(schedule, cmd, opts...)
+	c.runningMu.Unlock()
+	if err != nil {
+		return 0
+	}
+	c.entryAdded(e)
+	return e.ID
+}
+
+// schedule adds the entry, returning a snapshot of it. The caller must hold
+// runningMu, and call the OnAdded hook once it is released.
+func (c *Cron) schedule(schedule Schedule, cmd Job, opts ...EntryOption) (Entry, error) {
 	entry := &Entry{
-		ID:         c.nextID,
-		Schedule:   schedule,
-		WrappedJob: c.chain.Then(cmd),
-		Job:        cmd,
+		ID:       c.nextID + 1,
+		Schedule: schedule,
+		Job:      cmd,
+		runs:     &runState{},
+		stats:    newStatsRecorder(c.clock, c.statsWindow),
+	}
+	if p, ok := cmd.(payloader); ok {
+		entry.Payload = p.payload()
+	}
+	if _, ok := schedule.(*ChainedSchedule); ok {
+		entry.removeExhausted = true
+	} else {
+		entry.removeExhausted = c.removeExhausted
+	}
+	for _, opt := range opts {
+		opt(entry)
 	}
+	if entry.removeExhausted && stateless(schedule) && schedule.Next(c.now()).IsZero() {
+		return Entry{}, ErrExhausted
+	}
+	if c.tenancy != nil {
+		entry.Tenant = entry.Group
+		if c.tenancy.Key != nil {
+			entry.Tenant = c.tenancy.Key(*entry)
+		}
+		if err := c.claimTenant(entry.Tenant); err != nil {
+			return Entry{}, err
+		}
+	}
+	c.nextID++
+	entry.WrappedJob = c.wrap(entry)
+	added := *entry
 	if !c.running {
 		c.entries = append(c.entries, entry)
 	} else {
 		c.add <- entry
 	}
-	return entry.ID
+	return added, nil
+}
+
+// claimTenant counts a new entry against the tenant's quota.
+func (c *Cron) claimTenant(tenant string) error {
+	c.tenantMu.Lock()
+	defer c.tenantMu.Unlock()
+	if max := c.tenancy.MaxEntries; max > 0 && c.tenantEntries[tenant] >= max {
+		return &QuotaExceededError{Tenant: tenant, Limit: max}
+	}
+	c.tenantEntries[tenant]++
+	return nil
+}
+
+// releaseTenant gives back the quota of a removed entry.
+func (c *Cron) releaseTenant(tenant string) {
+	c.tenantMu.Lock()
+	defer c.tenantMu.Unlock()
+	c.tenantEntries[tenant]--
+}
+
+// wrap decorates the entry's job. From the outside in, the job is wrapped by
+// the Cron's chain, then by its entry wrappers, and finally by any wrappers
+// given for the entry itself.
+func (c *Cron) wrap(e *Entry) Job {
+	j := NewChain(e.chain...).Then(c.recordErr(e))
+	if e.replaceChain {
+		return j
+	}
+	for i := range c.entryWrappers {
+		j = c.entryWrappers[len(c.entryWrappers)-i-1](*e, j)
+	}
+	return c.chain.Then(j)
+}
+
+// Use adds wrappers to the Cron's chain, inside those already configured.
+// Jobs are wrapped when they are added, so it returns an error once any
+// entry has been added rather than leave those entries unwrapped.
+func (c *Cron) Use(wrappers ...JobWrapper) error {
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if c.nextID > 0 {
+		return fmt.Errorf("wrappers may not be added after entries")
+	}
+	c.chain = NewChain(append(c.chain.wrappers[:len(c.chain.wrappers):len(c.chain.wrappers)], wrappers...)...)
+	return nil
 }
 
 // Entries returns a snapshot of the cron entries.
@@ -207,12 +474,39 @@ func (c *Cron) Entry(id EntryID) Entry {
 // Remove an entry from being run in the future.
 func (c *Cron) Remove(id EntryID) {
 	c.runningMu.Lock()
-	defer c.runningMu.Unlock()
-	if c.running {
-		c.remove <- id
-	} else {
-		c.removeEntry(id)
+	removed := c.removeWhere(func(e *Entry) bool { return e.ID == id })
+	c.runningMu.Unlock()
+	c.entriesRemoved(removed, RemovedExplicitly)
+}
+
+// RemoveWhere removes every entry for which match returns true, given a
+// snapshot of it, and returns the number removed. Match must not call back
+// into the Cron.
+func (c *Cron) RemoveWhere(match func(Entry) bool) int {
+	c.runningMu.Lock()
+	removed := c.removeWhere(func(e *Entry) bool { return match(snapshotOf(e)) })
+	c.runningMu.Unlock()
+	c.entriesRemoved(removed, RemovedExplicitly)
+	return len(removed)
+}
+
+// removal asks the run loop to remove the entries matching a predicate, and
+// to reply with snapshots of them.
+type removal struct {
+	match   func(*Entry) bool
+	removed chan []Entry
+}
+
+// removeWhere removes the entries matching the predicate, returning snapshots
+// of them. The caller must hold runningMu, and call the OnRemoved hook once it
+// is released.
+func (c *Cron) removeWhere(match func(*Entry) bool) []Entry {
+	if !c.running {
+		return snapshots(c.removeEntries(match))
 	}
+	r := removal{match, make(chan []Entry, 1)}
+	c.remove <- r
+	return <-r.removed
 }
 
 // Start the cron scheduler in its own goroutine, or no-op if already started.
@@ -223,6 +517,7 @@ func (c *Cron) Start() {
 		return
 	}
 	c.running = true
+	c.setJobContext(nil)
 	go c.run()
 }
 
@@ -234,6 +529,7 @@ func (c *Cron) Run() {
 		return
 	}
 	c.running = true
+	c.setJobContext(nil)
 	c.runningMu.Unlock()
 	c.run()
 }
@@ -247,45 +543,91 @@ func (c *Cron) run() {
 	now := c.now()
 	for _, entry := range c.entries {
 		entry.Next = entry.Schedule.Next(now)
+		entry.planned = now
+		c.stagger(entry, now)
 		c.logger.Info("schedule", "now", now, "entry", entry.ID, "next", entry.Next)
 	}
+	c.removeFinished(RemovedExhausted, removable)
 
 	for {
 		// Determine the next entry to run.
 		sort.Sort(byTime(c.entries))
 
-		var timer *time.Timer
+		// Always sleep until the absolute activation time, measured from the
+		// clock right now, so that lateness in one wake doesn't carry over into
+		// the next.
+		var timer timer
 		if len(c.entries) == 0 || c.entries[0].Next.IsZero() {
 			// If there are no entries yet, just sleep - it still handles new entries
 			// and stop requests.
-			timer = time.NewTimer(100000 * time.Hour)
+			timer = c.clock.NewTimer(100000 * time.Hour)
 		} else {
-			timer = time.NewTimer(c.entries[0].Next.Sub(now))
+			timer = c.clock.NewTimer(c.entries[0].Next.Sub(c.now()))
 		}
 
 		for {
 			select {
-			case now = <-timer.C:
-				now = now.In(c.location)
+			case <-timer.C():
+				now = c.now()
 				c.logger.Info("wake", "now", now)
 
-				// Run every entry whose next time was less than now
+				// Run every entry whose next time was less than now, treating
+				// entries due within wakeEpsilon as on time. If we woke any
+				// earlier than that nothing runs and we go back to sleep.
+				due := now.Add(wakeEpsilon)
 				for _, e := range c.entries {
-					if e.Next.After(now) || e.Next.IsZero() {
+					if e.Next.After(due) || e.Next.IsZero() {
 						break
 					}
-					c.startJob(e.WrappedJob)
+					started := c.startJob(e)
+					if started {
+						e.starts++
+					}
 					e.Prev = e.Next
-					e.Next = e.Schedule.Next(now)
+					if chain, ok := e.Schedule.(*ChainedSchedule); ok {
+						chain.advance()
+					}
+					// Compute from the activation just run if we're early, so
+					// that it isn't returned (and run) a second time. Fixed
+					// delay and outcome schedules are rescheduled once the run
+					// finishes.
+					if afterCompletion(e.Schedule) && started {
+						e.Next = time.Time{}
+					} else if now.Before(e.Prev) {
+						e.Next = e.Schedule.Next(e.Prev)
+					} else {
+						e.Next = e.Schedule.Next(now)
+					}
 					c.logger.Info("run", "now", now, "entry", e.ID, "next", e.Next)
 				}
+				c.removeFinished(RemovedMaxRuns, func(e *Entry) bool {
+					return e.maxRuns > 0 && e.starts >= e.maxRuns
+				})
+				c.removeFinished(RemovedExhausted, removable)
+				c.notify()
 
 			case newEntry := <-c.add:
 				timer.Stop()
 				now = c.now()
 				newEntry.Next = newEntry.Schedule.Next(now)
+				newEntry.planned = now
 				c.entries = append(c.entries, newEntry)
 				c.logger.Info("added", "now", now, "entry", newEntry.ID, "next", newEntry.Next)
+				c.removeFinished(RemovedExhausted, removable)
+
+			case <-c.wakeup:
+				timer.Stop()
+				now = c.now()
+				for _, e := range c.entries {
+					if next := e.runs.takeOverride(); !next.IsZero() {
+						if next.Before(now) {
+							next = now
+						}
+						e.Next = next
+						c.logger.Info("reschedule", "now", now, "entry", e.ID, "next", e.Next)
+					}
+				}
+				c.removeFinished(RemovedExhausted, removable)
 
 			case replyChan := <-c.snapshot:
 				replyChan <- c.entrySnapshot()
@@ -296,11 +638,19 @@ func (c *Cron) run() {
 				c.logger.Info("stop")
 				return
 
-			case id := <-c.remove:
+			case r := <-c.remove:
 				timer.Stop()
 				now = c.now()
-				c.removeEntry(id)
-				c.logger.Info("removed", "entry", id)
+				removed := c.removeEntries(r.match)
+				for _, e := range removed {
+					c.logger.Info("removed", "entry", e.ID)
+				}
+				r.removed <- snapshots(removed)
+
+			case r := <-c.reload:
+				timer.Stop()
+				now = c.now()
+				r.updated <- c.relocate(r.locs, now)
 			}
 
 			break
@@ -308,18 +658,380 @@ func (c *Cron) run() {
 	}
 }
 
-// startJob runs the given job in a new goroutine.
-func (c *Cron) startJob(j Job) {
+// wakeEpsilon is how early the run loop may wake up and still consider
+// an activation to be on time.
+const wakeEpsilon = time.Millisecond
+
+// clock is the source of time for the run loop. It is swapped out in tests.
+type clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) timer
+}
+
+// timer is the subset of *time.Timer used by the run loop.
+type timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(d time.Duration) timer { return realTimer{time.NewTimer(d)} }
+
+type realTimer struct{ t *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.t.C }
+
+func (t realTimer) Stop() bool { return t.t.Stop() }
+
+// startJob runs the entry's job in a new goroutine, unless it is paused or
+// already running as many times as it is allowed to. It reports whether the
+// job was started.
+func (c *Cron) startJob(e *Entry) bool {
+	if e.group != nil && e.group.Paused() {
+		c.logger.Info("paused", "entry", e.ID, "group", e.Group)
+		return false
+	}
+	switch e.runs.acquire() {
+	case runSkipped:
+		c.logger.Info("skip", "entry", e.ID, "running", e.runs.max)
+		return false
+	case runQueued:
+		c.logger.Info("queue", "entry", e.ID, "running", e.runs.max)
+		return false
+	}
+	a := activation{schedule: e.Schedule, scheduled: e.Next}
+	if c.hooks.OnDropped != nil {
+		a.entry = snapshotOf(e)
+	}
+	a.admitted = c.dispatch != nil && c.dispatch.admit()
+	c.jobWaiter.Add(1)
+	go func() {
+		defer c.jobWaiter.Done()
+		defer c.notify()
+		if e.removeExhausted {
+			// The entry may be exhausted once the run is over.
+			defer c.wake()
+		}
+		for {
+			c.runJob(e, a)
+			a.admitted = false // queued runs weren't admitted
+			switch s := a.schedule.(type) {
+			case FixedDelaySchedule:
+				c.reschedule(e, s.Next(c.now()))
+			case OutcomeSchedule:
+				if e.runs.err() == nil {
+					s.Reset()
+				} else {
+					s.NotifyFailure()
+				}
+				c.reschedule(e, s.Next(c.now()))
+			}
+			c.selfSchedule(e, a.scheduled)
+			if !e.runs.release() {
+				return
+			}
+		}
+	}()
+	return true
+}
+
+// removeFinished removes the entries that the run loop is finished with, as
+// the predicate tells, and calls the OnRemoved hook for them with the reason.
+func (c *Cron) removeFinished(reason RemovalReason, match func(*Entry) bool) {
+	finished := c.removeEntries(match)
+	if len(finished) == 0 {
+		return
+	}
+	for _, e := range finished {
+		c.logger.Info("removed", "entry", e.ID, "runs", e.starts, "reason", reason)
+	}
+	// The hooks may call back into the Cron, which would deadlock here.
+	removed := snapshots(finished)
 	c.jobWaiter.Add(1)
 	go func() {
 		defer c.jobWaiter.Done()
-		j.Run()
+		c.entriesRemoved(removed, reason)
 	}()
 }
 
+// removable reports whether the entry is to be removed as exhausted: it has
+// no next activation, and no invocation running or next activation chosen by
+// one yet to be picked up.
+func removable(e *Entry) bool {
+	return e.removeExhausted && e.Next.IsZero() && e.runs.inFlight() == 0 && !e.runs.pending()
+}
+
+// stateless reports whether the schedule is known to give the same
+// activations however often it is asked, so that it may be asked from outside
+// the run loop without changing what the entry runs at.
+func stateless(s Schedule) bool {
+	switch s.(type) {
+	case *SpecSchedule, ConstantDelaySchedule:
+		return true
+	}
+	return false
+}
+
+// afterCompletion reports whether the schedule's next activation is computed
+// when a run finishes, rather than when it starts.
+func afterCompletion(s Schedule) bool {
+	switch s.(type) {
+	case FixedDelaySchedule, OutcomeSchedule:
+		return true
+	}
+	return false
+}
+
+// recordErr returns the entry's job, recording the error from each run, and
+// its duration in the entry's statistics. A panic is recorded as an error
+// (before being passed on).
+func (c *Cron) recordErr(e *Entry) Job {
+	return FuncJob(func() {
+		start := c.clock.Now()
+		err := fmt.Errorf("job panicked")
+		defer func() {
+			e.runs.setErr(err)
+			e.stats.record(start, err != nil)
+			if err != nil {
+				c.addRunErr(fmt.Errorf("entry %d: %w", e.ID, err))
+			}
+		}()
+		switch j := e.Job.(type) {
+		case ContextJob:
+			err = j.RunContext(c.jobContext())
+		case FallibleJob:
+			err = j.RunErr()
+		default:
+			e.Job.Run()
+			err = nil
+		}
+	})
+}
+
+// selfSchedule asks a SelfScheduler job when it should next run, and passes
+// the answer on to the run loop.
+func (c *Cron) selfSchedule(e *Entry, scheduled time.Time) {
+	s, ok := e.Job.(SelfScheduler)
+	if !ok {
+		return
+	}
+	if next, ok := s.NextRun(scheduled, e.runs.err()); ok {
+		c.reschedule(e, next)
+	}
+}
+
+// reschedule passes the entry's next activation on to the run loop.
+func (c *Cron) reschedule(e *Entry, next time.Time) {
+	e.runs.setOverride(next)
+	c.wake()
+}
+
+// wake has the run loop pick up next activations passed on by reschedule, and
+// remove entries that have become exhausted.
+func (c *Cron) wake() {
+	select {
+	case c.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// activation is a run of an entry's job that fell due.
+type activation struct {
+	schedule  Schedule // which the run loop may swap out meanwhile
+	scheduled time.Time
+	entry     Entry // a snapshot as it fell due, if there is an OnDropped hook
+	admitted  bool  // by dispatcher.admit
+}
+
+// runJob runs the entry's job, first waiting for room under its group's
+// limit. A job waiting on a group that is removed is dropped, as is one that
+// overflows the queue of jobs waiting under the Cron's limits.
+func (c *Cron) runJob(e *Entry, a activation) {
+	if g := e.group; g != nil && g.limit != nil {
+		if !g.limit.acquire(g.removed) {
+			if a.admitted {
+				c.dispatch.arrive()
+			}
+			c.logger.Info("drop", "entry", e.ID, "group", e.Group)
+			return
+		}
+		defer g.limit.release()
+	}
+	if c.dispatch != nil {
+		var done <-chan struct{}
+		if e.group != nil {
+			done = e.group.removed
+		}
+		switch c.dispatch.acquire(e.Tenant, done, a.admitted) {
+		case dispatchCancelled:
+			c.logger.Info("drop", "entry", e.ID, "group", e.Group)
+			return
+		case dispatchDropped:
+			c.logger.Info("overflow", "entry", e.ID, "scheduled", a.scheduled)
+			c.activationDropped(a.entry, a.scheduled)
+			return
+		}
+		defer c.dispatch.release(e.Tenant)
+	}
+	if c.tenancy != nil && c.tenancy.Observe != nil {
+		start := c.clock.Now()
+		defer func() {
+			c.tenancy.Observe(e.Tenant, e.ID, c.clock.Now().Sub(start))
+		}()
+	}
+	e.WrappedJob.Run()
+}
+
+// limiter bounds the number of jobs running at once.
+type limiter chan struct{}
+
+func newLimiter(n int) limiter {
+	return make(limiter, n)
+}
+
+// acquire waits for a slot to run in, giving up if done is closed first.
+func (l limiter) acquire(done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return false
+	default:
+	}
+	select {
+	case l <- struct{}{}:
+		return true
+	case <-done:
+		return false
+	}
+}
+
+func (l limiter) release() {
+	<-l
+}
+
+// OverlapPolicy determines what happens when an entry is due while it is
+// already running as many times as it is allowed to.
+type OverlapPolicy int
+
+const (
+	// OverlapSkip skips the activation.
+	OverlapSkip OverlapPolicy = iota
+
+	// OverlapQueue runs the job once a running invocation has finished.
+	OverlapQueue
+)
+
+// runState counts the invocations of an entry's job that are in flight. It is
+// shared between the run loop and the goroutines running the job.
+type runState struct {
+	mu       sync.Mutex
+	max      int // or 0 for no limit
+	policy   OverlapPolicy
+	running  int
+	queued   int
+	lastErr  error
+	override time.Time // next activation chosen by a SelfScheduler
+
+	// failures counts the invocations in a row that have failed, and overruns
+	// the activations in a row that found the job still running.
+	failures int
+	overruns int
+}
+
+const (
+	runStarted = iota
+	runSkipped
+	runQueued
+)
+
+// acquire claims a slot for an invocation, returning whether it may start,
+// is to be skipped, or has been queued.
+func (r *runState) acquire() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running > 0 {
+		r.overruns++
+	} else {
+		r.overruns = 0
+	}
+	if r.max > 0 && r.running >= r.max {
+		if r.policy == OverlapQueue {
+			r.queued++
+			return runQueued
+		}
+		return runSkipped
+	}
+	r.running++
+	return runStarted
+}
+
+// release gives up the slot of a finished invocation, unless there is a queued
+// invocation to hand it to, in which case it returns true.
+func (r *runState) release() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.queued > 0 {
+		r.queued--
+		return true
+	}
+	r.running--
+	return false
+}
+
+func (r *runState) setErr(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastErr = err
+	if err != nil {
+		r.failures++
+	} else {
+		r.failures = 0
+	}
+}
+
+// err returns the error from the most recently finished invocation.
+func (r *runState) err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastErr
+}
+
+func (r *runState) setOverride(t time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.override = t
+}
+
+// takeOverride returns and clears the next activation chosen by a
+// SelfScheduler, if there is one.
+func (r *runState) takeOverride() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t := r.override
+	r.override = time.Time{}
+	return t
+}
+
+// pending reports whether a next activation chosen after a run is yet to be
+// picked up by the run loop.
+func (r *runState) pending() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return !r.override.IsZero()
+}
+
+// inFlight returns the number of invocations running.
+func (r *runState) inFlight() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.running
+}
+
 // now returns current time in c location
 func (c *Cron) now() time.Time {
-	return time.Now().In(c.location)
+	return c.clock.Now().In(c.location)
 }
 
 // Stop stops the cron scheduler if it is running; otherwise it does nothing.
@@ -330,6 +1042,7 @@ func (c *Cron) Stop() context.Context {
 	if c.running {
 		c.stop <- struct{}{}
 		c.running = false
+		c.notify()
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
@@ -341,19 +1054,42 @@ func (c *Cron) Stop() context.Context {
 
 // entrySnapshot returns a copy of the current cron entry list.
 func (c *Cron) entrySnapshot() []Entry {
-	var entries = make([]Entry, len(c.entries))
-	for i, e := range c.entries {
-		entries[i] = *e
+	return snapshots(c.entries)
+}
+
+// snapshots returns copies of the entries.
+func snapshots(entries []*Entry) []Entry {
+	snaps := make([]Entry, len(entries))
+	for i, e := range entries {
+		snaps[i] = snapshotOf(e)
+	}
+	return snaps
+}
+
+// snapshotOf returns a copy of the entry.
+func snapshotOf(e *Entry) Entry {
+	snap := *e
+	snap.Running = e.runs.inFlight()
+	if e.stats != nil {
+		snap.runStats = e.stats.read()
 	}
-	return entries
+	return snap
 }
 
-func (c *Cron) removeEntry(id EntryID) {
-	var entries []*Entry
+// removeEntries removes the entries matching the predicate, returning them.
+func (c *Cron) removeEntries(match func(*Entry) bool) []*Entry {
+	var entries, removed []*Entry
 	for _, e := range c.entries {
-		if e.ID != id {
+		if match(e) {
+			removed = append(removed, e)
+			if c.tenancy != nil {
+				c.releaseTenant(e.Tenant)
+			}
+		} else {
 			entries = append(entries, e)
 		}
 	}
 	c.entries = entries
+	c.notify()
+	return removed
 }
@@ -2,6 +2,7 @@ package cron
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -682,6 +683,149 @@ func TestMultiThreadedStartAndStop(t *testing.T) {
 	cron.Stop()
 }
 
+// fakeClock is a clock whose time only moves when the test says so. Each timer
+// the run loop creates is handed to the test on the timers channel, to be
+// fired at a time of the test's choosing.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers chan *fakeTimer
+}
+
+type fakeTimer struct {
+	deadline time.Time
+	c        chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, timers: make(chan *fakeTimer, 16)}
+}
+
+func (fc *fakeClock) Now() time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.now
+}
+
+func (fc *fakeClock) NewTimer(d time.Duration) timer {
+	ft := &fakeTimer{deadline: fc.Now().Add(d), c: make(chan time.Time, 1)}
+	fc.timers <- ft
+	return ft
+}
+
+// fire sets the clock to the given time and fires the timer.
+func (fc *fakeClock) fire(ft *fakeTimer, now time.Time) {
+	fc.mu.Lock()
+	fc.now = now
+	fc.mu.Unlock()
+	ft.c <- now
+}
+
+func (ft *fakeTimer) C() <-chan time.Time { return ft.c }
+
+func (ft *fakeTimer) Stop() bool { return true }
+
+// newFakeCron returns a Cron with a fake clock set to start, parsing specs
+// with seconds, in UTC, with no chain and no logging. The options given are
+// applied after those.
+func newFakeCron(start time.Time, opts ...Option) (*Cron, *fakeClock) {
+	fc := newFakeClock(start)
+	cron := New(append([]Option{WithParser(secondParser), WithChain(), WithLocation(time.UTC),
+		WithLogger(DiscardLogger)}, opts...)...)
+	cron.clock = fc
+	return cron, fc
+}
+
+// Wake-ups a little late or a little early must not drift the schedule, skip
+// activations, or run any activation twice.
+func TestNoTimerDrift(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ran := make(chan struct{}, 10)
+	cron, fc := newFakeCron(start)
+	cron.AddFunc("* * * * * ?", func() { ran <- struct{}{} })
+	cron.Start()
+
+	const activations = 10000
+	for i := 1; i <= activations; i++ {
+		expected := start.Add(time.Duration(i) * time.Second)
+		timer := <-fc.timers
+		if !timer.deadline.Equal(expected) {
+			t.Fatalf("activation %d: timer set for %v, expected %v", i, timer.deadline, expected)
+		}
+
+		// Every so often, wake far too early; nothing should run.
+		if i%1000 == 0 {
+			fc.fire(timer, expected.Add(-100*time.Millisecond))
+			timer = <-fc.timers
+			if prev := cron.Entries()[0].Prev; !prev.Equal(expected.Add(-time.Second)) {
+				t.Fatalf("activation %d: early wake ran the job at %v", i, prev)
+			}
+		}
+
+		// Wake anywhere from half a millisecond early to a millisecond late.
+		jitter := time.Duration(i*7919%1500-500) * time.Microsecond
+		fc.fire(timer, expected.Add(jitter))
+		<-ran
+		if prev := cron.Entries()[0].Prev; !prev.Equal(expected) {
+			t.Fatalf("activation %d: dispatched for %v, expected %v", i, prev, expected)
+		}
+	}
+
+	<-cron.Stop().Done()
+	if len(ran) != 0 {
+		t.Errorf("expected %d runs, got %d extra", activations, len(ran))
+	}
+}
+
+// advance fires the given timer at its deadline and returns the next timer
+// set by the run loop, once it has finished handling the wake.
+func (fc *fakeClock) advance(ft *fakeTimer) *fakeTimer {
+	fc.fire(ft, ft.deadline)
+	return <-fc.timers
+}
+
+func TestMaxConcurrentRuns(t *testing.T) {
+	for _, policy := range []OverlapPolicy{OverlapSkip, OverlapQueue} {
+		var (
+			started = make(chan struct{}, 10)
+			release = make(chan struct{})
+			runs    int32
+		)
+		cron, fc := newFakeCron(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		cron.AddFunc("* * * * * ?", func() {
+			atomic.AddInt32(&runs, 1)
+			started <- struct{}{}
+			<-release
+		}, WithMaxConcurrentRuns(2, policy))
+		cron.Start()
+
+		timer := <-fc.timers
+		for i := 0; i < 5; i++ {
+			timer = fc.advance(timer)
+		}
+		<-started
+		<-started
+		if running := cron.Entries()[0].Running; running != 2 {
+			t.Errorf("policy %d: expected 2 running, got %d", policy, running)
+		}
+
+		expected := int32(2)
+		if policy == OverlapQueue {
+			expected = 5
+		}
+		for i := int32(0); i < expected; i++ {
+			release <- struct{}{}
+		}
+		<-cron.Stop().Done()
+		if actual := atomic.LoadInt32(&runs); actual != expected {
+			t.Errorf("policy %d: expected %d runs, got %d", policy, expected, actual)
+		}
+		if running := cron.Entries()[0].Running; running != 0 {
+			t.Errorf("policy %d: expected nothing running, got %d", policy, running)
+		}
+	}
+}
+
 func wait(wg *sync.WaitGroup) chan bool {
 	ch := make(chan bool)
 	go func() {
@@ -704,3 +848,104 @@ func stop(cron *Cron) chan bool {
 func newWithSeconds() *Cron {
 	return New(WithParser(secondParser), WithChain())
 }
+
+// retryJob fails on its first run, and asks to be retried a minute later.
+type retryJob struct {
+	runs int32
+	ran  chan error
+}
+
+func (j *retryJob) Run() { j.RunErr() }
+
+func (j *retryJob) RunErr() error {
+	var err error
+	if atomic.AddInt32(&j.runs, 1) == 1 {
+		err = errors.New("failed")
+	}
+	j.ran <- err
+	return err
+}
+
+func (j *retryJob) NextRun(lastScheduled time.Time, lastErr error) (time.Time, bool) {
+	if lastErr == nil {
+		return time.Time{}, false
+	}
+	return lastScheduled.Add(time.Minute), true
+}
+
+func TestSelfScheduler(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := newFakeClock(start)
+	job := &retryJob{ran: make(chan error, 10)}
+	cron := New(WithChain(), WithLocation(time.UTC))
+	cron.clock = fc
+	cron.AddJob("0 * * * *", job)
+	cron.Start()
+	defer cron.Stop()
+
+	timer := fc.advance(<-fc.timers)
+	if err := <-job.ran; err == nil {
+		t.Fatal("expected the first run to fail")
+	}
+	timer = <-fc.timers
+	retry := start.Add(time.Hour + time.Minute)
+	if !timer.deadline.Equal(retry) {
+		t.Fatalf("expected a retry at %v, got %v", retry, timer.deadline)
+	}
+	if next := cron.Entries()[0].Next; !next.Equal(retry) {
+		t.Errorf("expected entry's next run at %v, got %v", retry, next)
+	}
+
+	timer = fc.advance(timer)
+	if err := <-job.ran; err != nil {
+		t.Fatal("expected the retry to succeed, got", err)
+	}
+	regular := start.Add(2 * time.Hour)
+	if !timer.deadline.Equal(regular) {
+		t.Errorf("expected to revert to the schedule at %v, got %v", regular, timer.deadline)
+	}
+}
+
+func TestFixedDelay(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := newFakeClock(start)
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	cron := New(WithChain(), WithLocation(time.UTC))
+	cron.clock = fc
+	cron.Schedule(EveryAfterCompletion(time.Minute), FuncJob(func() {
+		started <- struct{}{}
+		<-release
+	}))
+	cron.Start()
+	defer cron.Stop()
+
+	timer := <-fc.timers
+	if expected := start.Add(time.Minute); !timer.deadline.Equal(expected) {
+		t.Fatalf("expected the first run at %v, got %v", expected, timer.deadline)
+	}
+	timer = fc.advance(timer)
+	<-started
+	if next := cron.Entries()[0].Next; !next.IsZero() {
+		t.Errorf("expected no next run while running, got %v", next)
+	}
+
+	// The run takes 90 seconds; the next starts a minute after it finishes.
+	finished := start.Add(time.Minute + 90*time.Second)
+	fc.mu.Lock()
+	fc.now = finished
+	fc.mu.Unlock()
+	release <- struct{}{}
+	timer = <-fc.timers
+	if expected := finished.Add(time.Minute); !timer.deadline.Equal(expected) {
+		t.Errorf("expected the next run at %v, got %v", expected, timer.deadline)
+	}
+	if next := cron.Entries()[0].Next; !next.Equal(finished.Add(time.Minute)) {
+		t.Errorf("expected entry's next run at %v, got %v", finished.Add(time.Minute), next)
+	}
+	fc.advance(timer)
+	<-started
+	release <- struct{}{}
+}
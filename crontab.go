@@ -0,0 +1,144 @@
+package cron
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// NamedSchedule is a line of a crontab file: a schedule and the command it
+// runs.
+type NamedSchedule struct {
+	// Schedule is nil for an @reboot line, which runs at startup rather than
+	// on a schedule.
+	Schedule *SpecSchedule
+
+	// User is the user the command runs as, given by lines of a system-wide
+	// crontab such as /etc/crontab, or "" for a per-user crontab line.
+	User string
+
+	Command string
+	RawLine string
+}
+
+// userName matches the user names of a system-wide crontab line.
+var userName = regexp.MustCompile(`^[a-z_][a-z0-9_-]*\$?$`)
+
+// ParseCrontab parses a crontab file, such as
+//
+//	# m h dom mon dow command
+//	0 2 * * * /usr/bin/backup
+//	@daily /usr/local/bin/cleanup
+//
+// returning a NamedSchedule for each line that runs a command, in order.
+// Blank lines, comments and variable assignments such as SHELL=/bin/sh are
+// skipped. A schedule is five fields, as ParseStandard accepts, or six with
+// seconds first if that parses; or a descriptor such as @daily. An @reboot
+// line has no schedule.
+//
+// In a system-wide crontab, the schedule is followed by the user to run the
+// command as. A token after the schedule that is a valid user name, rather
+// than a path, and is followed by more of the line is taken to be a user.
+// Per-user crontab lines whose command is a bare word with arguments, such
+// as "backup --now", are therefore read as running "--now" as user "backup";
+// give such commands by their path.
+func ParseCrontab(r io.Reader) ([]*NamedSchedule, error) {
+	var schedules []*NamedSchedule
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || isAssignment(line) {
+			continue
+		}
+		named, err := parseCrontabLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+		schedules = append(schedules, named)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// parseCrontabLine parses a line that runs a command.
+func parseCrontabLine(line string) (*NamedSchedule, error) {
+	named := &NamedSchedule{RawLine: line}
+	var rest string
+	switch fields := strings.Fields(line); {
+	case fields[0] == "@reboot":
+		_, rest = cutFields(line, 1)
+	case strings.HasPrefix(fields[0], "@"):
+		var spec []string
+		spec, rest = cutFields(line, 1)
+		sched, err := standardParser.Parse(spec[0])
+		if err != nil {
+			return nil, err
+		}
+		s, ok := sched.(*SpecSchedule)
+		if !ok {
+			return nil, fmt.Errorf("not a cron schedule: %s", spec[0])
+		}
+		named.Schedule = s
+	default:
+		if len(fields) > 6 {
+			spec, seconds := cutFields(line, 6)
+			if sched, err := layoutParsers[SecondsLayout].Parse(strings.Join(spec, " ")); err == nil {
+				named.Schedule, rest = sched.(*SpecSchedule), seconds
+				break
+			}
+		}
+		spec, minutes := cutFields(line, 5)
+		if len(spec) < 5 {
+			return nil, fmt.Errorf("expected a schedule and a command, found: %s", line)
+		}
+		sched, err := standardParser.Parse(strings.Join(spec, " "))
+		if err != nil {
+			return nil, err
+		}
+		named.Schedule, rest = sched.(*SpecSchedule), minutes
+	}
+
+	if user, command := cutFields(rest, 1); len(user) == 1 && command != "" && userName.MatchString(user[0]) {
+		named.User, rest = user[0], command
+	}
+	if rest == "" {
+		return nil, fmt.Errorf("missing command: %s", line)
+	}
+	named.Command = rest
+	return named, nil
+}
+
+// cutFields splits the first n whitespace-separated fields from s, returning
+// them and the rest of s, with its own spacing, trimmed.
+func cutFields(s string, n int) (fields []string, rest string) {
+	rest = strings.TrimSpace(s)
+	for len(fields) < n && rest != "" {
+		i := strings.IndexAny(rest, " \t")
+		if i < 0 {
+			return append(fields, rest), ""
+		}
+		fields = append(fields, rest[:i])
+		rest = strings.TrimSpace(rest[i:])
+	}
+	return fields, rest
+}
+
+// isAssignment reports whether the line sets an environment variable, as in
+// "SHELL=/bin/sh" or "MAILTO = root".
+func isAssignment(line string) bool {
+	name, _, ok := strings.Cut(line, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return false
+	}
+	for i, c := range name {
+		if !(c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || i > 0 && '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
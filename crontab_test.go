@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCrontab(t *testing.T) {
+	crontab := `
+# Edit this file to introduce tasks to be run by cron.
+SHELL=/bin/sh
+MAILTO = ops
+
+0 2 * * *   /usr/bin/backup --full   > /dev/null
+@daily /usr/local/bin/cleanup
+@reboot /usr/bin/warm-cache
+30 */5 9-17 * * mon-fri /usr/bin/poll
+17 * * * * root cd / && run-parts --report /etc/cron.hourly
+	# indented comment
+@weekly backup /opt/backup/rotate.sh
+`
+	schedules, err := ParseCrontab(strings.NewReader(crontab))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		spec, user, command string
+	}{
+		{"0 2 * * *", "", "/usr/bin/backup --full   > /dev/null"},
+		{"@daily", "", "/usr/local/bin/cleanup"},
+		{"", "", "/usr/bin/warm-cache"},
+		{"30 */5 9-17 * * mon-fri", "", "/usr/bin/poll"},
+		{"17 * * * *", "root", "cd / && run-parts --report /etc/cron.hourly"},
+		{"@weekly", "backup", "/opt/backup/rotate.sh"},
+	}
+	if len(schedules) != len(tests) {
+		t.Fatalf("expected %d schedules, got %d", len(tests), len(schedules))
+	}
+	for i, test := range tests {
+		named := schedules[i]
+		if test.spec == "" {
+			if named.Schedule != nil {
+				t.Errorf("%d: expected no schedule for @reboot, got %v", i, named.Schedule)
+			}
+		} else {
+			parser := standardParser
+			if len(strings.Fields(test.spec)) == 6 {
+				parser = layoutParsers[SecondsLayout]
+			}
+			expected, err := parser.Parse(test.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if named.Schedule == nil || !named.Schedule.Equal(expected.(*SpecSchedule)) {
+				t.Errorf("%d: expected %s, got %v", i, test.spec, named.Schedule)
+			}
+		}
+		if named.User != test.user || named.Command != test.command {
+			t.Errorf("%d: expected user %q and command %q, got %q and %q", i, test.user, test.command, named.User, named.Command)
+		}
+		if !strings.Contains(crontab, named.RawLine) || named.RawLine != strings.TrimSpace(named.RawLine) {
+			t.Errorf("%d: unexpected raw line %q", i, named.RawLine)
+		}
+	}
+}
+
+func TestParseCrontabErrors(t *testing.T) {
+	for _, crontab := range []string{
+		"0 2 * * *",
+		"0 2 * *",
+		"0 25 * * * /usr/bin/backup",
+		"@every 5m /usr/bin/poll",
+		"@often /usr/bin/poll",
+		"@reboot",
+	} {
+		if _, err := ParseCrontab(strings.NewReader("# header\n" + crontab)); err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+			t.Errorf("%q: expected an error on line 2, got %v", crontab, err)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package cron
+
+import "time"
+
+// DebounceSchedule suppresses bursts of activations from another schedule:
+// any activation less than Window after the last one it returned is skipped.
+// This limits how closely activations follow one another, whereas spacing
+// them out evenly would also delay isolated ones, which debouncing leaves
+// alone.
+//
+// It remembers the last activation it returned, so each entry needs its own,
+// and it is not safe for concurrent use.
+type DebounceSchedule struct {
+	Inner  Schedule
+	Window time.Duration
+
+	last time.Time
+}
+
+// Debounce returns a schedule that activates as inner does, skipping
+// activations less than window after the last one.
+func Debounce(inner Schedule, window time.Duration) *DebounceSchedule {
+	return &DebounceSchedule{Inner: inner, Window: window}
+}
+
+// Next returns the inner schedule's next activation after t that is at least
+// Window after the last one returned, or the zero time if there is none.
+func (s *DebounceSchedule) Next(t time.Time) time.Time {
+	next := s.Inner.Next(t)
+	for !next.IsZero() && !s.last.IsZero() && next.Sub(s.last) < s.Window {
+		next = s.Inner.Next(next)
+	}
+	if !next.IsZero() {
+		s.last = next
+	}
+	return next
+}
+
+// NextOK is like Next, but reports whether an activation was found.
+func (s *DebounceSchedule) NextOK(t time.Time) (time.Time, bool) {
+	next := s.Next(t)
+	return next, !next.IsZero()
+}
+
+// Latest returns the zero time, as activations depend on those returned by
+// Next before.
+func (s *DebounceSchedule) Latest(t time.Time) time.Time {
+	return time.Time{}
+}
+
+// Reset forgets the last activation, so that the next one is not debounced.
+func (s *DebounceSchedule) Reset() {
+	s.last = time.Time{}
+}
@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDebounceSchedule(t *testing.T) {
+	sched := Debounce(Every(time.Second), 5*time.Second)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	prev := sched.Next(start)
+	if !prev.Equal(start.Add(time.Second)) {
+		t.Errorf("expected the first activation undebounced, got %v", prev)
+	}
+	for i := 0; i < 10; i++ {
+		next := sched.Next(prev)
+		if gap := next.Sub(prev); gap != 5*time.Second {
+			t.Errorf("expected activations 5s apart, got %v", gap)
+		}
+		prev = next
+	}
+
+	// After a reset the next activation is not debounced.
+	sched.Reset()
+	if next := sched.Next(prev); !next.Equal(prev.Add(time.Second)) {
+		t.Errorf("expected no debouncing after a reset, got %v", next)
+	}
+}
+
+func TestDebounceScheduleIsolated(t *testing.T) {
+	inner := mustParse(t, "TZ=UTC 0 9,17 * * *")
+	sched := Debounce(inner, time.Minute)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		next, expected := sched.Next(from), inner.Next(from)
+		if !next.Equal(expected) {
+			t.Errorf("expected isolated activations to be kept: expected %v, got %v", expected, next)
+		}
+		from = next
+	}
+}
+
+func TestDebounceScheduleBursts(t *testing.T) {
+	// Bursts of activations a second apart, every 10 minutes.
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var burst timesSchedule
+	for b := 0; b < 3; b++ {
+		for i := 0; i < 4; i++ {
+			burst = append(burst, start.Add(time.Duration(b)*10*time.Minute+time.Duration(i)*time.Second))
+		}
+	}
+	sched := Debounce(burst, 30*time.Second)
+
+	var got []time.Time
+	for next := sched.Next(start.Add(-time.Second)); !next.IsZero(); next = sched.Next(next) {
+		got = append(got, next)
+	}
+	expected := []time.Time{start, start.Add(10 * time.Minute), start.Add(20 * time.Minute)}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range got {
+		if !got[i].Equal(expected[i]) {
+			t.Errorf("%d: expected %v, got %v", i, expected[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// StartDelaySchedule holds off another schedule's activations until a delay
+// has passed since it was first asked for one, e.g. so that a job doesn't
+// start until a service has warmed up. Its first activation is the end of the
+// delay, whatever the inner schedule says; after that it activates as the
+// inner schedule does. Unlike SpecSchedule.Shift, which moves every
+// activation, only the start is put off, and unlike SpecSchedule.Rebase, by a
+// delay rather than to a date.
+//
+// The delay starts from the first call to Next, so an entry added later than
+// another starts later even if both share the schedule; give each its own.
+type StartDelaySchedule struct {
+	inner Schedule
+	delay time.Duration
+
+	mu    sync.Mutex
+	start time.Time // of the delay, or zero until Next is first called
+}
+
+// DelayedSchedule returns a schedule whose first activation is delay after
+// the time first given to Next, and whose later ones are inner's.
+func DelayedSchedule(inner Schedule, delay time.Duration) *StartDelaySchedule {
+	return &StartDelaySchedule{inner: inner, delay: delay}
+}
+
+// Next returns the end of the delay if t is before it, starting the delay at
+// t if it hasn't started, and otherwise the inner schedule's next activation.
+func (s *StartDelaySchedule) Next(t time.Time) time.Time {
+	s.mu.Lock()
+	if s.start.IsZero() {
+		s.start = t
+	}
+	end := s.start.Add(s.delay)
+	s.mu.Unlock()
+	if t.Before(end) {
+		return end
+	}
+	return s.inner.Next(t)
+}
+
+// NextOK is like Next, but reports whether there is a next activation rather
+// than returning the zero time.
+func (s *StartDelaySchedule) NextOK(t time.Time) (time.Time, bool) {
+	next := s.Next(t)
+	return next, !next.IsZero()
+}
+
+// Latest returns the latest activation at or before t: the inner schedule's,
+// or the end of the delay if that is later. It returns the zero time if the
+// delay hasn't started or isn't over by t.
+func (s *StartDelaySchedule) Latest(t time.Time) time.Time {
+	s.mu.Lock()
+	start := s.start
+	s.mu.Unlock()
+	end := start.Add(s.delay)
+	if start.IsZero() || t.Before(end) {
+		return time.Time{}
+	}
+	if latest := s.inner.Latest(t); latest.After(end) {
+		return latest
+	}
+	return end
+}
+
+// Reset restarts the delay, from the time next given to Next. A Cron running
+// the schedule picks this up the next time it computes the entry's next
+// activation.
+func (s *StartDelaySchedule) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.start = time.Time{}
+}
@@ -0,0 +1,58 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDelayedSchedule(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sched := DelayedSchedule(Every(time.Minute), 5*time.Minute)
+	if latest := sched.Latest(start); !latest.IsZero() {
+		t.Errorf("expected no latest activation before the delay starts, got %v", latest)
+	}
+
+	// The first activation is the end of the delay, however often the inner
+	// schedule runs, and later ones are the inner schedule's.
+	next := sched.Next(start)
+	if !next.Equal(start.Add(5 * time.Minute)) {
+		t.Errorf("expected the end of the delay, got %v", next)
+	}
+	if n := sched.Next(start.Add(3 * time.Minute)); !n.Equal(next) {
+		t.Errorf("expected the end of the delay again, got %v", n)
+	}
+	if latest := sched.Latest(start.Add(3 * time.Minute)); !latest.IsZero() {
+		t.Errorf("expected no latest activation during the delay, got %v", latest)
+	}
+	if n := sched.Next(next); !n.Equal(next.Add(time.Minute)) {
+		t.Errorf("expected the inner schedule's activation, got %v", n)
+	}
+	if latest := sched.Latest(next.Add(30 * time.Second)); !latest.Equal(next) {
+		t.Errorf("expected the end of the delay as the latest activation, got %v", latest)
+	}
+
+	// Reset starts the delay over from the next call.
+	later := start.Add(time.Hour)
+	sched.Reset()
+	if n := sched.Next(later); !n.Equal(later.Add(5 * time.Minute)) {
+		t.Errorf("expected the delay to restart, got %v", n)
+	}
+}
+
+func TestDelayedScheduleRun(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := newFakeClock(start)
+	cron := New(WithLocation(time.UTC), WithLogger(DiscardLogger))
+	cron.clock = fc
+	cron.Schedule(DelayedSchedule(mustParse(t, "TZ=UTC * * * * *"), 90*time.Second), FuncJob(func() {}))
+	cron.Start()
+	defer cron.Stop()
+
+	timer := <-fc.timers
+	for _, expected := range []time.Duration{90 * time.Second, 2 * time.Minute, 3 * time.Minute} {
+		if !timer.deadline.Equal(start.Add(expected)) {
+			t.Errorf("expected an activation at %v, got %v", start.Add(expected), timer.deadline)
+		}
+		timer = fc.advance(timer)
+	}
+}
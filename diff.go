@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxDiffActivations is the most activations Diff will enumerate for each
+// schedule before giving up.
+const MaxDiffActivations = 100000
+
+// Diff returns the activations of oldSched in [from, to) that newSched does
+// not share, and those of newSched that oldSched does not share, each in
+// chronological order. Activations are found with Next, exactly as they would
+// be run, so each schedule's own location applies; two activations are shared
+// if they are the same instant.
+//
+// If two SpecSchedules are Equal, Diff returns without enumerating either. It
+// returns an error if either schedule activates more than MaxDiffActivations
+// times in the window.
+func Diff(oldSched, newSched Schedule, from, to time.Time) (removed, added []time.Time, err error) {
+	if a, ok := oldSched.(*SpecSchedule); ok {
+		if b, ok := newSched.(*SpecSchedule); ok && a.Equal(b) {
+			return nil, nil, nil
+		}
+	}
+	before, err := activations(oldSched, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	after, err := activations(newSched, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	i, j := 0, 0
+	for i < len(before) && j < len(after) {
+		switch {
+		case before[i].Equal(after[j]):
+			i++
+			j++
+		case before[i].Before(after[j]):
+			removed = append(removed, before[i])
+			i++
+		default:
+			added = append(added, after[j])
+			j++
+		}
+	}
+	removed = append(removed, before[i:]...)
+	added = append(added, after[j:]...)
+	return removed, added, nil
+}
+
+// activations returns the schedule's activations in [from, to), or an error if
+// there are more than MaxDiffActivations.
+func activations(sched Schedule, from, to time.Time) ([]time.Time, error) {
+	var times []time.Time
+	for t := sched.Next(from.Add(-time.Nanosecond)); !t.IsZero() && t.Before(to); t = sched.Next(t) {
+		if len(times) == MaxDiffActivations {
+			return nil, fmt.Errorf("more than %d activations from %v to %v", MaxDiffActivations, from, to)
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
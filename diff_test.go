@@ -0,0 +1,63 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleDiff(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, 1, day, hour, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		old, new       string
+		removed, added []time.Time
+	}{
+		{"TZ=UTC 0 9 * * *", "TZ=UTC 0 9 * * *", nil, nil},
+		{"TZ=UTC 0 9 * * *", "TZ=UTC 0 9 * * 1-5", []time.Time{at(6, 9), at(7, 9)}, nil},
+		{"TZ=UTC 0 9 * * 1-5", "TZ=UTC 0 9,17 * * 1,2",
+			[]time.Time{at(3, 9), at(4, 9), at(5, 9)},
+			[]time.Time{at(1, 17), at(2, 17)}},
+		// The same times of day, but an hour apart in UTC.
+		{"TZ=UTC 0 9 * * 1", "TZ=Europe/Paris 0 9 * * 1", []time.Time{at(1, 9)}, []time.Time{at(1, 8)}},
+	}
+	for _, c := range tests {
+		removed, added, err := Diff(mustParse(t, c.old), mustParse(t, c.new), from, from.AddDate(0, 0, 7))
+		if err != nil {
+			t.Errorf("%s to %s: %v", c.old, c.new, err)
+			continue
+		}
+		if !equalTimes(removed, c.removed) || !equalTimes(added, c.added) {
+			t.Errorf("%s to %s: expected -%v +%v, got -%v +%v", c.old, c.new, c.removed, c.added, removed, added)
+		}
+	}
+
+	// Any schedule can be compared.
+	removed, added, err := Diff(Every(12*time.Hour), mustParse(t, "TZ=UTC 0 0,12 * * *"), from.Add(-time.Nanosecond), from.Add(36*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 3 || len(added) != 3 {
+		t.Errorf("expected intervals a second early to share nothing, got -%v +%v", removed, added)
+	}
+
+	if _, _, err := Diff(mustParse(t, "* * * * *"), mustParse(t, "*/2 * * * *"), from, from.AddDate(1, 0, 0)); err == nil {
+		t.Error("expected an error for too many activations")
+	}
+	if _, _, err := Diff(mustParse(t, "* * * * *"), mustParse(t, "* * * * *"), from, from.AddDate(1, 0, 0)); err != nil {
+		t.Errorf("expected equal schedules not to be enumerated, got %v", err)
+	}
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
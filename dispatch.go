@@ -0,0 +1,329 @@
+package cron
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Tenancy configures a Cron that is shared between tenants, so that no tenant
+// can crowd out the others. Each entry is assigned to a tenant when it is added.
+type Tenancy struct {
+	// Key returns the tenant of the given entry. The entry's ID, Schedule, Job
+	// and Group are set when it is called. If nil, each group is a tenant.
+	Key func(Entry) string
+
+	// MaxEntries limits the number of entries each tenant may have, or is zero
+	// for no limit. Adding an entry beyond it fails with a *QuotaExceededError.
+	MaxEntries int
+
+	// MaxConcurrent limits the number of each tenant's jobs that may run at
+	// once, or is zero for no limit.
+	MaxConcurrent int
+
+	// Observe, if set, is called after each run of a job with the tenant the
+	// job belongs to and how long it took, e.g. to record metrics.
+	Observe func(tenant string, id EntryID, took time.Duration)
+}
+
+// QuotaExceededError is returned when adding an entry would take its tenant
+// over its limit.
+type QuotaExceededError struct {
+	Tenant string
+	Limit  int
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("tenant %q may not have more than %d entries", e.Tenant, e.Limit)
+}
+
+// OverflowPolicy determines what happens when a job falls due while the queue
+// of jobs waiting to run is full (see WithQueueSize).
+type OverflowPolicy int
+
+const (
+	// OverflowDropOldest drops the job that has waited longest, making room
+	// for the new one.
+	OverflowDropOldest OverflowPolicy = iota
+
+	// OverflowDropNewest drops the job that fell due.
+	OverflowDropNewest
+
+	// OverflowBlock makes the run loop wait for room in the queue before
+	// starting the job. Use it with care: while the run loop waits, later
+	// activations fall due late, and calls that go through it, such as Stop,
+	// Entries and Remove, wait too.
+	OverflowBlock
+)
+
+// dispatcher limits the number of jobs running at once, both in total and for
+// each tenant. Jobs that can't run yet wait in a queue per tenant, and the
+// queues are served round-robin as jobs finish so that a tenant with many
+// jobs due can't starve the others.
+type dispatcher struct {
+	mu        sync.Mutex
+	max       int // total, or 0 for no limit
+	tenantMax int // per tenant, or 0 for no limit
+	running   int
+	tenants   map[string]*tenantQueue // those running or waiting, by key
+	ring      []string                // tenants in the order they first had to wait
+
+	// size limits the number of jobs waiting across all tenants, or is 0 for
+	// no limit, and policy says what happens beyond it.
+	size   int
+	policy OverflowPolicy
+	seq    uint64 // of the job that last had to wait
+
+	// arriving counts the jobs admitted under OverflowBlock that are yet to
+	// reach acquire, and room is signalled as the queue shrinks.
+	arriving int
+	room     *sync.Cond
+}
+
+type tenantQueue struct {
+	running int
+	waiting []*waiter
+}
+
+// waiter is a job waiting to run. Ready is closed once it may run, or once it
+// has been dropped to make room for a later job.
+type waiter struct {
+	ready   chan struct{}
+	seq     uint64
+	dropped bool
+}
+
+func newDispatcher(max, tenantMax, size int, policy OverflowPolicy) *dispatcher {
+	d := &dispatcher{
+		max:       max,
+		tenantMax: tenantMax,
+		tenants:   make(map[string]*tenantQueue),
+		size:      size,
+		policy:    policy,
+	}
+	d.room = sync.NewCond(&d.mu)
+	return d
+}
+
+const (
+	dispatchRun       = iota
+	dispatchCancelled // done was closed
+	dispatchDropped   // the queue was full
+)
+
+// acquire waits until the tenant may run a job, giving up if done is closed
+// first, or if the queue is full and the overflow policy drops the job. It
+// returns whether the job may run, was cancelled or was dropped. Admitted is
+// true if the job was admitted by admit.
+func (d *dispatcher) acquire(tenant string, done <-chan struct{}, admitted bool) int {
+	d.mu.Lock()
+	if admitted {
+		d.arriving--
+		d.room.Broadcast()
+	}
+	t := d.tenant(tenant)
+	if len(t.waiting) == 0 && d.allowed(t) {
+		d.running++
+		t.running++
+		d.mu.Unlock()
+		return dispatchRun
+	}
+	if d.size > 0 && d.queued() >= d.size {
+		switch d.policy {
+		case OverflowDropNewest:
+			d.prune(tenant)
+			d.mu.Unlock()
+			return dispatchDropped
+		case OverflowDropOldest:
+			d.dropOldest()
+			t = d.tenant(tenant) // in case dropping its job forgot it
+		}
+	}
+	d.seq++
+	w := &waiter{ready: make(chan struct{}), seq: d.seq}
+	if len(t.waiting) == 0 && !d.inRing(tenant) {
+		d.ring = append(d.ring, tenant)
+	}
+	t.waiting = append(t.waiting, w)
+	d.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		if w.dropped {
+			return dispatchDropped
+		}
+		return dispatchRun
+	case <-done:
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := range t.waiting {
+		if t.waiting[i] == w {
+			t.waiting = append(t.waiting[:i], t.waiting[i+1:]...)
+			d.room.Broadcast()
+			d.prune(tenant)
+			return dispatchCancelled
+		}
+	}
+	if w.dropped {
+		return dispatchDropped
+	}
+	// The slot was granted as we gave up; pass it on.
+	d.running--
+	t.running--
+	d.grant(tenant)
+	d.prune(tenant)
+	return dispatchCancelled
+}
+
+// admit waits, under OverflowBlock, until there is room in the queue for a
+// job that is about to be started, and reports whether it did so. The job
+// must then call acquire, or arrive if it gives up first.
+func (d *dispatcher) admit() bool {
+	if d.size <= 0 || d.policy != OverflowBlock {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for d.queued()+d.arriving >= d.size {
+		d.room.Wait()
+	}
+	d.arriving++
+	return true
+}
+
+// arrive gives up the room in the queue of a job admitted by admit that
+// won't call acquire.
+func (d *dispatcher) arrive() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.arriving--
+	d.room.Broadcast()
+}
+
+// dropOldest drops the job that has waited longest.
+func (d *dispatcher) dropOldest() {
+	var (
+		oldest *tenantQueue
+		key    string
+	)
+	for tenant, t := range d.tenants {
+		if len(t.waiting) > 0 && (oldest == nil || t.waiting[0].seq < oldest.waiting[0].seq) {
+			oldest, key = t, tenant
+		}
+	}
+	if oldest == nil {
+		return
+	}
+	w := oldest.waiting[0]
+	oldest.waiting = oldest.waiting[1:]
+	w.dropped = true
+	close(w.ready)
+	d.prune(key)
+}
+
+// release gives up the tenant's slot, handing it to a waiting job.
+func (d *dispatcher) release(tenant string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.running--
+	d.tenants[tenant].running--
+	d.grant(tenant)
+	d.prune(tenant)
+}
+
+// grant starts as many waiting jobs as the limits allow, taking one from each
+// tenant in turn beginning with the tenant after last.
+func (d *dispatcher) grant(last string) {
+	start := 0
+	for i, tenant := range d.ring {
+		if tenant == last {
+			start = i + 1
+		}
+	}
+	for granted := true; granted; {
+		granted = false
+		for i := range d.ring {
+			tenant := d.ring[(start+i)%len(d.ring)]
+			t := d.tenants[tenant]
+			if len(t.waiting) == 0 || !d.allowed(t) {
+				continue
+			}
+			d.running++
+			t.running++
+			close(t.waiting[0].ready)
+			t.waiting = t.waiting[1:]
+			d.room.Broadcast()
+			start = (start + i + 1) % len(d.ring)
+			granted = true
+			break
+		}
+	}
+}
+
+// allowed reports whether the tenant may start another job.
+func (d *dispatcher) allowed(t *tenantQueue) bool {
+	return (d.max <= 0 || d.running < d.max) &&
+		(d.tenantMax <= 0 || t.running < d.tenantMax)
+}
+
+func (d *dispatcher) tenant(tenant string) *tenantQueue {
+	t, ok := d.tenants[tenant]
+	if !ok {
+		t = &tenantQueue{}
+		d.tenants[tenant] = t
+	}
+	return t
+}
+
+// prune forgets the tenant if it has nothing running and nothing waiting, so
+// that tenants that come and go don't accumulate. The caller must hold mu.
+func (d *dispatcher) prune(tenant string) {
+	t, ok := d.tenants[tenant]
+	if !ok || t.running > 0 || len(t.waiting) > 0 {
+		return
+	}
+	delete(d.tenants, tenant)
+	for i, r := range d.ring {
+		if r == tenant {
+			d.ring = append(d.ring[:i], d.ring[i+1:]...)
+			break
+		}
+	}
+}
+
+func (d *dispatcher) inRing(tenant string) bool {
+	for _, t := range d.ring {
+		if t == tenant {
+			return true
+		}
+	}
+	return false
+}
+
+// waiting returns the number of jobs waiting to run.
+func (d *dispatcher) waiting() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.queued()
+}
+
+// queued returns the number of jobs waiting to run. The caller must hold mu.
+func (d *dispatcher) queued() int {
+	n := 0
+	for _, t := range d.tenants {
+		n += len(t.waiting)
+	}
+	return n
+}
+
+// QueueDepth returns the number of jobs waiting to run under the limits of
+// WithMaxConcurrency and the Cron's Tenancy, e.g. to export as a gauge. It is
+// always 0 without such limits.
+func (c *Cron) QueueDepth() int {
+	if c.dispatch == nil {
+		return 0
+	}
+	return c.dispatch.waiting()
+}
@@ -0,0 +1,221 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTenantQuota(t *testing.T) {
+	cron := New(WithTenancy(Tenancy{MaxEntries: 2}))
+	acme := cron.Group("acme")
+	for i := 0; i < 2; i++ {
+		if _, err := acme.AddFunc("@every 1h", func() {}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := acme.AddFunc("@every 1h", func() {})
+	var quota *QuotaExceededError
+	if !errors.As(err, &quota) || quota.Tenant != "acme" || quota.Limit != 2 {
+		t.Fatalf("expected a quota error for acme, got %v", err)
+	}
+
+	// Other tenants are unaffected, and removing an entry frees its quota.
+	if _, err := cron.Group("initech").AddFunc("@every 1h", func() {}); err != nil {
+		t.Error(err)
+	}
+	acme.Remove(acme.Entries()[0].ID)
+	if _, err := acme.AddFunc("@every 1h", func() {}); err != nil {
+		t.Error(err)
+	}
+	for _, e := range cron.Entries() {
+		if e.Tenant != e.Group {
+			t.Errorf("expected the tenant to be the group, got %s", e.Tenant)
+		}
+	}
+}
+
+func TestTenantFairness(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		order    []string
+		observed = make(map[string]int)
+		release  = make(chan struct{})
+	)
+	cron, fc := newFakeCron(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		WithMaxConcurrency(1),
+		WithTenancy(Tenancy{
+			Key: func(e Entry) string {
+				if e.ID <= 4 {
+					return "noisy"
+				}
+				return "quiet"
+			},
+			Observe: func(tenant string, id EntryID, took time.Duration) {
+				mu.Lock()
+				observed[tenant]++
+				mu.Unlock()
+			},
+		}))
+	for i := 1; i <= 6; i++ {
+		id := EntryID(i)
+		cron.AddFunc("0 * * * * ?", func() {
+			mu.Lock()
+			order = append(order, cron.tenancy.Key(Entry{ID: id}))
+			mu.Unlock()
+			<-release
+		})
+	}
+	cron.Start()
+	fc.advance(<-fc.timers)
+
+	// Wait for all but the running job to queue up.
+	for cron.dispatch.waiting() < 5 {
+		time.Sleep(time.Millisecond)
+	}
+	for i := 0; i < 6; i++ {
+		release <- struct{}{}
+	}
+	<-cron.Stop().Done()
+
+	// Once both tenants are waiting, they take turns until the quiet one runs out.
+	if len(order) != 6 {
+		t.Fatalf("expected 6 runs, got %v", order)
+	}
+	last := 0
+	for i, tenant := range order {
+		if tenant == "quiet" {
+			last = i
+		}
+	}
+	for i := 1; i <= last; i++ {
+		if order[i] == order[i-1] {
+			t.Fatalf("expected tenants to take turns, got %v", order)
+		}
+	}
+	if observed["noisy"] != 4 || observed["quiet"] != 2 {
+		t.Errorf("expected runs to be observed per tenant, got %v", observed)
+	}
+}
+
+func TestQueueOverflow(t *testing.T) {
+	second := func(s int) time.Time { return time.Date(2024, 1, 1, 0, 0, s, 0, time.UTC) }
+	tests := []struct {
+		policy  OverflowPolicy
+		ran     []EntryID
+		dropped map[EntryID]time.Time
+	}{
+		{OverflowDropNewest, []EntryID{1, 2, 3}, map[EntryID]time.Time{4: second(4)}},
+		{OverflowDropOldest, []EntryID{1, 3, 4}, map[EntryID]time.Time{2: second(2)}},
+		{OverflowBlock, []EntryID{1, 2, 3, 4}, map[EntryID]time.Time{}},
+	}
+	for _, c := range tests {
+		var (
+			mu      sync.Mutex
+			ran     []EntryID
+			dropped = make(map[EntryID]time.Time)
+			release = make(chan struct{})
+		)
+		hooks := EntryHooks{OnDropped: func(e Entry, scheduled time.Time) {
+			mu.Lock()
+			defer mu.Unlock()
+			dropped[e.ID] = scheduled
+		}}
+		cron, fc := newFakeCron(second(0), WithMaxConcurrency(1), WithQueueSize(2, c.policy), WithEntryHooks(hooks))
+		// Entry i runs at second i, and the first holds the only slot until
+		// released, so that the rest queue up behind it.
+		for i := 1; i <= 4; i++ {
+			id := EntryID(i)
+			cron.AddFunc(fmt.Sprintf("%d * * * * ?", i), func() {
+				mu.Lock()
+				ran = append(ran, id)
+				mu.Unlock()
+				if id == 1 {
+					<-release
+				}
+			})
+		}
+		counts := func() (int, int) {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(ran), len(dropped)
+		}
+		cron.Start()
+
+		timer := fc.advance(<-fc.timers)
+		waitFor(t, func() bool { n, _ := counts(); return n == 1 })
+		timer = fc.advance(timer)
+		waitFor(t, func() bool { return cron.QueueDepth() == 1 })
+		timer = fc.advance(timer)
+		waitFor(t, func() bool { return cron.QueueDepth() == 2 })
+
+		// The queue is full when the fourth entry falls due.
+		if c.policy == OverflowBlock {
+			fc.fire(timer, second(4))
+			select {
+			case <-fc.timers:
+				t.Fatal("expected the run loop to wait for room in the queue")
+			case <-time.After(50 * time.Millisecond):
+			}
+			close(release)
+			<-fc.timers
+		} else {
+			fc.advance(timer)
+			waitFor(t, func() bool { _, n := counts(); return n == 1 })
+			if depth := cron.QueueDepth(); depth != 2 {
+				t.Errorf("%d: expected a queue of 2, got %d", c.policy, depth)
+			}
+			close(release)
+		}
+		waitFor(t, func() bool { n, _ := counts(); return n == len(c.ran) })
+		<-cron.Stop().Done()
+
+		mu.Lock()
+		if !reflect.DeepEqual(ran, c.ran) || !reflect.DeepEqual(dropped, c.dropped) {
+			t.Errorf("%d: expected runs %v and drops %v, got %v and %v", c.policy, c.ran, c.dropped, ran, dropped)
+		}
+		mu.Unlock()
+		if depth := cron.QueueDepth(); depth != 0 {
+			t.Errorf("%d: expected an empty queue, got %d", c.policy, depth)
+		}
+	}
+}
+
+func TestDispatcherForgetsIdleTenants(t *testing.T) {
+	d := newDispatcher(1, 0, 0, OverflowDropNewest)
+	for i := 0; i < 100; i++ {
+		tenant := fmt.Sprint("tenant", i)
+		if d.acquire(tenant, nil, false) != dispatchRun {
+			t.Fatalf("expected %s to run", tenant)
+		}
+		d.release(tenant)
+	}
+
+	// A tenant that waits is kept until its job has run, and one that gives
+	// up waiting is forgotten at once.
+	d.acquire("a", nil, false)
+	ran := make(chan int)
+	go func() { ran <- d.acquire("b", nil, false) }()
+	done := make(chan struct{})
+	cancelled := make(chan int)
+	go func() { cancelled <- d.acquire("c", done, false) }()
+	waitFor(t, func() bool { return d.waiting() == 2 })
+	close(done)
+	if r := <-cancelled; r != dispatchCancelled {
+		t.Errorf("expected c to be cancelled, got %d", r)
+	}
+	d.release("a")
+	if r := <-ran; r != dispatchRun {
+		t.Errorf("expected b to run, got %d", r)
+	}
+	d.release("b")
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.tenants) != 0 || len(d.ring) != 0 {
+		t.Errorf("expected idle tenants to be forgotten, got %v and %v", d.tenants, d.ring)
+	}
+}
@@ -36,6 +36,17 @@ them in their own goroutines.
 	..
 	c.Stop()  // Stop the scheduler (does not stop any jobs already running).
 
+A program that ties its lifetime to a context may instead call RunWithContext,
+which runs the scheduler until the context is done, then waits for running
+jobs to finish, up to the timeout set by WithDrainTimeout. ContextJobs, such as
+those added with AddTyped, are passed the context:
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := c.RunWithContext(ctx); err != nil {
+		log.Print(err)
+	}
+
 CRON Expression Format
 
 A cron expression represents a set of times, using 5 space-separated fields.
@@ -44,16 +55,53 @@ A cron expression represents a set of times, using 5 space-separated fields.
 	----------   | ---------- | --------------  | --------------------------
 	Minutes      | Yes        | 0-59            | * / , -
 	Hours        | Yes        | 0-23            | * / , -
-	Day of month | Yes        | 1-31            | * / , - ? L 1L 2L 3L 4L 5L 6L 7L
+	Day of month | Yes        | 1-31            | * / , - ? L 1L 2L 3L 4L 5L 6L 7L W LW
 	Month        | Yes        | 1-12 or JAN-DEC | * / , -
-	Day of week  | Yes        | 0-6 or SUN-SAT  | * / , - ? 0L to 6L SUNL to SATL
+	Day of week  | Yes        | 0-6 or SUN-SAT  | * / , - ? 0L to 6L SUNL to SATL #
 
 Month and Day-of-week field values are case insensitive.  "SUN", "Sun", and
-"sun" are equally accepted.
+"sun" are equally accepted. Names may also be spelled out in full ("sunday",
+"january"), or shortened to any unambiguous prefix of three letters or more
+("thur", "sept").
+
+Parser.WithNames adds the names of another language, such as "fr" for
+"0 9 * * lun-ven", and Parser.WithNameTable adds names of your own. English
+names remain accepted.
+
+Numbered days of the week start from Sunday, unless the parser is created with
+the WeekStartsMonday option, in which case 0 is Monday and 6 is Sunday. Named
+days are unaffected.
+
+A parser created with the Week option takes a further field after the others,
+restricting the schedule to ISO 8601 weeks of the year (1-53). For example,
+"0 9 * * MON 1,26" runs on the Mondays of weeks 1 and 26. Note that week 1
+may start in the previous December.
+
+A parser created with the Millisecond option takes a further field before the
+others, for the milliseconds of each second (0-999). For example,
+"*\/250 * * * * * *" with the seconds field runs four times a second. Schedules
+without it run at the start of each second, as before.
+
+With a parser created with the ExcludeDates option, or ParseFlexible, a spec
+may end with a list of dates on which it doesn't run, each given as
+YYYY-MM-DD after a "!", in the schedule's location. For example,
+"0 9 * * MON-FRI !2024-12-25,2025-01-01" runs on weekdays apart from Christmas
+and New Year's Day.
+
+Fields are separated by spaces, so "0 0 9, 12 * *" has six fields rather than
+five. A parser created with the LenientLists option joins such fragments of a
+list back together, reading it as "0 0 9,12 * *", and if there are still too
+many fields, its error names the first one too many.
 
 L in day of month indicates last day in the month (eom),  1L means eom - 1 , etc...
 Additional L in  day of week indicates last occurance of the day in the month
 
+W after a day of month indicates the weekday nearest that day, within the same
+month: 15W runs on the 15th if it is a weekday, on Friday the 14th if it is a
+Saturday, and on Monday the 16th if it is a Sunday. LW is the last weekday of
+the month. # in day of week indicates the nth such day of the month: MON#2 is
+the second Monday, and 5#1 the first Friday.
+
 The specific interpretation of the format is based on the Cron Wikipedia page:
 https://en.wikipedia.org/wiki/Cron
 
@@ -76,6 +124,9 @@ parser you saw earlier, except that its seconds field is REQUIRED:
 That emulates Quartz, the most popular alternative Cron schedule format:
 http://www.quartz-scheduler.org/documentation/quartz-2.x/tutorials/crontrigger.html
 
+ParseQuartz parses Quartz expressions themselves, whose days of the week are
+numbered from 1 (Sunday) to 7, and ParseAWS those of Amazon EventBridge.
+
 Special Characters
 
 Asterisk ( * )
@@ -92,6 +143,8 @@ minutes thereafter. The form "*\/..." is equivalent to the form "first-last/..."
 that is, an increment over the largest possible range of the field.  The form
 "N/..." is accepted as meaning "N-MAX/...", that is, starting at N, use the
 increment until the end of that specific range.  It does not wrap around.
+The ends of a range may be names: "MON-FRI/2" means Mondays, Wednesdays and
+Fridays, and "JAN-DEC/2" the odd months.
 
 Comma ( , )
 
@@ -101,13 +154,31 @@ the 5th field (day of week) would mean Mondays, Wednesdays and Fridays.
 Hyphen ( - )
 
 Hyphens are used to define ranges. For example, 9-17 would indicate every
-hour between 9am and 5pm inclusive.
+hour between 9am and 5pm inclusive. Either end of a range may be left out, for
+the field's minimum or maximum: 9- is every hour from 9am, -17 every hour until
+5pm, and WED- every day from Wednesday to Saturday.
 
 Question mark ( ? )
 
 Question mark may be used instead of '*' for leaving either day-of-month or
 day-of-week blank.
 
+Day of month and day of week
+
+If both day-of-month and day-of-week are restricted, a day matches if it
+satisfies either one, as in other crons: "0 0 1-7 * MON" runs on each of the
+first seven days of the month and on every Monday. To require both, e.g. to
+run on the first Monday of the month, create the parser with the DomAndDow
+option, or begin the day of month with "&", as in "0 0 &1-7 * MON".
+
+A day of month that a month doesn't have, such as 31 in April, never matches
+in that month. With the ClampDom parser option, single days and the ends of
+ranges past the end of a month match its last day instead, so "0 0 31 * *"
+runs on the last day of every month. Days produced by steps are not clamped.
+
+Analyze reports specs like these that are legal but probably mistaken, such
+as "0 0 31 2 *", which never runs, each with a stable WarningCode.
+
 Predefined schedules
 
 You may use one of several pre-defined schedules in place of a cron expression.
@@ -119,6 +190,13 @@ You may use one of several pre-defined schedules in place of a cron expression.
 	@weekly                | Run once a week, midnight between Sat/Sun  | 0 0 * * 0
 	@daily (or @midnight)  | Run once a day, midnight                   | 0 0 * * *
 	@hourly                | Run once an hour, beginning of hour        | 0 * * * *
+	@minutely              | Run once a minute, beginning of minute     | 0 * * * * *
+	@secondly              | Run once a second                          | * * * * * *
+
+The last two are given with a seconds field. Note that @secondly runs a job
+86400 times a day, so use it deliberately.
+SpecSchedule.Shorthand goes the other way, returning the descriptor a
+schedule is equal to, if any.
 
 Intervals
 
@@ -135,7 +213,15 @@ For example, "@every 1h30m10s" would indicate a schedule that activates after
 
 Note: The interval does not take the job runtime into account.  For example,
 if a job takes 3 minutes to run, and it is scheduled to run every 5 minutes,
-it will have only 2 minutes of idle time between each run.
+it will have only 2 minutes of idle time between each run. To measure the
+interval from the end of each run instead, schedule the job with
+EveryAfterCompletion:
+
+	c.Schedule(cron.EveryAfterCompletion(5*time.Minute), job)
+
+ExponentialBackoffSchedule also waits from the end of each run, but doubles
+(or multiplies by another factor) the delay after each failed run of a
+FallibleJob, up to a maximum, and starts over after a success.
 
 Time zones
 
@@ -172,6 +258,16 @@ The prefix "TZ=(TIME ZONE)" is also supported for legacy compatibility.
 Be aware that jobs scheduled during daylight-savings leap-ahead transitions will
 not be run!
 
+Time zones are loaded when a spec is parsed. A Cron that runs for a long time
+can pick up changes to the time zone database, once the host's copy is
+updated, by calling ReloadLocations, which reloads each schedule's time zone by
+name and computes its next activation again.
+
+A time zone kept in configuration may be stored as a Location, which is
+serialized as its name and standard offset, e.g. "Europe/Berlin +01:00". On a
+system whose time zone database lacks the name it is restored as a fixed zone
+with that offset, which loses only daylight saving time.
+
 Job Wrappers
 
 A Cron runner may be configured with a chain of job wrappers to add
@@ -189,12 +285,135 @@ Install wrappers for all jobs added to a cron using the `cron.WithChain` option:
 		cron.SkipIfStillRunning(logger),
 	))
 
-Install wrappers for individual jobs by explicitly wrapping them:
+Wrappers that need to know which entry they are decorating, such as tracing
+middleware, may be installed with `cron.WithEntryWrappers`. They are given the
+entry with its ID and schedule assigned.
+
+Install wrappers for individual jobs by explicitly wrapping them, or by passing
+them when the job is added:
 
 	job = cron.NewChain(
 		cron.SkipIfStillRunning(logger),
 	).Then(job)
 
+	c.AddJob(spec, job, cron.WithEntryChain(cron.SkipIfStillRunning(logger)))
+
+From the outside in, a job is wrapped by the cron's chain, its entry wrappers,
+and then the entry's own chain. An entry may opt out of the cron's wrappers
+with `cron.WithReplacedChain`. Jobs are wrapped when they are added, so
+further wrappers may only be installed with `Cron.Use` before any are added.
+
+Overlapping runs
+
+By default a job runs every time it is due, even if earlier invocations have
+not finished. An entry may instead be limited to a number of concurrent
+invocations, with activations beyond that skipped or queued until one
+finishes:
+
+	c.AddFunc("@every 1m", ingest, cron.WithMaxConcurrentRuns(3, cron.OverlapSkip))
+
+The number of invocations in flight is reported by Entry.Running.
+
+Entries that all fall due soon after the Cron starts can be spread out with
+WithStartupStagger, which puts off the first run of each by an amount chosen
+by its name (see WithName), reported by Entry.Stagger. Later runs keep to the
+schedule.
+
+Rescheduling
+
+A job that implements SelfScheduler chooses its own next run after each
+invocation, e.g. to retry a failure sooner than its schedule would. Jobs
+implementing FallibleJob report the error that NextRun is given.
+
+Batch workloads
+
+When every entry runs a bounded number of times, Wait blocks until all of them
+have finished, and returns the errors of any failed runs:
+
+	c.Start()
+	if err := c.Wait(ctx); err != nil {
+		...
+	}
+
+Typed jobs
+
+AddTyped registers a function together with the value it works on, rather
+than a closure capturing it. The value is reported by Entry.Payload:
+
+	cron.AddTyped(c, "@daily", report{Name: "sales"}, func(ctx context.Context, r report) error {
+		...
+	})
+
+Groups
+
+Entries may be collected into named groups, which can be paused, resumed,
+limited in concurrency, and removed as a unit:
+
+	g := c.Group("tenant-acme", cron.GroupMaxConcurrency(2))
+	g.AddFunc("@hourly", sync)
+	g.Pause()
+	...
+	c.RemoveGroup("tenant-acme")
+
+A group's limit applies on top of the limits of its entries. Entries report
+their group in Entry.Group.
+
+Tenants
+
+A Cron may limit the number of jobs running at once with
+`cron.WithMaxConcurrency`. When it is shared between tenants, WithTenancy
+assigns each entry to a tenant and enforces per-tenant quotas:
+
+	c := cron.New(
+		cron.WithMaxConcurrency(10),
+		cron.WithTenancy(cron.Tenancy{MaxEntries: 100, MaxConcurrent: 3}))
+	c.Group("tenant-acme").AddFunc("@hourly", sync)
+
+Adding an entry beyond a tenant's quota fails with a *QuotaExceededError. Jobs
+waiting for room under the limits are started round-robin between tenants, so
+that one tenant with many jobs due can't starve the others.
+
+The queue of jobs waiting for room is unbounded unless limited with
+WithQueueSize, whose OverflowPolicy drops the oldest or the newest job, or
+holds up the run loop until there is room. Dropped jobs are reported to the
+OnDropped entry hook, and Cron.QueueDepth gives the length of the queue.
+
+Entry hooks
+
+WithEntryHooks registers functions called whenever an entry is added,
+removed, paused or resumed, by whichever call or by the run loop itself, e.g.
+to keep an audit log:
+
+	c := cron.New(cron.WithEntryHooks(cron.EntryHooks{
+		OnRemoved: func(e cron.Entry, reason cron.RemovalReason) {
+			audit.Printf("entry %d removed: %s", e.ID, reason)
+		},
+	}))
+
+Entries added with WithMaxRuns are removed once their job has been started
+that many times, with the reason RemovedMaxRuns.
+With WithRemoveExhausted, entries are removed once their schedule has no
+further activations and their last run is over, with the reason
+RemovedExhausted, so that one-off entries don't pile up; WithExhaustedRemoval
+sets this for a single entry. Entries scheduled with a ScheduleChain, which
+activates once for each of its schedules in turn, are removed after the last
+either way, unless WithExhaustedRemoval(false) is given.
+
+Health
+
+Health reports, for each entry, whether it has stalled (missed its latest
+activation, or gone longer than its WithSLA without running), has no next
+activation although its schedule does, or keeps failing or overrunning:
+
+	for _, h := range c.Health().Unhealthy() {
+		alert(h.ID, h.Problems)
+	}
+
+Each entry snapshot also carries statistics of its job's runs, from
+Entry.Stats: the number of runs and failures, their mean and longest
+durations, and the success rate over the last hour, or the window given by
+WithStatsWindow. ResetStats clears them, e.g. once an incident is over.
+
 Thread safety
 
 Since the Cron service runs concurrently with the calling code, some amount of
@@ -0,0 +1,151 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultStatsWindow is the window over which EntryStats gives recent figures.
+const defaultStatsWindow = time.Hour
+
+// statsBuckets is the number of buckets the window is divided into, so the
+// window slides in steps of a sixtieth of its length.
+const statsBuckets = 60
+
+// EntryStats are statistics of an entry's runs, as of an Entry snapshot. Runs
+// are counted as the job returns, with a run that returned an error or
+// panicked counting as a failure, even if a wrapper such as Recover recovered
+// the panic. Activations that a wrapper such as SkipIfStillRunning kept from
+// running the job aren't counted.
+type EntryStats struct {
+	// Runs is the number of runs since the entry was added, or its stats were
+	// last reset by ResetStats, and Failures the number of them that failed.
+	Runs, Failures int
+
+	// MeanDuration and MaxDuration are the mean and longest durations of
+	// those runs.
+	MeanDuration, MaxDuration time.Duration
+
+	// Window is the span, ending at the snapshot, that RecentRuns,
+	// RecentFailures and SuccessRate cover (see WithStatsWindow).
+	Window time.Duration
+
+	// RecentRuns and RecentFailures count the runs and failures in the window.
+	RecentRuns, RecentFailures int
+
+	// SuccessRate is the fraction of the runs in the window that succeeded,
+	// or 1 if there were none.
+	SuccessRate float64
+}
+
+// Stats returns statistics of the entry's runs as of the snapshot.
+func (e Entry) Stats() EntryStats {
+	return e.runStats
+}
+
+// ResetStats clears the statistics of the given entry's runs, e.g. once an
+// incident is over, and reports whether there is such an entry.
+func (c *Cron) ResetStats(id EntryID) bool {
+	for _, e := range c.Entries() {
+		if e.ID == id {
+			e.stats.reset()
+			return true
+		}
+	}
+	return false
+}
+
+// statsRecorder keeps an entry's statistics. The figures for the window are
+// kept in a ring of buckets, each counting the runs that finished in its
+// stretch of time, so that it takes the same memory however many runs there
+// are. It is shared between the run loop and the goroutines running the job.
+type statsRecorder struct {
+	mu      sync.Mutex
+	clock   clock
+	width   time.Duration // of a bucket
+	total   statsBucket
+	buckets [statsBuckets]statsBucket
+}
+
+// statsBucket counts runs, here in the stretch of time with the given index.
+type statsBucket struct {
+	index    int64
+	runs     int
+	failures int
+	sum, max time.Duration
+}
+
+func (b *statsBucket) add(took time.Duration, failed bool) {
+	b.runs++
+	if failed {
+		b.failures++
+	}
+	b.sum += took
+	if took > b.max {
+		b.max = took
+	}
+}
+
+func newStatsRecorder(clk clock, window time.Duration) *statsRecorder {
+	width := window / statsBuckets
+	if width <= 0 {
+		width = 1
+	}
+	return &statsRecorder{clock: clk, width: width}
+}
+
+// index returns the index of the bucket stretch that t falls in.
+func (r *statsRecorder) index(t time.Time) int64 {
+	return t.UnixNano() / int64(r.width)
+}
+
+// record counts a run that started at the given time and has just finished.
+func (r *statsRecorder) record(start time.Time, failed bool) {
+	now := r.clock.Now()
+	took := now.Sub(start)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.index(now)
+	b := &r.buckets[i%statsBuckets]
+	if b.index != i || b.runs == 0 {
+		*b = statsBucket{index: i}
+	}
+	b.add(took, failed)
+	r.total.add(took, failed)
+}
+
+// read returns the statistics as they are now.
+func (r *statsRecorder) read() EntryStats {
+	now := r.clock.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := EntryStats{
+		Runs:        r.total.runs,
+		Failures:    r.total.failures,
+		MaxDuration: r.total.max,
+		Window:      r.width * statsBuckets,
+		SuccessRate: 1,
+	}
+	if r.total.runs > 0 {
+		s.MeanDuration = r.total.sum / time.Duration(r.total.runs)
+	}
+	i := r.index(now)
+	for _, b := range r.buckets {
+		if b.runs > 0 && b.index > i-statsBuckets && b.index <= i {
+			s.RecentRuns += b.runs
+			s.RecentFailures += b.failures
+		}
+	}
+	if s.RecentRuns > 0 {
+		s.SuccessRate = float64(s.RecentRuns-s.RecentFailures) / float64(s.RecentRuns)
+	}
+	return s
+}
+
+// reset clears the statistics.
+func (r *statsRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.total = statsBucket{}
+	r.buckets = [statsBuckets]statsBucket{}
+}
@@ -0,0 +1,105 @@
+package cron
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEntryStats(t *testing.T) {
+	cron, fc := newFakeCron(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var runs int32
+	id := cron.Schedule(Every(time.Second), FuncJob(func() {
+		if atomic.AddInt32(&runs, 1) == 2 {
+			panic("second run")
+		}
+	}), WithEntryChain(Recover(DiscardLogger)))
+	cron.Start()
+	defer cron.Stop()
+
+	timer := <-fc.timers
+	for i := 0; i < 3; i++ {
+		timer = fc.advance(timer)
+	}
+	waitFor(t, func() bool { return cron.Entry(id).Stats().Runs == 3 })
+	stats := cron.Entry(id).Stats()
+	if stats.Failures != 1 || stats.RecentRuns != 3 || stats.RecentFailures != 1 || stats.Window != time.Hour {
+		t.Errorf("expected 3 runs, the second failing, got %+v", stats)
+	}
+	if stats.SuccessRate < 0.66 || stats.SuccessRate > 0.67 {
+		t.Errorf("expected a success rate of 2/3, got %v", stats.SuccessRate)
+	}
+
+	if !cron.ResetStats(id) {
+		t.Error("expected the entry to be found")
+	}
+	if stats := cron.Entry(id).Stats(); stats != (EntryStats{Window: time.Hour, SuccessRate: 1}) {
+		t.Errorf("expected the stats to be reset, got %+v", stats)
+	}
+	if cron.ResetStats(id + 1) {
+		t.Error("expected no such entry")
+	}
+}
+
+func TestStatsWindow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := newFakeClock(start)
+	at := func(d time.Duration) {
+		fc.mu.Lock()
+		fc.now = start.Add(d)
+		fc.mu.Unlock()
+	}
+	r := newStatsRecorder(fc, time.Minute)
+	r.record(start.Add(-2*time.Second), false)
+	at(30 * time.Second)
+	r.record(start.Add(26*time.Second), true)
+
+	expected := EntryStats{
+		Runs: 2, Failures: 1, MeanDuration: 3 * time.Second, MaxDuration: 4 * time.Second,
+		Window: time.Minute, RecentRuns: 2, RecentFailures: 1, SuccessRate: 0.5,
+	}
+	if stats := r.read(); stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+
+	// The first run leaves the window, and then the second.
+	at(65 * time.Second)
+	expected.RecentRuns, expected.RecentFailures, expected.SuccessRate = 1, 1, 0
+	if stats := r.read(); stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+	at(90 * time.Second)
+	expected.RecentRuns, expected.RecentFailures, expected.SuccessRate = 0, 0, 1
+	if stats := r.read(); stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+
+	// A bucket reused for a later stretch of time starts afresh.
+	r.record(start.Add(90*time.Second), false)
+	expected.Runs, expected.MeanDuration = 3, 2*time.Second
+	expected.RecentRuns = 1
+	if stats := r.read(); stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+}
+
+func TestStatsConcurrentRuns(t *testing.T) {
+	r := newStatsRecorder(realClock{}, time.Hour)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				r.record(time.Now(), i%5 == 0)
+				r.read()
+			}
+		}(i)
+	}
+	wg.Wait()
+	stats := r.read()
+	if stats.Runs != 1000 || stats.Failures != 200 || stats.RecentRuns != 1000 || stats.RecentFailures != 200 {
+		t.Errorf("expected 1000 runs and 200 failures, got %+v", stats)
+	}
+}
@@ -0,0 +1,88 @@
+package cron
+
+import "time"
+
+// EveryNDaysSchedule activates at a fixed time of day on every Nth calendar
+// day, counting from an anchor date, e.g. "every 10 days from 2024-01-01".
+// Unlike a day-of-month step, the count carries across month boundaries.
+type EveryNDaysSchedule struct {
+	// Anchor is the first day of the cycle. Only its date and location are
+	// used; the location determines both day boundaries and the time of day.
+	Anchor time.Time
+	N      int
+
+	Hour, Minute, Second int
+}
+
+// EveryNDays returns a Schedule that activates at hour:minute:second on the
+// anchor date and on every nth day after it. Days are counted on the calendar,
+// so activations stay at the same wall clock time across daylight savings
+// transitions. An n of less than one is treated as one.
+func EveryNDays(anchor time.Time, n int, hour, minute, second int) EveryNDaysSchedule {
+	if n < 1 {
+		n = 1
+	}
+	return EveryNDaysSchedule{
+		Anchor: anchor,
+		N:      n,
+		Hour:   hour,
+		Minute: minute,
+		Second: second,
+	}
+}
+
+// Next returns the first activation after the given time.
+func (s EveryNDaysSchedule) Next(t time.Time) time.Time {
+	k := s.cycles(t)
+	if k < 0 {
+		k = 0
+	}
+	for {
+		if next := s.activation(k); next.After(t) {
+			return next.In(t.Location())
+		}
+		k++
+	}
+}
+
+// NextOK returns the first activation after the given time, which always
+// exists.
+func (s EveryNDaysSchedule) NextOK(t time.Time) (time.Time, bool) {
+	return s.Next(t), true
+}
+
+// Latest returns the latest activation at or before the given time, or the
+// zero time if t is before the first activation.
+func (s EveryNDaysSchedule) Latest(t time.Time) time.Time {
+	k := s.cycles(t) + 1
+	for ; k >= 0; k-- {
+		if latest := s.activation(k); !latest.After(t) {
+			return latest.In(t.Location())
+		}
+	}
+	return time.Time{}
+}
+
+// cycles returns the number of whole cycles between the anchor date and t's
+// date, which may be one less than the index of the activation nearest t.
+func (s EveryNDaysSchedule) cycles(t time.Time) int {
+	var (
+		loc        = s.Anchor.Location()
+		ay, am, ad = s.Anchor.Date()
+		ty, tm, td = t.In(loc).Date()
+		anchorDay  = time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+		day        = time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC)
+		days       = int(day.Sub(anchorDay).Hours() / 24)
+		cycles     = days / s.N
+	)
+	if days < 0 && days%s.N != 0 {
+		cycles--
+	}
+	return cycles
+}
+
+// activation returns the k'th activation time.
+func (s EveryNDaysSchedule) activation(k int) time.Time {
+	y, m, d := s.Anchor.Date()
+	return time.Date(y, m, d+k*s.N, s.Hour, s.Minute, s.Second, 0, s.Anchor.Location())
+}
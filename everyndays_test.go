@@ -0,0 +1,60 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEveryNDays(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	anchor := time.Date(2024, 1, 1, 0, 0, 0, 0, ny)
+	sched := EveryNDays(anchor, 10, 9, 30, 0)
+
+	// Walk across the February and March month boundaries and the March DST
+	// transition.
+	expected := []string{
+		"2024-01-01", "2024-01-11", "2024-01-21", "2024-01-31",
+		"2024-02-10", "2024-02-20", "2024-03-01", "2024-03-11", "2024-03-21",
+	}
+	next := anchor.Add(-time.Second)
+	for _, day := range expected {
+		next = sched.Next(next)
+		if got := next.Format("2006-01-02"); got != day {
+			t.Errorf("expected activation on %s, got %s", day, got)
+		}
+		if next.Hour() != 9 || next.Minute() != 30 || next.Second() != 0 {
+			t.Errorf("expected activation at 09:30:00, got %v", next)
+		}
+	}
+
+	tests := []struct {
+		time, next, latest string
+	}{
+		{"2023-12-25 00:00", "2024-01-01 09:30", ""},
+		{"2024-01-01 09:30", "2024-01-11 09:30", "2024-01-01 09:30"},
+		{"2024-01-05 12:00", "2024-01-11 09:30", "2024-01-01 09:30"},
+		{"2024-01-11 09:29", "2024-01-11 09:30", "2024-01-01 09:30"},
+		{"2024-03-15 00:00", "2024-03-21 09:30", "2024-03-11 09:30"},
+	}
+	parse := func(value string) time.Time {
+		if value == "" {
+			return time.Time{}
+		}
+		t, err := time.ParseInLocation("2006-01-02 15:04", value, ny)
+		if err != nil {
+			panic(err)
+		}
+		return t
+	}
+	for _, c := range tests {
+		if actual := sched.Next(parse(c.time)); !actual.Equal(parse(c.next)) {
+			t.Errorf("Next(%s): expected %s, got %v", c.time, c.next, actual)
+		}
+		if actual := sched.Latest(parse(c.time)); !actual.Equal(parse(c.latest)) {
+			t.Errorf("Latest(%s): expected %s, got %v", c.time, c.latest, actual)
+		}
+	}
+}
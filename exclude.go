@@ -0,0 +1,60 @@
+package cron
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// dateLayout is the layout of the dates in a spec's exclusion list.
+const dateLayout = "2006-01-02"
+
+// ExcludedDates returns the dates on which the schedule does not run, as given
+// by a trailing "!" list such as "!2024-12-25,2025-01-01", in ascending order.
+// Each is midnight in the schedule's location.
+func (s *SpecSchedule) ExcludedDates() []time.Time {
+	var dates []time.Time
+	for key := range s.excluded {
+		dates = append(dates, time.Date(key/10000, time.Month(key/100%100), key%100, 0, 0, 0, 0, s.EffectiveLocation()))
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates
+}
+
+// excludes returns true if the schedule is not to run on t's date.
+func (s *SpecSchedule) excludes(t time.Time) bool {
+	return s.excluded[dateKey(t.Year(), t.Month(), t.Day())]
+}
+
+// dateKey returns the key of a date in SpecSchedule.excluded, e.g. 20241225.
+func dateKey(year int, month time.Month, day int) int {
+	return year*10000 + int(month)*100 + day
+}
+
+// parseExcludedDates parses a comma-separated list of dates, without the "!".
+func parseExcludedDates(list string) (map[int]bool, error) {
+	excluded := make(map[int]bool)
+	for _, date := range strings.Split(list, ",") {
+		t, err := time.Parse(dateLayout, date)
+		if err != nil {
+			return nil, fmt.Errorf("bad excluded date %q, expected YYYY-MM-DD", date)
+		}
+		excluded[dateKey(t.Year(), t.Month(), t.Day())] = true
+	}
+	return excluded, nil
+}
+
+// formatExcludedDates returns the token for the schedule's excluded dates, or
+// "" if there are none.
+func (s *SpecSchedule) formatExcludedDates() string {
+	dates := s.ExcludedDates()
+	if len(dates) == 0 {
+		return ""
+	}
+	tokens := make([]string, len(dates))
+	for i, date := range dates {
+		tokens[i] = date.Format(dateLayout)
+	}
+	return "!" + strings.Join(tokens, ",")
+}
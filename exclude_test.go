@@ -0,0 +1,85 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// excludeParser is the standard parser, accepting excluded dates.
+var excludeParser = NewParser(Minute | Hour | Dom | Month | Dow | Descriptor | ExcludeDates)
+
+func TestExcludedDates(t *testing.T) {
+	sched, err := NewParser(Second | Minute | Hour | Dom | Month | Dow | ExcludeDates).Parse("TZ=UTC 0 0 9 * * MON-FRI !2024-12-25,2025-01-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sched.(*SpecSchedule)
+	tests := []struct {
+		time, next, latest time.Time
+	}{
+		{time.Date(2024, 12, 24, 10, 0, 0, 0, time.UTC), time.Date(2024, 12, 26, 9, 0, 0, 0, time.UTC), time.Date(2024, 12, 24, 9, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 12, 25, 12, 0, 0, 0, time.UTC), time.Date(2024, 12, 26, 9, 0, 0, 0, time.UTC), time.Date(2024, 12, 24, 9, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 12, 31, 10, 0, 0, 0, time.UTC), time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 9, 0, 0, 0, time.UTC)},
+		{time.Date(2025, 12, 24, 10, 0, 0, 0, time.UTC), time.Date(2025, 12, 25, 9, 0, 0, 0, time.UTC), time.Date(2025, 12, 24, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range tests {
+		if next := s.Next(c.time); !next.Equal(c.next) {
+			t.Errorf("Next(%v): expected %v, got %v", c.time, c.next, next)
+		}
+		if latest := s.Latest(c.time); !latest.Equal(c.latest) {
+			t.Errorf("Latest(%v): expected %v, got %v", c.time, c.latest, latest)
+		}
+	}
+
+	if expected := "TZ=UTC 0 0 9 * * 1-5 * !2024-12-25,2025-01-01"; s.String() != expected {
+		t.Errorf("expected %s, got %s", expected, s.String())
+	}
+	dates := s.ExcludedDates()
+	if len(dates) != 2 || !dates[0].Equal(time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)) ||
+		!dates[1].Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected Christmas and New Year's Day, got %v", dates)
+	}
+	if plain, _ := NewParser(Second | Minute | Hour | Dom | Month | Dow).Parse("TZ=UTC 0 0 9 * * MON-FRI"); plain.(*SpecSchedule).Equal(s) {
+		t.Error("expected excluded dates to be compared")
+	}
+	if reparsed, _, err := ParseFlexible(s.String()); err != nil || !reparsed.(*SpecSchedule).Equal(s) {
+		t.Errorf("expected String to parse back, got %v", err)
+	}
+}
+
+func TestExcludedDatesLocation(t *testing.T) {
+	// Dates are in the schedule's location: 9am in Tokyo on the 25th is
+	// midnight UTC.
+	sched, err := excludeParser.Parse("TZ=Asia/Tokyo 0 9 * * * !2024-12-25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sched.(*SpecSchedule)
+	from := time.Date(2024, 12, 24, 1, 0, 0, 0, time.UTC)
+	if expected := time.Date(2024, 12, 26, 0, 0, 0, 0, time.UTC); !s.Next(from).Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, s.Next(from))
+	}
+
+	sched, err = excludeParser.Parse("TZ=UTC @daily !2024-12-25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	daily := sched.(*SpecSchedule)
+	if expected := time.Date(2024, 12, 26, 0, 0, 0, 0, time.UTC); !daily.Next(time.Date(2024, 12, 24, 1, 0, 0, 0, time.UTC)).Equal(expected) {
+		t.Errorf("expected %v", expected)
+	}
+
+	commented, err := NewParser(Minute | Hour | Dom | Month | Dow | InlineComments | ExcludeDates).Parse("0 9 * * * !2024-12-25 # not on Christmas")
+	if err != nil || len(commented.(*SpecSchedule).ExcludedDates()) != 1 {
+		t.Errorf("expected a date to be excluded before the comment, got %v", err)
+	}
+
+	for _, spec := range []string{"0 9 * * * !2024-13-01", "0 9 * * * !", "0 9 * * * !2024-12-25,", "0 9 * * * !12/25", "@every 1h !2024-12-25"} {
+		if _, err := excludeParser.Parse(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+	if _, err := ParseStandard("0 9 * * * !2024-12-25"); err == nil {
+		t.Error("expected a parser without ExcludeDates to reject excluded dates")
+	}
+}
@@ -0,0 +1,179 @@
+package cron
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Expression is a cron spec that has been split into fields and checked, but
+// not yet compiled into the bit sets of a SpecSchedule. It allows a spec to be
+// validated without choosing a time zone, and to be compiled for several.
+type Expression struct {
+	raw        string
+	options    ParseOption
+	loc        *time.Location         // from a TZ prefix, if any
+	descriptor string                 // the descriptor, if one was used
+	fields     []string               // one per place
+	locale     *locale                // extra month and weekday names, if any
+	yearPivot  int                    // for TwoDigitYears, or 0 for DefaultYearPivot
+	bounds     map[ParseOption]bounds // fields with custom bounds
+	comment    string                 // a trailing comment, with InlineComments
+	millis     string                 // the milliseconds field, with Millisecond
+	excluded   map[int]bool           // dates not to run on, by dateKey
+}
+
+// ParseExpression parses the given spec with a parser configured by the given
+// options, which default to the standard 5 fields if they include none. The
+// fields are checked, but bit sets are only computed by Compile. Interval
+// descriptors ("@every 5m") are not cron expressions, and are rejected.
+func ParseExpression(spec string, opts ...ParseOption) (*Expression, error) {
+	var options ParseOption
+	for _, opt := range opts {
+		options |= opt
+	}
+	if requiredFields(options) == 0 && options&(SecondOptional|DowOptional|YearOptional) == 0 {
+		options |= Minute | Hour | Dom | Month | Dow
+	}
+
+	expr, err := Parser{options: options}.expression(spec)
+	if err != nil {
+		return nil, err
+	}
+	if expr.fields == nil {
+		return nil, fmt.Errorf("interval is not a cron expression: %s", spec)
+	}
+	if _, errs := expr.compile(time.UTC, false); len(errs) > 0 {
+		return nil, errs[0].Err
+	}
+	return expr, nil
+}
+
+// Compile returns the schedule described by the expression, interpreted in
+// the given location. A time zone given in the expression itself (CRON_TZ=)
+// takes precedence over loc. If neither is given, time.Local is used.
+func (e *Expression) Compile(loc *time.Location) (*SpecSchedule, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	if e.loc != nil {
+		loc = e.loc
+	}
+	sched, errs := e.compile(loc, false)
+	if len(errs) > 0 {
+		return nil, errs[0].Err
+	}
+	return sched, nil
+}
+
+// ExpressionFields holds the token given for each field of an expression,
+// exactly as written. Fields that were omitted hold their default.
+type ExpressionFields struct {
+	Second, Minute, Hour, Dom, Month, Dow, Year, Week string
+}
+
+// Fields returns the token given for each field of the expression.
+func (e *Expression) Fields() ExpressionFields {
+	return ExpressionFields{
+		Second: e.fields[0],
+		Minute: e.fields[1],
+		Hour:   e.fields[2],
+		Dom:    e.fields[3],
+		Month:  e.fields[4],
+		Dow:    e.fields[5],
+		Year:   e.fields[6],
+		Week:   e.fields[7],
+	}
+}
+
+// Comment returns the text of the expression's trailing comment, without the
+// "#", if it was parsed with the InlineComments option and had one.
+func (e *Expression) Comment() string {
+	return e.comment
+}
+
+// String returns the expression exactly as it was given.
+func (e *Expression) String() string {
+	return e.raw
+}
+
+// compile returns the schedule for the expression in loc. If all is set, it
+// keeps going after a bad field so that every field error is reported.
+func (e *Expression) compile(loc *time.Location, all bool) (*SpecSchedule, []ParseError) {
+	bits, errs := e.bits(all)
+	var millis *big.Int
+	if e.millis != "" {
+		var err error
+		if millis, err = getField(e.millis, milliseconds); err != nil {
+			// The milliseconds field comes first.
+			errs = append([]ParseError{{Field: "millisecond", Value: e.millis, Err: err}}, errs...)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	sched := &SpecSchedule{
+		Second:      bits[0],
+		Minute:      bits[1],
+		Hour:        bits[2],
+		Dom:         bits[3],
+		Month:       bits[4],
+		Dow:         bits[5],
+		Year:        bits[6],
+		Millisecond: millis,
+		Location:    loc,
+		excluded:    e.excluded,
+		raw:         e.raw,
+		comment:     e.comment,
+	}
+	if bits[7].Bit(maxBits) == 0 {
+		sched.WeekOfYear = bits[7]
+	}
+	domField, and := cutDomAndDow(e.fields[3])
+	if e.options&ClampDom > 0 {
+		sched.Dom.Or(sched.Dom, clampFlags(domField))
+	}
+	if (and || e.options&DomAndDow > 0) && !sched.bothDays() {
+		sched.Dom.SetBit(sched.Dom, domAndDowBit, 1)
+	}
+	return sched, nil
+}
+
+// bits returns the bit set for each field.
+func (e *Expression) bits(all bool) ([]*big.Int, []ParseError) {
+	var (
+		errs []ParseError
+		bits = make([]*big.Int, len(places))
+		err  error
+	)
+	monday := e.options&WeekStartsMonday > 0
+	for i, r := range fieldBounds {
+		if len(errs) > 0 && !all {
+			break
+		}
+		b := *r
+		if custom, ok := e.bounds[places[i]]; ok {
+			b = custom
+		}
+		if places[i] == Dow && monday {
+			b = mondayDow
+		}
+		if places[i] == Year && e.options&TwoDigitYears > 0 {
+			b = twoDigitYears(e.yearPivot)
+		}
+		if e.locale != nil {
+			b = e.locale.bounds(places[i], b, monday)
+		}
+		field := e.fields[i]
+		if places[i] == Dom {
+			field, _ = cutDomAndDow(field)
+		}
+		if bits[i], err = getField(field, b); err == nil && places[i] == Dow && monday {
+			bits[i] = fromMondayDow(bits[i])
+		}
+		if err != nil {
+			errs = append(errs, ParseError{Field: fieldNames[i], Value: e.fields[i], Err: err})
+		}
+	}
+	return bits, errs
+}
@@ -0,0 +1,171 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExpressionCompile(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	specs := []string{
+		"0 5 * * * *",
+		"*/15 9-17 ? JAN,jul MON-FRI",
+		"0 0 L * 5L",
+		"@daily",
+	}
+	for _, spec := range specs {
+		expr, err := ParseExpression(spec, SecondOptional, Minute, Hour, Dom, Month, Dow, Descriptor)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", spec, err)
+			continue
+		}
+		if expr.String() != spec {
+			t.Errorf("%s => expected String to return the spec, got %s", spec, expr.String())
+		}
+		for _, loc := range []*time.Location{time.UTC, tokyo} {
+			actual, err := expr.Compile(loc)
+			if err != nil {
+				t.Errorf("%s => unexpected error %v", spec, err)
+				continue
+			}
+			parsed, _ := NewParser(SecondOptional | Minute | Hour | Dom | Month | Dow | Descriptor).Parse(spec)
+			expected := parsed.(*SpecSchedule)
+			expected.Location = loc
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s in %s => expected %v, got %v", spec, loc, expected, actual)
+			}
+		}
+	}
+}
+
+func TestExpressionLocation(t *testing.T) {
+	expr, err := ParseExpression("CRON_TZ=Asia/Tokyo 0 6 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sched, err := expr.Compile(time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sched.Location.String() != "Asia/Tokyo" {
+		t.Errorf("expected the expression's time zone to win, got %s", sched.Location)
+	}
+
+	expr, _ = ParseExpression("0 6 * * *")
+	if sched, _ := expr.Compile(nil); sched.Location != time.Local {
+		t.Errorf("expected time.Local, got %s", sched.Location)
+	}
+}
+
+func TestExpressionErrors(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* * * * XYZ",
+		"@every 5m",
+	}
+	for _, spec := range specs {
+		if _, err := ParseExpression(spec, Minute, Hour, Dom, Month, Dow, Descriptor); err == nil {
+			t.Errorf("%s => expected an error", spec)
+		}
+	}
+}
+
+func TestExpressionFields(t *testing.T) {
+	tests := []struct {
+		spec     string
+		opts     ParseOption
+		expected ExpressionFields
+	}{
+		{"0 9 1,15 * MON-FRI", Minute | Hour | Dom | Month | Dow,
+			ExpressionFields{"0", "0", "9", "1,15", "*", "MON-FRI", "*", "*"}},
+		{"30 */5 9-17 ? Jan,JUL sun", Second | Minute | Hour | Dom | Month | Dow,
+			ExpressionFields{"30", "*/5", "9-17", "?", "Jan,JUL", "sun", "*", "*"}},
+		{"0 0 0 L,2l * 5L 2024-2026", Second | Minute | Hour | Dom | Month | Dow | Year,
+			ExpressionFields{"0", "0", "0", "L,2l", "*", "5L", "2024-2026", "*"}},
+		{"CRON_TZ=UTC 15 10 * *", Minute | Hour | Dom | Month | DowOptional,
+			ExpressionFields{"0", "15", "10", "*", "*", "*", "*", "*"}},
+		{"0 9 * * * 1,26", Minute | Hour | Dom | Month | Dow | Week,
+			ExpressionFields{"0", "0", "9", "*", "*", "*", "*", "1,26"}},
+	}
+	for _, c := range tests {
+		expr, err := ParseExpression(c.spec, c.opts)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", c.spec, err)
+			continue
+		}
+		if actual := expr.Fields(); actual != c.expected {
+			t.Errorf("%s => expected %+v, got %+v", c.spec, c.expected, actual)
+		}
+	}
+}
+
+func TestInlineComments(t *testing.T) {
+	tests := []struct {
+		spec     string
+		options  ParseOption
+		expected string
+		comment  string
+	}{
+		{"0 30 3 * * * *  # nightly vacuum", Second | Minute | Hour | Dom | Month | Dow | Year, "0 30 3 * * * *", "nightly vacuum"},
+		{"30 3 * * * #nightly", Minute | Hour | Dom | Month | Dow, "30 3 * * *", "nightly"},
+		{"TZ=UTC 30 3 * * *\t#\tnightly # vacuum ", Minute | Hour | Dom | Month | Dow, "TZ=UTC 30 3 * * *", "nightly # vacuum"},
+		{"30 3 * * # weekly?", Minute | Hour | Dom | Month | DowOptional, "30 3 * *", "weekly?"},
+		{"@daily # nightly", Minute | Hour | Dom | Month | Dow | Descriptor, "@daily", "nightly"},
+		{"30 3 * * *", Minute | Hour | Dom | Month | Dow, "30 3 * * *", ""},
+		{"30 3 * * * #", Minute | Hour | Dom | Month | Dow, "30 3 * * *", ""},
+		{"30 3 * * 1#2 # second Monday", Minute | Hour | Dom | Month | Dow, "30 3 * * 1#2", "second Monday"},
+	}
+	for _, c := range tests {
+		expr, err := ParseExpression(c.spec, c.options|InlineComments)
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		if expr.Comment() != c.comment {
+			t.Errorf("%s: expected comment %q, got %q", c.spec, c.comment, expr.Comment())
+		}
+		if expr.String() != c.spec {
+			t.Errorf("%s: expected String to return the spec as given, got %q", c.spec, expr.String())
+		}
+		expected, err := ParseExpression(c.expected, c.options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(expr.Fields(), expected.Fields()) {
+			t.Errorf("%s: expected fields %v, got %v", c.spec, expected.Fields(), expr.Fields())
+		}
+	}
+
+	// A "#" is only a comment once the required fields are given, and only
+	// at the start of a token.
+	for _, spec := range []string{"30 3 * * #weekly", "30 3 * * # weekly"} {
+		if _, err := ParseExpression(spec, InlineComments|Minute|Hour|Dom|Month|Dow); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+
+	if expr, err := ParseExpression("30 3 * * * # nightly", InlineComments); err != nil || expr.Comment() != "nightly" {
+		t.Errorf("expected the standard fields by default, got %v, %v", expr, err)
+	}
+
+	// Without the option, a comment is an error as before.
+	if _, err := standardParser.Parse("30 3 * * * # nightly"); err == nil {
+		t.Error("expected a comment to be rejected by default")
+	}
+	sched, err := NewParser(Minute | Hour | Dom | Month | Dow | InlineComments).Parse("30 3 * * * # nightly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, _ := standardParser.Parse("30 3 * * *"); !sched.(*SpecSchedule).Equal(expected.(*SpecSchedule)) {
+		t.Errorf("expected the comment to be ignored, got %v", sched)
+	}
+	if comment := sched.(*SpecSchedule).Comment(); comment != "nightly" {
+		t.Errorf("expected the schedule to keep its comment, got %q", comment)
+	}
+}
@@ -0,0 +1,40 @@
+package cron
+
+import "time"
+
+// FixedDelaySchedule runs a job a fixed delay after its previous run
+// finished, rather than at fixed intervals, so that slow runs never leave the
+// job less than Delay to rest. It needs the cooperation of the Cron running
+// it: while a run is in progress, the entry's Next is the zero time, and it is
+// set to the completion time plus Delay once the run finishes. An activation
+// that does not run, e.g. because the entry's group is paused, counts as
+// finishing straight away.
+type FixedDelaySchedule struct {
+	Delay time.Duration
+}
+
+// EveryAfterCompletion returns a Schedule that runs a job the given duration
+// after each run completes. Delays of less than a second are rounded up to one
+// second.
+func EveryAfterCompletion(duration time.Duration) FixedDelaySchedule {
+	if duration < time.Second {
+		duration = time.Second
+	}
+	return FixedDelaySchedule{Delay: duration}
+}
+
+// Next returns the time the delay after t, taking t to be the time the
+// previous run finished.
+func (s FixedDelaySchedule) Next(t time.Time) time.Time {
+	return t.Add(s.Delay)
+}
+
+// NextOK returns the time the delay after t, which always exists.
+func (s FixedDelaySchedule) NextOK(t time.Time) (time.Time, bool) {
+	return s.Next(t), true
+}
+
+// Latest returns the zero time, as activations depend on when runs finish.
+func (s FixedDelaySchedule) Latest(t time.Time) time.Time {
+	return time.Time{}
+}
@@ -0,0 +1,42 @@
+package cron
+
+import "time"
+
+// frequencyTolerance is how much two estimates from EstimateFrequency must
+// differ by, as a fraction of the larger, for IsMoreFrequentThan to trust
+// them.
+const frequencyTolerance = 0.25
+
+// EstimateFrequency returns the average number of times a day the schedule
+// activates, computed from its fields without finding any activations. It
+// shares the approximations of Occupancy, and also takes the months into
+// account, but not the years or weeks of the year.
+func (s *SpecSchedule) EstimateFrequency() float64 {
+	report := s.Occupancy()
+	var perWeek float64
+	for d := range report.Days {
+		perWeek += report.Share[d] * float64(report.Days[d])
+	}
+	return perWeek / 7 * float64(countBits(s.Month, months)) / 12
+}
+
+// IsMoreFrequentThan reports whether the schedule activates more often than
+// the other. It compares their estimates from EstimateFrequency if those
+// differ by more than a quarter; otherwise it counts the activations of each
+// in the week from now, which takes time in proportion to their number.
+func (s *SpecSchedule) IsMoreFrequentThan(other *SpecSchedule) bool {
+	return s.isMoreFrequentThan(other, time.Now())
+}
+
+// isMoreFrequentThan is IsMoreFrequentThan, counting from ref if need be.
+func (s *SpecSchedule) isMoreFrequentThan(other *SpecSchedule, ref time.Time) bool {
+	a, b := s.EstimateFrequency(), other.EstimateFrequency()
+	switch {
+	case a > b && a-b > frequencyTolerance*a:
+		return true
+	case b > a && b-a > frequencyTolerance*b:
+		return false
+	}
+	week := ref.AddDate(0, 0, 7)
+	return s.Count(ref, week) > other.Count(ref, week)
+}
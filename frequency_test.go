@@ -0,0 +1,58 @@
+package cron
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEstimateFrequency(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected float64
+	}{
+		{"* * * * *", 24 * 60},
+		{"0 * * * *", 24},
+		{"0 9 * * *", 1},
+		{"0 9,17 * * MON-FRI", 2 * 5.0 / 7},
+		{"0 9 * * MON", 1.0 / 7},
+		{"0 9 * 1-6 *", 0.5},
+		{"0 0 1 * *", 12 / 365.25},
+	}
+	for _, c := range tests {
+		if actual := mustParse(t, c.spec).(*SpecSchedule).EstimateFrequency(); math.Abs(actual-c.expected) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", c.spec, c.expected, actual)
+		}
+	}
+}
+
+func TestIsMoreFrequentThan(t *testing.T) {
+	ref := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"* * * * *", "0 * * * *", true},
+		{"0 * * * *", "* * * * *", false},
+		{"@daily", "@weekly", true},
+		{"@weekly", "@daily", false},
+		{"0 9 * * *", "0 9 * * *", false},
+		{"0 9 * * *", "0 9 1-31 * 0-6", false},
+		{"0 9 1-31 * 0-6", "0 9 * * *", false},
+
+		// These are too close to tell apart from their fields. The week
+		// from February 29th has both the 29th and the 1st, but only one
+		// Monday.
+		{"TZ=UTC 0 9 */7 * *", "TZ=UTC 0 9 * * MON", true},
+		{"TZ=UTC 0 9 * * MON", "TZ=UTC 0 9 */7 * *", false},
+	}
+	for _, c := range tests {
+		a, b := mustParse(t, c.a).(*SpecSchedule), mustParse(t, c.b).(*SpecSchedule)
+		if actual := a.isMoreFrequentThan(b, ref); actual != c.expected {
+			t.Errorf("%s more frequent than %s: expected %v", c.a, c.b, c.expected)
+		}
+	}
+	if mustParse(t, "0 9 * * *").(*SpecSchedule).IsMoreFrequentThan(mustParse(t, "0 9 * * *").(*SpecSchedule)) {
+		t.Error("expected a schedule not to be more frequent than itself")
+	}
+}
@@ -0,0 +1,185 @@
+package cron
+
+import (
+	"sort"
+	"time"
+)
+
+// MinGap returns the shortest time between consecutive activations in a
+// yearly cycle: from the first activation to the last of the year in which
+// the schedule next runs. It returns zero if the schedule runs at most once
+// that year, or never.
+//
+// Gaps are elapsed time, so a daily schedule's gap across a daylight saving
+// change is an hour shorter or longer than a day. The cost is in proportion
+// to the days of the year plus the activations of a single day, as each day
+// the schedule runs on is read off its time of day fields, except days on
+// which the clocks change, which are stepped through one activation at a
+// time; a schedule that runs every second thus takes tens of milliseconds.
+func (s *SpecSchedule) MinGap() time.Duration {
+	min, _ := s.gapExtremes(s.referenceYear())
+	return min
+}
+
+// MaxGap returns the longest time between consecutive activations in a
+// yearly cycle, as MinGap returns the shortest.
+func (s *SpecSchedule) MaxGap() time.Duration {
+	_, max := s.gapExtremes(s.referenceYear())
+	return max
+}
+
+// MaxGapBetween returns the longest time without an activation from start to
+// end, counting the time from start to the first activation and from the last
+// to end, e.g. to check that a schedule never leaves an SLA's window
+// uncovered for longer than allowed. Activations at start or end count as
+// within it. It returns end-start if there are none, and zero if end is
+// before start. Each activation in the range is stepped through, so the cost
+// is in proportion to their number.
+func (s *SpecSchedule) MaxGapBetween(start, end time.Time) time.Duration {
+	var max time.Duration
+	prev := start
+	for t := s.Next(start.Add(-time.Nanosecond)); !t.IsZero() && !t.After(end); t = s.Next(t) {
+		if gap := t.Sub(prev); gap > max {
+			max = gap
+		}
+		prev = t
+	}
+	if gap := end.Sub(prev); gap > max {
+		max = gap
+	}
+	return max
+}
+
+// gapSamples is the number of consecutive gaps TypicalGap and MeanGap measure.
+const gapSamples = 1000
+
+// TypicalGap returns the median of the gaps between the next 1001 activations
+// from now, as the gap to plan capacity for: unlike the mean, it isn't pulled
+// up by the rare long gaps, such as weekends for a schedule that runs on
+// weekdays. It returns zero if the schedule has fewer than two activations
+// to come. Like MinGap, gaps are elapsed time; all 1000 are kept and sorted.
+func (s *SpecSchedule) TypicalGap() time.Duration {
+	return median(s.sampleGaps(time.Now()))
+}
+
+// MeanGap returns the mean of the gaps TypicalGap takes the median of.
+func (s *SpecSchedule) MeanGap() time.Duration {
+	return mean(s.sampleGaps(time.Now()))
+}
+
+// sampleGaps returns the gaps between up to gapSamples+1 activations after
+// from.
+func (s *SpecSchedule) sampleGaps(from time.Time) []time.Duration {
+	var gaps []time.Duration
+	prev := s.Next(from)
+	for !prev.IsZero() && len(gaps) < gapSamples {
+		next := s.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		gaps = append(gaps, next.Sub(prev))
+		prev = next
+	}
+	return gaps
+}
+
+// median returns the median of the gaps, or zero if there are none. The gaps
+// are sorted in place.
+func median(gaps []time.Duration) time.Duration {
+	if len(gaps) == 0 {
+		return 0
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	mid := len(gaps) / 2
+	if len(gaps)%2 == 1 {
+		return gaps[mid]
+	}
+	return (gaps[mid-1] + gaps[mid]) / 2
+}
+
+// mean returns the mean of the gaps, or zero if there are none.
+func mean(gaps []time.Duration) time.Duration {
+	if len(gaps) == 0 {
+		return 0
+	}
+	var sum time.Duration
+	for _, g := range gaps {
+		sum += g
+	}
+	return sum / time.Duration(len(gaps))
+}
+
+// referenceYear returns the year, in the schedule's location, of its next
+// activation from now, or the current year if there is none.
+func (s *SpecSchedule) referenceYear() int {
+	now := time.Now().In(s.EffectiveLocation())
+	if next := s.Next(now); !next.IsZero() {
+		return next.In(s.EffectiveLocation()).Year()
+	}
+	return now.Year()
+}
+
+// gapExtremes returns the shortest and longest gaps between consecutive
+// activations in the given year.
+func (s *SpecSchedule) gapExtremes(year int) (min, max time.Duration) {
+	times := s.TimesOfDay()
+	if s.Millisecond != nil {
+		var millis, withMillis []time.Duration
+		for ms := milliseconds.min; ms <= milliseconds.max; ms++ {
+			if s.Millisecond.Bit(int(ms)) > 0 {
+				millis = append(millis, time.Duration(ms)*time.Millisecond)
+			}
+		}
+		for _, t := range times {
+			for _, ms := range millis {
+				withMillis = append(withMillis, t+ms)
+			}
+		}
+		times = withMillis
+	}
+	if len(times) == 0 {
+		return 0, 0
+	}
+
+	var prev time.Time
+	gap := func(d time.Duration) {
+		if min == 0 || d < min {
+			min = d
+		}
+		if d > max {
+			max = d
+		}
+	}
+	activate := func(t time.Time) {
+		if !prev.IsZero() {
+			gap(t.Sub(prev))
+		}
+		prev = t
+	}
+
+	// The gaps within a day, if any day is read off the time of day fields.
+	wholeDay := false
+	loc := s.EffectiveLocation()
+	for d := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); d.Year() == year; d = d.AddDate(0, 0, 1) {
+		midnight := time.Date(year, d.Month(), d.Day(), 0, 0, 0, 0, loc)
+		if !s.dateMatches(midnight) {
+			continue
+		}
+		tomorrow := time.Date(year, d.Month(), d.Day()+1, 0, 0, 0, 0, loc)
+		if tomorrow.Sub(midnight) != 24*time.Hour {
+			for t := s.Next(midnight.Add(-time.Nanosecond)); !t.IsZero() && t.Before(tomorrow); t = s.Next(t) {
+				activate(t)
+			}
+			continue
+		}
+		activate(midnight.Add(times[0]))
+		prev = midnight.Add(times[len(times)-1])
+		wholeDay = true
+	}
+	if wholeDay {
+		for i := 1; i < len(times); i++ {
+			gap(times[i] - times[i-1])
+		}
+	}
+	return min, max
+}
@@ -0,0 +1,118 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGapExtremes(t *testing.T) {
+	withSeconds := NewParser(Second | Minute | Hour | Dom | Month | Dow)
+	withMillis := NewParser(Millisecond | Second | Minute | Hour | Dom | Month | Dow)
+	tests := []struct {
+		parser   ScheduleParser
+		spec     string
+		min, max time.Duration
+	}{
+		{standardParser, "TZ=UTC 0 9 * * 1-5", 24 * time.Hour, 72 * time.Hour},
+		{withSeconds, "TZ=UTC * * * * * *", time.Second, time.Second},
+		{withMillis, "TZ=UTC 0,250 * * * * * *", 250 * time.Millisecond, 750 * time.Millisecond},
+		{standardParser, "TZ=UTC */20 9-17 * * *", 20 * time.Minute, 15*time.Hour + 20*time.Minute},
+		{standardParser, "TZ=UTC 0 0 1 * *", 29 * 24 * time.Hour, 31 * 24 * time.Hour},
+		// Elapsed time: the weekend the clocks go back is an hour longer.
+		{standardParser, "TZ=America/New_York 0 9 * * 1-5", 24 * time.Hour, 73 * time.Hour},
+		{standardParser, "TZ=America/New_York 30 * * * *", time.Hour, time.Hour},
+		// 02:30 is skipped the day the clocks go forward.
+		{standardParser, "TZ=America/New_York 30 2 * * *", 24 * time.Hour, 47 * time.Hour},
+		// Once a year, or never.
+		{standardParser, "TZ=UTC 0 0 1 1 *", 0, 0},
+		{standardParser, "TZ=UTC 0 0 30 2 *", 0, 0},
+	}
+	for _, c := range tests {
+		sched, err := c.parser.Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := sched.(*SpecSchedule)
+		if min, max := s.gapExtremes(2024); min != c.min || max != c.max {
+			t.Errorf("%s: expected %v and %v, got %v and %v", c.spec, c.min, c.max, min, max)
+		}
+	}
+
+	s := mustParse(t, "TZ=UTC 0 9 * * 1-5").(*SpecSchedule)
+	if min, max := s.MinGap(), s.MaxGap(); min != 24*time.Hour || max != 72*time.Hour {
+		t.Errorf("expected 24h and 72h, got %v and %v", min, max)
+	}
+}
+
+func TestTypicalGap(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	tests := []struct {
+		spec         string
+		median, mean time.Duration
+	}{
+		{"TZ=UTC 0 9 * * *", day, day},
+		{"TZ=UTC 0 * * * *", time.Hour, time.Hour},
+		{"TZ=UTC 0 9 * * mon", 7 * day, 7 * day},
+		{"TZ=UTC 0 9 29 2 *", (4*365 + 1) * day, (4*365 + 1) * day},
+	}
+	for _, c := range tests {
+		gaps := mustParse(t, c.spec).(*SpecSchedule).sampleGaps(from)
+		if m := mean(gaps); m != c.mean {
+			t.Errorf("%s: expected a mean of %v, got %v", c.spec, c.mean, m)
+		}
+		if m := median(gaps); m != c.median {
+			t.Errorf("%s: expected a median of %v, got %v", c.spec, c.median, m)
+		}
+	}
+
+	// Weekends pull the mean up to about 7/5 of a day, but not the median.
+	weekdays := mustParse(t, "TZ=UTC 0 9 * * mon-fri").(*SpecSchedule)
+	gaps := weekdays.sampleGaps(from)
+	if len(gaps) != 1000 {
+		t.Errorf("expected 1000 gaps, got %d", len(gaps))
+	}
+	if m := mean(gaps); m < 33*time.Hour || m > 34*time.Hour {
+		t.Errorf("expected a mean of about 33.6h, got %v", m)
+	}
+	if m := median(gaps); m != day {
+		t.Errorf("expected a median of 24h, got %v", m)
+	}
+	if typical, mean := weekdays.TypicalGap(), weekdays.MeanGap(); typical != day || mean <= typical {
+		t.Errorf("expected a typical gap of 24h below the mean, got %v and %v", typical, mean)
+	}
+
+	never := mustParse(t, "TZ=UTC 0 9 30 2 *").(*SpecSchedule)
+	if typical, mean := never.TypicalGap(), never.MeanGap(); typical != 0 || mean != 0 {
+		t.Errorf("expected no gaps, got %v and %v", typical, mean)
+	}
+}
+
+func TestMaxGapBetween(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	tests := []struct {
+		spec       string
+		start, end time.Time
+		expected   time.Duration
+	}{
+		{"TZ=UTC 0 * * * *", at(0, 0), at(24, 0), time.Hour},
+		// Partial hours at either end.
+		{"TZ=UTC 0 * * * *", at(0, 30), at(3, 0), time.Hour},
+		{"TZ=UTC 30 * * * *", at(0, 0), at(2, 0), time.Hour},
+		// Nothing from 09:00 to 13:00.
+		{"TZ=UTC 0 0-9,13-23 * * *", at(0, 0), at(24, 0), 4 * time.Hour},
+		// From the last activation to the end, and from the start to the first.
+		{"TZ=UTC 0 9 * * *", at(0, 0), at(23, 0), 14 * time.Hour},
+		{"TZ=UTC 0 15 * * *", at(0, 0), at(23, 0), 15 * time.Hour},
+		// No activations at all, or no time.
+		{"TZ=UTC 0 0 1 1 *", at(0, 0), at(24, 0), 24 * time.Hour},
+		{"TZ=UTC 0 * * * *", at(2, 0), at(1, 0), 0},
+	}
+	for _, c := range tests {
+		s := mustParse(t, c.spec).(*SpecSchedule)
+		if gap := s.MaxGapBetween(c.start, c.end); gap != c.expected {
+			t.Errorf("%s from %v to %v: expected %v, got %v", c.spec, c.start, c.end, c.expected, gap)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package cron
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// GenOpts tunes the specs made by GenerateSpec. Each probability is from 0 to
+// 1; the zero GenOpts makes plain 5-field specs of single values and
+// wildcards. DefaultGenOpts turns every feature on.
+type GenOpts struct {
+	// Seconds adds a leading seconds field, and Year a trailing year field.
+	// Year requires Seconds, as ParseFlexible would otherwise read the year
+	// as the day of week.
+	Seconds, Year bool
+
+	// Range, Step and List are the probabilities that a field is a range
+	// ("9-17"), a step ("*/15", "10-40/5") or a list of several values and
+	// ranges ("1,15,20-25").
+	Range, Step, List float64
+
+	// Names is the probability that months and days of the week are given by
+	// name ("jan-mar", "mon-fri/2") rather than by number.
+	Names float64
+
+	// Last is the probability that a day field gives last days or weekdays of
+	// the month ("L", "3L", "5L"), and Weekday that it gives nearest or nth
+	// weekdays ("15W", "LW", "mon#2").
+	Last, Weekday float64
+
+	// Zone is the probability of a TZ= prefix, and Excluded of a trailing list
+	// of dates not to run on.
+	Zone, Excluded float64
+}
+
+// DefaultGenOpts returns GenOpts that exercise every feature of the grammar
+// that ParseFlexible accepts.
+func DefaultGenOpts() GenOpts {
+	return GenOpts{
+		Seconds:  true,
+		Year:     true,
+		Range:    0.2,
+		Step:     0.2,
+		List:     0.2,
+		Names:    0.5,
+		Last:     0.1,
+		Weekday:  0.1,
+		Zone:     0.3,
+		Excluded: 0.1,
+	}
+}
+
+// genZones are the locations GenerateSpec may give, those with daylight
+// saving time first.
+var genZones = []string{"America/New_York", "Europe/London", "Australia/Lord_Howe", "Asia/Kolkata", "UTC"}
+
+// genYears are the years GenerateSpec chooses from: near enough to now that
+// schedules restricted to them are likely to run.
+var genYears = bounds{2020, 2040, nil}
+
+// GenerateSpec returns a random spec, which ParseFlexible is guaranteed to
+// accept, for fuzzing and property tests of code that handles schedules. The
+// same r state gives the same spec. The specs are legal, but not necessarily
+// sensible: some never run, such as "0 0 31 2 *".
+func GenerateSpec(r *rand.Rand, opts GenOpts) string {
+	var tokens []string
+	if opts.Zone > 0 && r.Float64() < opts.Zone {
+		// Only zones this system has can be given.
+		for _, name := range genZones[r.Intn(len(genZones)):] {
+			if _, err := time.LoadLocation(name); err == nil {
+				tokens = append(tokens, "TZ="+name)
+				break
+			}
+		}
+	}
+
+	g := generator{r: r, opts: opts}
+	if opts.Seconds {
+		tokens = append(tokens, g.field(seconds, nil))
+	}
+	tokens = append(tokens,
+		g.field(minutes, nil),
+		g.field(hours, nil),
+		g.day(dom),
+		g.field(months, genMonthNames),
+		g.day(dow))
+	if opts.Seconds && opts.Year {
+		tokens = append(tokens, g.field(genYears, nil))
+	}
+
+	if opts.Excluded > 0 && r.Float64() < opts.Excluded {
+		dates := make([]string, 1+r.Intn(3))
+		for i := range dates {
+			day := time.Date(2024, time.January, 1+r.Intn(7*366), 0, 0, 0, 0, time.UTC)
+			dates[i] = day.Format(dateLayout)
+		}
+		tokens = append(tokens, "!"+strings.Join(dates, ","))
+	}
+	return strings.Join(tokens, " ")
+}
+
+var (
+	genMonthNames = []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	genDowNames   = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+)
+
+// generator makes the fields of a spec for GenerateSpec.
+type generator struct {
+	r    *rand.Rand
+	opts GenOpts
+}
+
+// chance reports true with probability p.
+func (g generator) chance(p float64) bool {
+	return p > 0 && g.r.Float64() < p
+}
+
+// field returns a field of values in b, given by the names indexed by value
+// if there are any and opts.Names allows.
+func (g generator) field(b bounds, names []string) string {
+	n := 1
+	if g.chance(g.opts.List) {
+		n = 2 + g.r.Intn(2)
+	}
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = g.part(b, names, n == 1)
+	}
+	return strings.Join(parts, ",")
+}
+
+// part returns a value, range or step of a field; a wildcard only if alone.
+func (g generator) part(b bounds, names []string, alone bool) string {
+	value := func(v int) string {
+		if names != nil && g.chance(g.opts.Names) {
+			return names[v]
+		}
+		return fmt.Sprint(v)
+	}
+	lo, n := int(b.min), int(b.max-b.min+1)
+	a := lo + g.r.Intn(n)
+	switch {
+	case g.chance(g.opts.Step):
+		step := 1 + g.r.Intn(n/2+1)
+		switch g.r.Intn(3) {
+		case 0:
+			return fmt.Sprintf("*/%d", step)
+		case 1:
+			return fmt.Sprintf("%s/%d", value(a), step)
+		}
+		return fmt.Sprintf("%s-%s/%d", value(a), value(a+g.r.Intn(int(b.max)-a+1)), step)
+	case g.chance(g.opts.Range):
+		return value(a) + "-" + value(a+g.r.Intn(int(b.max)-a+1))
+	case alone && g.r.Intn(3) == 0:
+		return "*"
+	}
+	return value(a)
+}
+
+// day returns a day of month or day of week field, which may give last,
+// nearest or nth weekdays, or be "?".
+func (g generator) day(b bounds) string {
+	var names []string
+	if b.max == dow.max {
+		names = genDowNames
+	}
+	switch {
+	case g.chance(g.opts.Last):
+		if names == nil {
+			if g.r.Intn(2) == 0 {
+				return "L"
+			}
+			return fmt.Sprintf("%dL", 1+g.r.Intn(7))
+		}
+		w := g.r.Intn(7)
+		if g.chance(g.opts.Names) {
+			return names[w] + "L"
+		}
+		return fmt.Sprintf("%dL", w)
+	case g.chance(g.opts.Weekday):
+		if names == nil {
+			if g.r.Intn(4) == 0 {
+				return "LW"
+			}
+			return fmt.Sprintf("%dW", 1+g.r.Intn(31))
+		}
+		w := fmt.Sprint(g.r.Intn(7))
+		if g.chance(g.opts.Names) {
+			w = names[g.r.Intn(7)]
+		}
+		return fmt.Sprintf("%s#%d", w, 1+g.r.Intn(5))
+	case g.r.Intn(10) == 0:
+		return "?"
+	}
+	return g.field(b, names)
+}
+
+// CheckScheduleInvariants probes the schedule at random times, chosen by the
+// seed, from 2000 to 2050, and returns an error describing the first broken
+// invariant it finds, or nil:
+//
+//   - Next returns a time after the one it is given, or the zero time, and
+//     NextOK agrees with it;
+//   - Next of an activation is later still, so activations only increase;
+//   - Latest of an activation is that activation, and Latest of the instant
+//     before it is no later than the time Next was given, as nothing falls in
+//     between;
+//   - if the schedule has a Matches method, as SpecSchedule does, it matches
+//     each activation, and no time between the given time and the activation.
+//
+// Schedules whose Latest always returns the zero time, such as
+// ConstantDelaySchedule, are not checked against it. Pairing it with
+// GenerateSpec gives a ready-made fuzz harness.
+func CheckScheduleInvariants(s Schedule, seed int64) error {
+	const probes = 50
+	r := rand.New(rand.NewSource(seed))
+	matcher, hasMatches := s.(interface{ Matches(time.Time) bool })
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	span := time.Date(2050, time.January, 1, 0, 0, 0, 0, time.UTC).Sub(start)
+
+	var withLatest, withoutLatest time.Time // activations with and without a Latest
+	for i := 0; i < probes; i++ {
+		t := start.Add(time.Duration(r.Int63n(int64(span))))
+		next := s.Next(t)
+		if okNext, ok := NextOK(s, t); !okNext.Equal(next) || ok == next.IsZero() {
+			return fmt.Errorf("NextOK(%v) = %v, %v, but Next = %v", t, okNext, ok, next)
+		}
+		if next.IsZero() {
+			continue
+		}
+		if !next.After(t) {
+			return fmt.Errorf("Next(%v) = %v, which is not after it", t, next)
+		}
+		if after := s.Next(next); !after.IsZero() && !after.After(next) {
+			return fmt.Errorf("Next(%v) = %v, which is not after it", next, after)
+		}
+
+		if latest := s.Latest(next); latest.IsZero() {
+			withoutLatest = next
+		} else if !latest.Equal(next) {
+			return fmt.Errorf("Latest(%v) = %v for the activation itself", next, latest)
+		} else {
+			withLatest = next
+			before := next.Add(-time.Nanosecond)
+			if latest := s.Latest(before); latest.After(t) {
+				return fmt.Errorf("Latest(%v) = %v, but Next(%v) = %v", before, latest, t, next)
+			}
+		}
+		if !withLatest.IsZero() && !withoutLatest.IsZero() {
+			return fmt.Errorf("Latest(%v) = %v, but Latest(%v) is the zero time",
+				withLatest, withLatest, withoutLatest)
+		}
+
+		if !hasMatches {
+			continue
+		}
+		if !matcher.Matches(next) {
+			return fmt.Errorf("Next(%v) = %v, which Matches rejects", t, next)
+		}
+		// A whole second strictly between t and next.
+		first := t.Truncate(time.Second).Add(time.Second)
+		if gap := next.Sub(first); gap > 0 {
+			between := first.Add(time.Duration(r.Int63n(int64(gap)))).Truncate(time.Second)
+			if matcher.Matches(between) {
+				return fmt.Errorf("Matches(%v), between %v and Next = %v", between, t, next)
+			}
+		}
+	}
+	return nil
+}
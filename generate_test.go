@@ -0,0 +1,110 @@
+package cron
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateSpec(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	seen := make(map[string]bool)
+	for _, opts := range []GenOpts{{}, {Seconds: true}, DefaultGenOpts()} {
+		for i := 0; i < 500; i++ {
+			spec := GenerateSpec(r, opts)
+			sched, layout, err := ParseFlexible(spec)
+			if err != nil {
+				t.Fatalf("%q: %v", spec, err)
+			}
+			if expected := map[bool]FieldLayout{false: StandardLayout, true: SecondsLayout}[opts.Seconds]; opts.Year {
+				expected = SecondsYearLayout
+				if layout != expected {
+					t.Errorf("%q: expected the %v layout, got %v", spec, expected, layout)
+				}
+			} else if layout != expected {
+				t.Errorf("%q: expected the %v layout, got %v", spec, expected, layout)
+			}
+			if err := CheckScheduleInvariants(sched, int64(i)); err != nil {
+				t.Errorf("%q: %v", spec, err)
+			}
+			for _, feature := range []string{"TZ=", "!", "/", "-", ",", "L", "W", "#", "?", "mon", "jan"} {
+				if strings.Contains(spec, feature) {
+					seen[feature] = true
+				}
+			}
+		}
+	}
+	for _, feature := range []string{"TZ=", "!", "/", "-", ",", "L", "W", "#", "?", "mon", "jan"} {
+		if !seen[feature] {
+			t.Errorf("expected some spec to contain %q", feature)
+		}
+	}
+
+	// The same state gives the same spec.
+	a, b := rand.New(rand.NewSource(7)), rand.New(rand.NewSource(7))
+	for i := 0; i < 10; i++ {
+		if x, y := GenerateSpec(a, DefaultGenOpts()), GenerateSpec(b, DefaultGenOpts()); x != y {
+			t.Errorf("expected %q and %q to be equal", x, y)
+		}
+	}
+}
+
+// brokenSchedule breaks one of the invariants.
+type brokenSchedule struct {
+	SpecSchedule
+	next, latest func(*SpecSchedule, time.Time) time.Time
+}
+
+func (b *brokenSchedule) Next(t time.Time) time.Time {
+	if b.next != nil {
+		return b.next(&b.SpecSchedule, t)
+	}
+	return b.SpecSchedule.Next(t)
+}
+
+func (b *brokenSchedule) NextOK(t time.Time) (time.Time, bool) {
+	next := b.Next(t)
+	return next, !next.IsZero()
+}
+
+func (b *brokenSchedule) Latest(t time.Time) time.Time {
+	if b.latest != nil {
+		return b.latest(&b.SpecSchedule, t)
+	}
+	return b.SpecSchedule.Latest(t)
+}
+
+func TestCheckScheduleInvariants(t *testing.T) {
+	hourly := *mustParse(t, "TZ=UTC 0 * * * *").(*SpecSchedule)
+	for _, sched := range []Schedule{&hourly, Every(time.Minute), MustParseMultiple("0 9 * * *", "30 */2 * * *")} {
+		if err := CheckScheduleInvariants(sched, 1); err != nil {
+			t.Errorf("%v: %v", sched, err)
+		}
+	}
+
+	for name, broken := range map[string]*brokenSchedule{
+		"next not after": {SpecSchedule: hourly, next: func(s *SpecSchedule, t time.Time) time.Time {
+			return s.Next(t.Add(-time.Hour))
+		}},
+		"skips an activation": {SpecSchedule: hourly, next: func(s *SpecSchedule, t time.Time) time.Time {
+			return s.Next(s.Next(t))
+		}},
+		"latest before": {SpecSchedule: hourly, latest: func(s *SpecSchedule, t time.Time) time.Time {
+			return s.Latest(t.Add(-time.Nanosecond))
+		}},
+		"latest sometimes zero": {SpecSchedule: hourly, latest: func(s *SpecSchedule, t time.Time) time.Time {
+			if t.Year()%2 == 0 {
+				return time.Time{}
+			}
+			return s.Latest(t)
+		}},
+		"matches off": {SpecSchedule: hourly, next: func(s *SpecSchedule, t time.Time) time.Time {
+			return s.Next(t).Add(time.Minute)
+		}},
+	} {
+		if err := CheckScheduleInvariants(broken, 1); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
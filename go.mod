@@ -1,3 +1,3 @@
 module github.com/penhauer-xiao/cron/v3
 
-go 1.12
+go 1.20
@@ -0,0 +1,158 @@
+package cron
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Group is a named set of entries that can be controlled together: paused,
+// resumed, limited in concurrency, or removed at once. Groups are created
+// with Cron.Group.
+type Group struct {
+	c       *Cron
+	name    string
+	limit   limiter       // or nil for no limit
+	removed chan struct{} // closed when the group is removed
+
+	mu     sync.Mutex
+	paused bool
+}
+
+// GroupOption represents a modification to the behavior of a Group.
+type GroupOption func(*Group)
+
+// GroupMaxConcurrency limits the number of the group's jobs that may run at
+// once. Jobs due while the group is at its limit wait for a running job to
+// finish. The limit applies on top of any limit on the entries themselves.
+func GroupMaxConcurrency(n int) GroupOption {
+	return func(g *Group) {
+		if n > 0 {
+			g.limit = newLimiter(n)
+		}
+	}
+}
+
+// Group returns the group with the given name, creating it with the given
+// options if it does not exist yet. Options are ignored for an existing group.
+func (c *Cron) Group(name string, opts ...GroupOption) *Group {
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if g, ok := c.groups[name]; ok {
+		return g
+	}
+	g := &Group{c: c, name: name, removed: make(chan struct{})}
+	for _, opt := range opts {
+		opt(g)
+	}
+	c.groups[name] = g
+	return g
+}
+
+// RemoveGroup removes the named group and all of its entries at once. Jobs
+// of the group that are running are left to finish, while those waiting for
+// room under the group's limit are dropped.
+func (c *Cron) RemoveGroup(name string) {
+	c.runningMu.Lock()
+	g, ok := c.groups[name]
+	if !ok {
+		c.runningMu.Unlock()
+		return
+	}
+	delete(c.groups, name)
+	close(g.removed)
+	removed := c.removeWhere(func(e *Entry) bool { return e.group == g })
+	c.runningMu.Unlock()
+	c.entriesRemoved(removed, RemovedExplicitly)
+}
+
+// Name returns the name of the group.
+func (g *Group) Name() string { return g.name }
+
+// AddFunc adds a func to the group, as Cron.AddFunc.
+func (g *Group) AddFunc(spec string, cmd func(), opts ...EntryOption) (EntryID, error) {
+	return g.AddJob(spec, FuncJob(cmd), opts...)
+}
+
+// AddJob adds a Job to the group, as Cron.AddJob.
+func (g *Group) AddJob(spec string, cmd Job, opts ...EntryOption) (EntryID, error) {
+	schedule, err := g.c.parser.Parse(spec)
+	if err != nil {
+		return 0, err
+	}
+	return g.schedule(schedule, cmd, opts...)
+}
+
+// Schedule adds a Job to the group, as Cron.Schedule. It returns the zero
+// EntryID, adding nothing, if the group has been removed.
+func (g *Group) Schedule(schedule Schedule, cmd Job, opts ...EntryOption) EntryID {
+	id, _ := g.schedule(schedule, cmd, opts...)
+	return id
+}
+
+func (g *Group) schedule(schedule Schedule, cmd Job, opts ...EntryOption) (EntryID, error) {
+	g.c.runningMu.Lock()
+	if g.c.groups[g.name] != g {
+		g.c.runningMu.Unlock()
+		return 0, fmt.Errorf("group %s has been removed", g.name)
+	}
+	e, err := g.c.schedule(schedule, cmd, append(opts, func(e *Entry) {
+		e.Group = g.name
+		e.group = g
+	})...)
+	g.c.runningMu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	g.c.entryAdded(e)
+	return e.ID, nil
+}
+
+// Remove removes an entry of the group. Entries outside the group are left alone.
+func (g *Group) Remove(id EntryID) {
+	g.c.runningMu.Lock()
+	removed := g.c.removeWhere(func(e *Entry) bool { return e.ID == id && e.group == g })
+	g.c.runningMu.Unlock()
+	g.c.entriesRemoved(removed, RemovedExplicitly)
+}
+
+// Entries returns a snapshot of the group's entries.
+func (g *Group) Entries() []Entry {
+	var entries []Entry
+	for _, e := range g.c.Entries() {
+		if e.group == g {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Pause stops the group's jobs from being run. Activations that fall due
+// while the group is paused are skipped, not caught up later.
+func (g *Group) Pause() {
+	if g.setPaused(true) {
+		g.c.entriesPaused(g, true)
+	}
+}
+
+// Resume undoes Pause.
+func (g *Group) Resume() {
+	if g.setPaused(false) {
+		g.c.entriesPaused(g, false)
+	}
+}
+
+// setPaused pauses or resumes the group, reporting whether that changed it.
+func (g *Group) setPaused(paused bool) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	changed := g.paused != paused
+	g.paused = paused
+	return changed
+}
+
+// Paused reports whether the group is paused.
+func (g *Group) Paused() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.paused
+}
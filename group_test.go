@@ -0,0 +1,89 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroupPauseResume(t *testing.T) {
+	fc := newFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var runs int32
+	cron := New(WithParser(secondParser), WithChain(), WithLocation(time.UTC))
+	cron.clock = fc
+	g := cron.Group("reporting")
+	id, err := g.AddFunc("* * * * * ?", func() { atomic.AddInt32(&runs, 1) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	cron.AddFunc("* * * * * ?", func() {})
+	cron.Start()
+
+	timer := <-fc.timers
+	g.Pause()
+	timer = fc.advance(timer)
+	timer = fc.advance(timer)
+	g.Resume()
+	timer = fc.advance(timer)
+	<-cron.Stop().Done()
+
+	if actual := atomic.LoadInt32(&runs); actual != 1 {
+		t.Errorf("expected 1 run after resuming, got %d", actual)
+	}
+	entries := g.Entries()
+	if len(entries) != 1 || entries[0].ID != id || entries[0].Group != "reporting" {
+		t.Errorf("expected the group's entry in its snapshot, got %v", entries)
+	}
+	if cron.Group("reporting") != g {
+		t.Error("expected the existing group to be returned")
+	}
+}
+
+func TestGroupMaxConcurrency(t *testing.T) {
+	fc := newFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var (
+		runs    int32
+		started = make(chan struct{}, 10)
+		release = make(chan struct{})
+	)
+	block := func() {
+		atomic.AddInt32(&runs, 1)
+		started <- struct{}{}
+		<-release
+	}
+	cron := New(WithParser(secondParser), WithChain(), WithLocation(time.UTC))
+	cron.clock = fc
+	g := cron.Group("tenant-acme", GroupMaxConcurrency(1))
+	g.AddFunc("* * * * * ?", block)
+	g.AddFunc("* * * * * ?", block)
+	cron.Start()
+
+	// Two jobs are due at once, but only one may run.
+	timer := <-fc.timers
+	timer = fc.advance(timer)
+	<-started
+	select {
+	case <-started:
+		t.Fatal("expected the group to run one job at a time")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// Removing the group drops the waiting job and leaves the running one.
+	cron.RemoveGroup("tenant-acme")
+	if entries := cron.Entries(); len(entries) != 0 {
+		t.Errorf("expected the group's entries to be removed, got %v", entries)
+	}
+	release <- struct{}{}
+	select {
+	case <-cron.Stop().Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected no jobs to be left waiting")
+	}
+	if actual := atomic.LoadInt32(&runs); actual != 1 {
+		t.Errorf("expected 1 run, got %d", actual)
+	}
+
+	if _, err := g.AddFunc("* * * * * ?", block); err == nil {
+		t.Error("expected an error adding to a removed group")
+	}
+}
@@ -0,0 +1,64 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseWithSeed parses a standard 5-field spec in which a field may be given
+// as H, Jenkins-style, for a value chosen by the seed: H is the field's
+// minimum plus the seed modulo the number of values in the field, and H(lo-hi)
+// is lo plus the seed modulo the number of values from lo to hi. For example,
+// "H H(0-5) * * *" with seed 100 runs at 4:40 every day.
+//
+// The seed is typically a hash of the job's name, so that many jobs with the
+// same spec are spread out, while each keeps the same times from one run of
+// the program to the next. The result is the same as parsing the spec with the
+// chosen values in place of H. Interval descriptors ("@every 5m") are
+// rejected.
+func ParseWithSeed(spec string, seed uint64) (*SpecSchedule, error) {
+	expr, err := standardParser.expression(spec)
+	if err != nil {
+		return nil, err
+	}
+	if expr.fields == nil {
+		return nil, fmt.Errorf("interval is not a cron expression: %s", spec)
+	}
+	for i, field := range expr.fields {
+		if field != "H" && !strings.HasPrefix(field, "H(") {
+			continue
+		}
+		v, err := hashValue(field, *fieldBounds[i], seed)
+		if err != nil {
+			return nil, ParseError{Field: fieldNames[i], Value: field, Err: err}
+		}
+		expr.fields[i] = strconv.FormatUint(uint64(v), 10)
+	}
+	return expr.Compile(time.Local)
+}
+
+// hashValue returns the value chosen by the seed for an H token, which is "H"
+// or "H(lo-hi)", in a field with the given bounds.
+func hashValue(token string, r bounds, seed uint64) (uint, error) {
+	lo, hi := r.min, r.max
+	if token != "H" {
+		inner := strings.TrimSuffix(strings.TrimPrefix(token, "H("), ")")
+		ends := strings.Split(inner, "-")
+		if !strings.HasSuffix(token, ")") || len(ends) != 2 {
+			return 0, fmt.Errorf("expected H or H(lo-hi): %s", token)
+		}
+		var err error
+		if lo, err = parseIntOrName(ends[0], r.names); err != nil {
+			return 0, err
+		}
+		if hi, err = parseIntOrName(ends[1], r.names); err != nil {
+			return 0, err
+		}
+		if lo < r.min || hi > r.max || lo > hi {
+			return 0, fmt.Errorf("range of H must be within %d-%d: %s", r.min, r.max, token)
+		}
+	}
+	return lo + uint(seed%uint64(hi-lo+1)), nil
+}
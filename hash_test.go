@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWithSeed(t *testing.T) {
+	tests := []struct {
+		spec     string
+		seed     uint64
+		expected string
+	}{
+		{"H H(0-5) * * *", 100, "40 4 * * *"},
+		{"H H(0-5) * * *", 101, "41 5 * * *"},
+		{"H H(0-5) * * *", 0, "0 0 * * *"},
+		{"0 H(9-17) * * H(mon-fri)", 7, "0 16 * * wed"},
+		{"0 0 H * *", 30, "0 0 31 * *"},
+		{"0 0 H * *", 31, "0 0 1 * *"},
+		{"0 0 1 H *", 12, "0 0 1 1 *"},
+		{"30 H(23-23) * * *", 1 << 63, "30 23 * * *"},
+	}
+	for _, c := range tests {
+		actual, err := ParseWithSeed(c.spec, c.seed)
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		expected := mustParse(t, c.expected).(*SpecSchedule)
+		if !actual.Equal(expected) {
+			t.Errorf("%s with seed %d: %v", c.spec, c.seed, actual.Diff(expected))
+		}
+	}
+
+	// The same seed always gives the same times, and other seeds spread them
+	// over the field's values.
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	minutes := make(map[int]bool)
+	for seed := uint64(0); seed < 1000; seed++ {
+		a, err := ParseWithSeed("TZ=UTC H H * * *", seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := ParseWithSeed("TZ=UTC H H * * *", seed)
+		if !a.Next(from).Equal(b.Next(from)) {
+			t.Fatalf("seed %d: expected the same time, got %v and %v", seed, a.Next(from), b.Next(from))
+		}
+		next := a.Next(from)
+		if next.Sub(from) > 24*time.Hour {
+			t.Errorf("seed %d: expected a time on the first day, got %v", seed, next)
+		}
+		minutes[next.Minute()] = true
+	}
+	if len(minutes) != 60 {
+		t.Errorf("expected every minute to be chosen by some seed, got %d", len(minutes))
+	}
+	a, _ := ParseWithSeed("TZ=UTC H 9 * * *", 1)
+	b, _ := ParseWithSeed("TZ=UTC H 9 * * *", 2)
+	if a.Next(from).Equal(b.Next(from)) {
+		t.Error("expected different seeds to give different times")
+	}
+
+	for _, spec := range []string{"H(0-60) * * * *", "H(5-1) * * * *", "H(5) * * * *", "H(0-5 * * * *", "0 0 H(0-5) * *", "H,30 9 * * *", "@every 1h"} {
+		if _, err := ParseWithSeed(spec, 1); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}
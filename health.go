@@ -0,0 +1,135 @@
+package cron
+
+import "time"
+
+// HealthProblem is something Health found wrong with an entry.
+type HealthProblem string
+
+const (
+	// HealthStalled means the entry hasn't run when it should have: it missed
+	// its schedule's latest activation, or went longer than its WithSLA
+	// without running.
+	HealthStalled HealthProblem = "stalled"
+
+	// HealthNoNext means the entry has no next activation although its
+	// schedule does, so it will never run again.
+	HealthNoNext HealthProblem = "no-next"
+
+	// HealthFailing means the entry's job has failed at least the threshold
+	// number of times in a row (see WithHealthThresholds).
+	HealthFailing HealthProblem = "failing"
+
+	// HealthOverrunning means the entry's job was still running at least the
+	// threshold number of activations in a row.
+	HealthOverrunning HealthProblem = "overrunning"
+)
+
+// stallGrace is how late an activation may be dispatched before Health
+// reports its entry as stalled.
+const stallGrace = time.Minute
+
+const (
+	defaultFailureThreshold = 3
+	defaultOverrunThreshold = 3
+)
+
+// EntryHealth is the health of an entry at the time of a HealthReport.
+type EntryHealth struct {
+	ID EntryID
+
+	// Problems lists what is wrong with the entry, or is empty if nothing is.
+	Problems []HealthProblem
+
+	// LastRun and Next are the entry's Prev and Next.
+	LastRun, Next time.Time
+
+	// Failures is the number of runs in a row that have failed, and Overruns
+	// the number of activations in a row that found the job still running.
+	Failures, Overruns int
+}
+
+// Has reports whether the entry has the given problem.
+func (h EntryHealth) Has(problem HealthProblem) bool {
+	for _, p := range h.Problems {
+		if p == problem {
+			return true
+		}
+	}
+	return false
+}
+
+// HealthReport is the health of a Cron's entries, as returned by Health.
+type HealthReport struct {
+	Time    time.Time
+	Entries []EntryHealth
+}
+
+// Healthy reports whether no entry has a problem.
+func (r HealthReport) Healthy() bool {
+	return len(r.Unhealthy()) == 0
+}
+
+// Unhealthy returns the entries that have a problem.
+func (r HealthReport) Unhealthy() []EntryHealth {
+	var unhealthy []EntryHealth
+	for _, h := range r.Entries {
+		if len(h.Problems) > 0 {
+			unhealthy = append(unhealthy, h)
+		}
+	}
+	return unhealthy
+}
+
+// Health reports on each entry, so that one that has silently stopped
+// running, or keeps failing or overrunning, can be alerted on. It works from
+// the entries' snapshots, which the run loop keeps, and asks only schedules
+// known to have no state, such as SpecSchedule, for a next activation, so it
+// is cheap enough to call on every health check and leaves schedules such as
+// BackoffSchedule untouched. Entries in paused groups, and those
+// of a Cron that hasn't been started, are never reported as stalled.
+func (c *Cron) Health() HealthReport {
+	now := c.now()
+	report := HealthReport{Time: now}
+	for _, e := range c.Entries() {
+		h := EntryHealth{ID: e.ID, LastRun: e.Prev, Next: e.Next}
+		e.runs.mu.Lock()
+		h.Failures, h.Overruns = e.runs.failures, e.runs.overruns
+		pending := !e.runs.override.IsZero()
+		e.runs.mu.Unlock()
+
+		if !e.planned.IsZero() && (e.group == nil || !e.group.Paused()) && stalled(e, now) {
+			h.Problems = append(h.Problems, HealthStalled)
+		}
+		if !e.planned.IsZero() && e.Next.IsZero() && e.Running == 0 && !pending &&
+			stateless(e.Schedule) && !e.Schedule.Next(now).IsZero() {
+			h.Problems = append(h.Problems, HealthNoNext)
+		}
+		if c.failureThreshold > 0 && h.Failures >= c.failureThreshold {
+			h.Problems = append(h.Problems, HealthFailing)
+		}
+		if c.overrunThreshold > 0 && h.Overruns >= c.overrunThreshold {
+			h.Problems = append(h.Problems, HealthOverrunning)
+		}
+		report.Entries = append(report.Entries, h)
+	}
+	return report
+}
+
+// stalled reports whether the entry should have run since it last did: with
+// an SLA, when it has gone longer than that without running, and otherwise
+// when its next activation is more than stallGrace past without the run loop
+// having dispatched it.
+func stalled(e Entry, now time.Time) bool {
+	if e.sla > 0 {
+		last := e.Prev
+		switch {
+		case !last.IsZero():
+		case e.Stagger > 0:
+			last = e.Next // held back by WithStartupStagger until then
+		default:
+			last = e.planned
+		}
+		return now.Sub(last) > e.sla
+	}
+	return !e.Next.IsZero() && now.Sub(e.Next) > stallGrace
+}
@@ -0,0 +1,121 @@
+package cron
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHealth(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	release := make(chan struct{})
+	cron, fc := newFakeCron(start)
+	healthy, _ := cron.AddFunc("* * * * * ?", func() {})
+	failing, _ := cron.AddJob("* * * * * ?", failingJob{errors.New("failed")})
+	overrunning, _ := cron.AddFunc("* * * * * ?", func() { <-release }, WithMaxConcurrentRuns(1, OverlapSkip))
+	sla, _ := cron.AddFunc("0 0 * * * ?", func() {}, WithSLA(30*time.Minute))
+	cron.Start()
+
+	// Runs finish in their own goroutines: wait for the quick ones before each
+	// activation, so that only the blocked job overruns.
+	timer := <-fc.timers
+	for i := 0; i < 4; i++ {
+		timer = fc.advance(timer)
+		waitFor(t, func() bool { return cron.Entry(healthy).Running == 0 && cron.Entry(failing).Running == 0 })
+	}
+
+	problems := func(report HealthReport) map[EntryID][]HealthProblem {
+		m := make(map[EntryID][]HealthProblem)
+		for _, h := range report.Entries {
+			m[h.ID] = h.Problems
+		}
+		return m
+	}
+	expected := map[EntryID][]HealthProblem{
+		healthy:     nil,
+		failing:     {HealthFailing},
+		overrunning: {HealthOverrunning},
+		sla:         nil,
+	}
+	report := cron.Health()
+	if got := problems(report); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if report.Healthy() || len(report.Unhealthy()) != 2 {
+		t.Errorf("expected 2 unhealthy entries, got %v", report.Unhealthy())
+	}
+
+	// The run loop stops waking up, as if its timer were lost: every entry
+	// falls behind, the hourly one once it has gone its SLA without running.
+	fc.mu.Lock()
+	fc.now = fc.now.Add(10 * time.Minute)
+	fc.mu.Unlock()
+	report = cron.Health()
+	for _, h := range report.Entries {
+		if h.Has(HealthStalled) != (h.ID != sla) {
+			t.Errorf("entry %d: unexpected problems %v", h.ID, h.Problems)
+		}
+	}
+	fc.mu.Lock()
+	fc.now = fc.now.Add(30 * time.Minute)
+	fc.mu.Unlock()
+	if h := problems(cron.Health())[sla]; !reflect.DeepEqual(h, []HealthProblem{HealthStalled}) {
+		t.Errorf("expected the hourly entry to be stalled, got %v", h)
+	}
+
+	close(release)
+	<-cron.Stop().Done()
+}
+
+// waitFor waits up to a second for cond to hold.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); !cond(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+	}
+}
+
+func TestHealthNoNext(t *testing.T) {
+	cron, fc := newFakeCron(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		WithParser(NewParser(Minute|Hour|Dom|Month|Dow|Year)))
+	id, _ := cron.AddFunc("0 0 * * * 2024", func() {})
+	if report := cron.Health(); !report.Healthy() {
+		t.Errorf("expected a Cron that hasn't started to be healthy, got %v", report)
+	}
+	cron.Start()
+	defer cron.Stop()
+	<-fc.timers
+
+	// The clock is stepped back into the schedule's year, after the entry was
+	// found to have no next activation.
+	fc.mu.Lock()
+	fc.now = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	fc.mu.Unlock()
+	h := cron.Health().Entries[0]
+	if h.ID != id || !reflect.DeepEqual(h.Problems, []HealthProblem{HealthNoNext}) {
+		t.Errorf("expected the entry to have no next activation, got %v", h)
+	}
+}
+
+func TestHealthLeavesScheduleAlone(t *testing.T) {
+	cron, fc := newFakeCron(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	backoff := ExponentialBackoffSchedule(3*time.Hour, 2, 1000*time.Hour)
+	id := cron.Schedule(backoff, FuncJob(func() {}))
+	cron.Start()
+	defer cron.Stop()
+	<-fc.timers
+
+	next := cron.Entry(id).Next
+	for i := 0; i < 4; i++ {
+		cron.Health()
+	}
+	backoff.mu.Lock()
+	attempt := backoff.attempt
+	backoff.mu.Unlock()
+	if attempt != 1 || !cron.Entry(id).Next.Equal(next) {
+		t.Errorf("expected Health to leave the backoff at its first delay, got %d attempts and next %v", attempt, cron.Entry(id).Next)
+	}
+}
@@ -0,0 +1,117 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// EntryHooks are called as entries are added, changed, paused, resumed and
+// removed, whichever call or the run loop itself made the change, e.g. to keep
+// an audit log. Any of them may be nil.
+//
+// Hooks are called synchronously, once the change has been made, with
+// snapshots of the entries concerned. They are called outside the Cron's
+// locks, so they may call back into it; the exception is changes made by the
+// run loop itself, such as removing an entry that has reached WithMaxRuns,
+// whose hooks are called on a goroutine of their own. A hook that panics is
+// logged and otherwise ignored, leaving the change in place.
+type EntryHooks struct {
+	// OnAdded is called after an entry is added.
+	OnAdded func(Entry)
+
+	// OnRemoved is called after an entry is removed, with the reason why.
+	// Removing several entries at once, as by RemoveWhere or RemoveGroup,
+	// calls it once for each.
+	OnRemoved func(Entry, RemovalReason)
+
+	// OnUpdated is called after an entry is changed in place, as when
+	// ReloadLocations moves its next activation, with snapshots from before
+	// and after the change.
+	OnUpdated func(old, new Entry)
+
+	// OnPaused and OnResumed are called for each entry of a group after the
+	// group is paused or resumed. Pausing a paused group, or resuming one that
+	// isn't, calls neither.
+	OnPaused  func(Entry)
+	OnResumed func(Entry)
+
+	// OnDropped is called when an activation of an entry is dropped because
+	// the queue of jobs waiting to run is full (see WithQueueSize), with a
+	// snapshot of the entry as it fell due and the time it was scheduled
+	// for. It is called on the goroutine that would have run the job.
+	OnDropped func(Entry, time.Time)
+}
+
+// RemovalReason tells EntryHooks.OnRemoved why an entry was removed.
+type RemovalReason string
+
+const (
+	// RemovedExplicitly is given for entries removed by Remove, RemoveWhere,
+	// RemoveGroup or Group.Remove.
+	RemovedExplicitly RemovalReason = "removed"
+
+	// RemovedMaxRuns is given for entries removed once their job has been
+	// started as many times as WithMaxRuns allows.
+	RemovedMaxRuns RemovalReason = "max-runs"
+
+	// RemovedExhausted is given for entries removed because their schedule
+	// has no further activations, under WithRemoveExhausted or once a
+	// ScheduleChain has been through all of its schedules.
+	RemovedExhausted RemovalReason = "exhausted"
+)
+
+// callHook calls the hook, logging rather than passing on a panic.
+func (c *Cron) callHook(name string, id EntryID, hook func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.logger.Error(fmt.Errorf("%v", r), "hook panicked", "hook", name, "entry", id)
+		}
+	}()
+	hook()
+}
+
+func (c *Cron) entryAdded(e Entry) {
+	if h := c.hooks.OnAdded; h != nil {
+		c.callHook("OnAdded", e.ID, func() { h(e) })
+	}
+}
+
+func (c *Cron) entriesRemoved(entries []Entry, reason RemovalReason) {
+	if h := c.hooks.OnRemoved; h != nil {
+		for _, e := range entries {
+			e := e
+			c.callHook("OnRemoved", e.ID, func() { h(e, reason) })
+		}
+	}
+}
+
+func (c *Cron) entriesUpdated(updates []entryUpdate) {
+	if h := c.hooks.OnUpdated; h != nil {
+		for _, u := range updates {
+			u := u
+			c.callHook("OnUpdated", u.new.ID, func() { h(u.old, u.new) })
+		}
+	}
+}
+
+func (c *Cron) activationDropped(e Entry, scheduled time.Time) {
+	if h := c.hooks.OnDropped; h != nil {
+		c.callHook("OnDropped", e.ID, func() { h(e, scheduled) })
+	}
+}
+
+// entriesPaused calls OnPaused, or OnResumed if paused is false, for each of
+// the group's entries.
+func (c *Cron) entriesPaused(g *Group, paused bool) {
+	h, name := c.hooks.OnPaused, "OnPaused"
+	if !paused {
+		h, name = c.hooks.OnResumed, "OnResumed"
+	}
+	if h == nil {
+		return
+	}
+	for _, e := range g.Entries() {
+		e := e
+		c.callHook(name, e.ID, func() { h(e) })
+	}
+}
@@ -0,0 +1,157 @@
+package cron
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEntryHooks(t *testing.T) {
+	var (
+		cron   *Cron
+		fc     *fakeClock
+		mu     sync.Mutex
+		events []string
+	)
+	record := func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, fmt.Sprintf(format, args...))
+	}
+	cron, fc = newFakeCron(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		WithEntryHooks(EntryHooks{
+			OnAdded: func(e Entry) {
+				record("added %d", e.ID)
+				if e.ID == 6 {
+					panic("audit log unavailable")
+				}
+			},
+			OnRemoved: func(e Entry, reason RemovalReason) {
+				// The change is made before the hook is called, and the hook
+				// may call back into the Cron.
+				if cron.Entry(e.ID).Valid() {
+					t.Errorf("entry %d still present when its removal hook ran", e.ID)
+				}
+				record("removed %d %s", e.ID, reason)
+			},
+			OnPaused:  func(e Entry) { record("paused %d", e.ID) },
+			OnResumed: func(e Entry) { record("resumed %d", e.ID) },
+		}))
+
+	noop := func() {}
+	cron.AddFunc("* * * * * ?", noop) // 1
+	g := cron.Group("batch")
+	g.AddFunc("* * * * * ?", noop)                    // 2
+	g.AddFunc("* * * * * ?", noop)                    // 3
+	cron.AddFunc("* * * * * ?", noop, WithMaxRuns(2)) // 4
+	cron.Start()
+
+	timer := <-fc.timers
+	cron.AddFunc("* * * * * ?", noop) // 5, added while running
+	g.Pause()
+	g.Pause()
+	g.Resume()
+	timer = fc.advance(timer)
+	timer = fc.advance(timer) // entry 4 reaches its maximum
+	fc.advance(timer)
+
+	// Bulk removals call the hook for each entry.
+	if n := cron.RemoveWhere(func(e Entry) bool { return e.Group == "" }); n != 2 {
+		t.Errorf("expected 2 entries removed, got %d", n)
+	}
+	cron.RemoveGroup("batch")
+	cron.Remove(42)
+
+	// A panicking hook leaves the entry added.
+	id, err := cron.AddFunc("* * * * * ?", noop)
+	if err != nil || id != 6 || !cron.Entry(id).Valid() {
+		t.Errorf("expected entry 6 to be added, got %d, %v", id, err)
+	}
+	<-cron.Stop().Done()
+
+	expected := []string{
+		"added 1", "added 2", "added 3", "added 4", "added 5",
+		"paused 2", "paused 3", "resumed 2", "resumed 3",
+		"removed 4 max-runs",
+		"removed 1 removed", "removed 5 removed",
+		"removed 2 removed", "removed 3 removed",
+		"added 6",
+	}
+	sort.Strings(expected)
+	sort.Strings(events)
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("expected events\n%v\ngot\n%v", expected, events)
+	}
+	if entries := cron.Entries(); len(entries) != 1 || entries[0].ID != 6 {
+		t.Errorf("expected only entry 6 to be left, got %v", entries)
+	}
+}
+
+func TestRemoveExhausted(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := newFakeClock(start)
+	type removal struct {
+		entry  Entry
+		reason RemovalReason
+	}
+	removed := make(chan removal, 2)
+	cron := New(WithLocation(time.UTC), WithLogger(DiscardLogger), WithRemoveExhausted(),
+		WithEntryHooks(EntryHooks{OnRemoved: func(e Entry, reason RemovalReason) {
+			removed <- removal{e, reason}
+		}}))
+	cron.clock = fc
+
+	// A spec with no activations to come is rejected, but schedules with state
+	// aren't asked until the Cron runs them.
+	past, _ := NewParser(Minute | Hour | Dom | Month | Dow | Year).Parse("0 0 * * * 2023")
+	if id := cron.Schedule(past, FuncJob(func() {})); id != 0 {
+		t.Errorf("expected the exhausted entry to be rejected, got %d", id)
+	}
+	_, err := cron.schedule(past, FuncJob(func() {}))
+	if err != ErrExhausted {
+		t.Errorf("expected ErrExhausted, got %v", err)
+	}
+	stale := cron.Schedule(timesSchedule{start.Add(-time.Hour)}, FuncJob(func() {}))
+	debounced := Debounce(Every(time.Minute), 90*time.Second)
+	cron.Schedule(debounced, FuncJob(func() {}), WithExhaustedRemoval(false))
+	if !debounced.last.IsZero() {
+		t.Errorf("expected adding the entry to leave its debounce alone, got last %v", debounced.last)
+	}
+
+	var runs sync.WaitGroup
+	runs.Add(4)
+	twice := timesSchedule{start.Add(time.Second), start.Add(2 * time.Second)}
+	id := cron.Schedule(twice, FuncJob(runs.Done))
+	kept := cron.Schedule(twice, FuncJob(runs.Done), WithExhaustedRemoval(false))
+	cron.Start()
+	defer cron.Stop()
+
+	timer := <-fc.timers
+	if r := <-removed; r.entry.ID != stale || r.reason != RemovedExhausted {
+		t.Fatalf("expected entry %d to be removed as exhausted on start, got %d %s", stale, r.entry.ID, r.reason)
+	}
+	for i := 0; i < 2; i++ {
+		fc.advance(timer)
+		timer = <-fc.timers // woken as the run finishes
+	}
+	runs.Wait()
+
+	r := <-removed
+	if r.entry.ID != id || r.reason != RemovedExhausted {
+		t.Fatalf("expected entry %d to be removed as exhausted, got %d %s", id, r.entry.ID, r.reason)
+	}
+	if !r.entry.Prev.Equal(twice[1]) || r.entry.Stats().Runs != 2 {
+		t.Errorf("expected the hook to get the entry's history, got %v and %+v", r.entry.Prev, r.entry.Stats())
+	}
+	if entries := cron.Entries(); len(entries) != 2 || entries[0].ID != kept && entries[1].ID != kept {
+		t.Errorf("expected entry %d to be kept, got %v", kept, entries)
+	}
+	select {
+	case r := <-removed:
+		t.Errorf("expected no other removal, got %d %s", r.entry.ID, r.reason)
+	default:
+	}
+}
@@ -0,0 +1,275 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// locale holds the month and weekday names of a language, in addition to the
+// English names, which are always accepted.
+type locale struct {
+	months, weekdays map[string]uint
+}
+
+var (
+	localesMu sync.RWMutex
+
+	// locales maps a BCP 47 language tag, in lower case, to its names.
+	locales = map[string]locale{
+		"fr": {
+			months: map[string]uint{
+				"janvier": 1, "février": 2, "fevrier": 2, "mars": 3,
+				"avril": 4, "mai": 5, "juin": 6, "juillet": 7,
+				"août": 8, "aout": 8, "septembre": 9, "octobre": 10,
+				"novembre": 11, "décembre": 12, "decembre": 12,
+			},
+			weekdays: map[string]uint{
+				"dimanche": 0, "lundi": 1, "mardi": 2, "mercredi": 3,
+				"jeudi": 4, "vendredi": 5, "samedi": 6,
+				"dim": 0, "lun": 1, "mar": 2, "mer": 3,
+				"jeu": 4, "ven": 5, "sam": 6,
+			},
+		},
+		"de": {
+			months: map[string]uint{
+				"januar": 1, "februar": 2, "märz": 3, "maerz": 3,
+				"april": 4, "mai": 5, "juni": 6, "juli": 7,
+				"august": 8, "september": 9, "oktober": 10,
+				"november": 11, "dezember": 12,
+			},
+			weekdays: map[string]uint{
+				"sonntag": 0, "montag": 1, "dienstag": 2, "mittwoch": 3,
+				"donnerstag": 4, "freitag": 5, "samstag": 6,
+				"so": 0, "mo": 1, "di": 2, "mi": 3,
+				"do": 4, "fr": 5, "sa": 6,
+			},
+		},
+		"es": {
+			months: map[string]uint{
+				"enero": 1, "febrero": 2, "marzo": 3, "abril": 4,
+				"mayo": 5, "junio": 6, "julio": 7, "agosto": 8,
+				"septiembre": 9, "octubre": 10, "noviembre": 11,
+				"diciembre": 12,
+			},
+			weekdays: map[string]uint{
+				"domingo": 0, "lunes": 1, "martes": 2, "miércoles": 3,
+				"jueves": 4, "viernes": 5, "sábado": 6,
+				"miercoles": 3, "sabado": 6,
+				"dom": 0, "lun": 1, "mar": 2, "mié": 3,
+				"jue": 4, "vie": 5, "sáb": 6,
+				"mie": 3, "sab": 6,
+			},
+		},
+		"ja": {
+			months: map[string]uint{
+				"1月": 1, "2月": 2, "3月": 3, "4月": 4, "5月": 5, "6月": 6,
+				"7月": 7, "8月": 8, "9月": 9, "10月": 10, "11月": 11,
+				"12月": 12,
+			},
+			weekdays: map[string]uint{
+				"日曜日": 0, "月曜日": 1, "火曜日": 2, "水曜日": 3,
+				"木曜日": 4, "金曜日": 5, "土曜日": 6,
+				"日": 0, "月": 1, "火": 2, "水": 3,
+				"木": 4, "金": 5, "土": 6,
+			},
+		},
+	}
+)
+
+// RegisterLocale adds or replaces the month and weekday names for the given
+// language tag, for use by ParseWithLocale and Parser.WithNames. Months are
+// numbered from 1 and weekdays from 0 (Sunday). Names are matched
+// case-insensitively.
+func RegisterLocale(tag string, months, weekdays map[string]uint) error {
+	if tag == "" {
+		return fmt.Errorf("empty locale tag")
+	}
+	l, err := newLocale(months, weekdays)
+	if err != nil {
+		return fmt.Errorf("locale %s: %v", tag, err)
+	}
+
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[strings.ToLower(tag)] = l
+	return nil
+}
+
+// newLocale validates the names and returns them in lower case.
+func newLocale(months, weekdays map[string]uint) (locale, error) {
+	l := locale{
+		months:   make(map[string]uint, len(months)),
+		weekdays: make(map[string]uint, len(weekdays)),
+	}
+	for name, v := range months {
+		if name == "" || v < 1 || v > 12 {
+			return l, fmt.Errorf("bad month %q: %d", name, v)
+		}
+		l.months[strings.ToLower(name)] = v
+	}
+	for name, v := range weekdays {
+		if name == "" || v > 6 {
+			return l, fmt.Errorf("bad weekday %q: %d", name, v)
+		}
+		l.weekdays[strings.ToLower(name)] = v
+	}
+	return l, nil
+}
+
+// WithNames returns a copy of the parser that accepts the month and weekday
+// names of the language given by the BCP 47 tag, as well as those it already
+// accepts. See WithNameTable.
+func (p Parser) WithNames(tag string) (Parser, error) {
+	l := lookupLocale(tag)
+	if l == nil {
+		return p, fmt.Errorf("unknown locale: %s", tag)
+	}
+	return p.WithNameTable(l.months, l.weekdays)
+}
+
+// WithNameTable returns a copy of the parser that accepts the given month and
+// weekday names as well as those it already accepts. Months are numbered from
+// 1 and weekdays from 0 (Sunday), and a weekday name followed by L means the
+// last such weekday of the month, like "monl". It returns an error if a name
+// would change the meaning of one the parser already accepts.
+func (p Parser) WithNameTable(months, weekdays map[string]uint) (Parser, error) {
+	l, err := newLocale(months, weekdays)
+	if err != nil {
+		return p, err
+	}
+	if p.names != nil {
+		if err := mergeNames("month", l.months, p.names.months); err != nil {
+			return p, err
+		}
+		if err := mergeNames("weekday", l.weekdays, p.names.weekdays); err != nil {
+			return p, err
+		}
+	}
+	if err := l.check(); err != nil {
+		return p, err
+	}
+	p.names = &l
+	return p, nil
+}
+
+// check returns an error if any of the names would clash with a number, an
+// English name, or another of the names, including their L forms.
+func (l *locale) check() error {
+	m := make(map[string]uint)
+	if err := mergeNames("month", m, months.names); err != nil {
+		return err
+	}
+	if err := mergeNames("month", m, l.months); err != nil {
+		return err
+	}
+	w := make(map[string]uint)
+	if err := mergeNames("weekday", w, dow.names); err != nil {
+		return err
+	}
+	if err := mergeNames("weekday", w, l.weekdays); err != nil {
+		return err
+	}
+	return mergeNames("weekday", w, lastWeekdays(l.weekdays))
+}
+
+// mergeNames adds the names in src to dst, returning an error if a name is a
+// number or is already in dst with a different value.
+func mergeNames(field string, dst, src map[string]uint) error {
+	for name, v := range src {
+		if isNumber(name) {
+			return fmt.Errorf("%s name %q is a number", field, name)
+		}
+		if old, ok := dst[name]; ok && old != v {
+			return fmt.Errorf("%s name %q clashes with an existing name", field, name)
+		}
+		dst[name] = v
+	}
+	return nil
+}
+
+// lastWeekdays returns the L forms of the weekday names, e.g. "lundil" for the
+// last Monday of the month.
+func lastWeekdays(weekdays map[string]uint) map[string]uint {
+	last := make(map[string]uint, len(weekdays))
+	for name, v := range weekdays {
+		last[name+"l"] = 49 + v
+	}
+	return last
+}
+
+// lookupLocale returns the names for the given tag, falling back from a
+// regional tag ("fr-CA") to its language ("fr"). It returns nil if neither
+// is known.
+func lookupLocale(tag string) *locale {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	tag = strings.ToLower(tag)
+	for {
+		if l, ok := locales[tag]; ok {
+			return &l
+		}
+		i := strings.LastIndexAny(tag, "-_")
+		if i < 0 {
+			return nil
+		}
+		tag = tag[:i]
+	}
+}
+
+// bounds returns b with the locale's names added, if the place is one that
+// has names. Weekday names are converted if the week starts on Monday.
+func (l *locale) bounds(place ParseOption, b bounds, monday bool) bounds {
+	var extra map[string]uint
+	switch place {
+	case Month:
+		extra = l.months
+	case Dow:
+		extra = l.weekdays
+	default:
+		return b
+	}
+	names := make(map[string]uint, len(b.names)+len(extra))
+	for name, v := range b.names {
+		names[name] = v
+	}
+	add := func(extra map[string]uint) {
+		for name, v := range extra {
+			if place == Dow && monday {
+				v = mondayDay(v)
+			}
+			names[name] = v
+		}
+	}
+	add(extra)
+	if place == Dow {
+		add(lastWeekdays(extra))
+	}
+	b.names = names
+	return b
+}
+
+// ParseWithLocale parses a standard 5-field spec, accepting month and weekday
+// names in the language given by the BCP 47 tag as well as in English, e.g.
+// "0 9 * * lun-ven" in "fr". Unknown languages fall back to English.
+func ParseWithLocale(spec string, tag string) (*SpecSchedule, error) {
+	expr, err := standardParser.expression(spec)
+	if err != nil {
+		return nil, err
+	}
+	if expr.fields == nil {
+		return nil, fmt.Errorf("interval is not a cron expression: %s", spec)
+	}
+	expr.locale = lookupLocale(tag)
+
+	loc := expr.loc
+	if loc == nil {
+		loc = time.Local
+	}
+	sched, errs := expr.compile(loc, false)
+	if len(errs) > 0 {
+		return nil, errs[0].Err
+	}
+	return sched, nil
+}
@@ -0,0 +1,168 @@
+package cron
+
+import "testing"
+
+func TestParseWithLocale(t *testing.T) {
+	weekdays, err := ParseWithLocale("0 9 * * mon-fri", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		spec, locale string
+	}{
+		{"0 9 * * lun-ven", "fr"},
+		{"0 9 * * LUNDI-Vendredi", "fr-CA"},
+		{"0 9 * * mo-fr", "de"},
+		{"0 9 * * lunes-viernes", "es"},
+		{"0 9 * * 月-金", "ja"},
+		{"0 9 * * mon-fri", "fr"},
+		{"0 9 * * mon-fri", "xx"},
+		{"0 9 * * mon-fri", ""},
+	}
+	for _, c := range tests {
+		sched, err := ParseWithLocale(c.spec, c.locale)
+		if err != nil {
+			t.Errorf("%s in %q: %v", c.spec, c.locale, err)
+			continue
+		}
+		if sched.Dow.Cmp(weekdays.Dow) != 0 {
+			t.Errorf("%s in %q: expected Monday to Friday, got %s", c.spec, c.locale, sched.Dow.Text(2))
+		}
+	}
+
+	march, _ := ParseWithLocale("0 9 * mar *", "")
+	if sched, err := ParseWithLocale("0 9 * MÄRZ *", "de"); err != nil || sched.Month.Cmp(march.Month) != 0 {
+		t.Errorf("expected March, got %v, %v", sched, err)
+	}
+	if _, err := ParseWithLocale("0 9 * * lun-ven", "en"); err == nil {
+		t.Error("expected French names to be rejected in English")
+	}
+	if _, err := ParseWithLocale("@every 1h", "fr"); err == nil {
+		t.Error("expected an interval to be rejected")
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	if err := RegisterLocale("x-test", map[string]uint{"Primo": 1}, map[string]uint{"Uno": 1, "Cinque": 5}); err != nil {
+		t.Fatal(err)
+	}
+	sched, err := ParseWithLocale("0 9 * primo uno-cinque", "x-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := ParseWithLocale("0 9 * jan mon-fri", "")
+	if sched.Month.Cmp(expected.Month) != 0 || sched.Dow.Cmp(expected.Dow) != 0 {
+		t.Errorf("expected January, Monday to Friday, got %s and %s", sched.Month.Text(2), sched.Dow.Text(2))
+	}
+
+	if err := RegisterLocale("x-bad", map[string]uint{"zero": 0}, nil); err == nil {
+		t.Error("expected month 0 to be rejected")
+	}
+	if err := RegisterLocale("x-bad", nil, map[string]uint{"seven": 7}); err == nil {
+		t.Error("expected weekday 7 to be rejected")
+	}
+	if err := RegisterLocale("", nil, nil); err == nil {
+		t.Error("expected an empty tag to be rejected")
+	}
+}
+
+func TestWithNames(t *testing.T) {
+	fr, err := standardParser.WithNames("fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	de, err := NewParser(Second | Minute | Hour | Dom | Month | Dow).WithNames("de")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		parser   Parser
+		spec     string
+		expected string
+	}{
+		{fr, "0 9 * * lun-ven", "0 9 * * mon-fri"},
+		{fr, "0 9 * * lundi-vendredi/2", "0 9 * * mon-fri/2"},
+		{fr, "0 9 * * dim,mer", "0 9 * * sun,wed"},
+		{fr, "0 9 * * venl", "0 9 * * fril"},
+		{fr, "0 9 * juin-août *", "0 9 * jun-aug *"},
+		{fr, "0 9 * janvier-décembre/3 *", "0 9 * jan-dec/3 *"},
+		{fr, "0 9 * * mon-fri", "0 9 * * mon-fri"},
+		{de, "0 0 9 * * montag-freitag", "0 0 9 * * mon-fri"},
+		{de, "0 0 9 * * mo-fr/2", "0 0 9 * * mon-fri/2"},
+		{de, "0 0 9 * * sol", "0 0 9 * * sunl"},
+		{de, "0 0 9 * märz-mai *", "0 0 9 * mar-may *"},
+		{de, "0 0 9 * januar-dezember/6 *", "0 0 9 * jan-dec/6 *"},
+	}
+	for _, c := range tests {
+		got, err := c.parser.Parse(c.spec)
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		expected, err := c.parser.Parse(c.expected)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.(*SpecSchedule).Equal(expected.(*SpecSchedule)) {
+			t.Errorf("%s: got %v, expected %s", c.spec, got.(*SpecSchedule).Diff(expected.(*SpecSchedule)), c.expected)
+		}
+	}
+
+	if _, err := standardParser.Parse("0 9 * * lun-ven"); err == nil {
+		t.Error("expected the standard parser to be unchanged")
+	}
+	if _, err := fr.Parse("0 9 * * montag"); err == nil {
+		t.Error("expected German names to be rejected in French")
+	}
+
+	both, err := fr.WithNames("de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := both.Parse("0 9 * * lundi,dienstag"); err != nil {
+		t.Error(err)
+	}
+
+	weekStartsMonday, err := NewParser(Minute | Hour | Dom | Month | Dow | WeekStartsMonday).WithNames("fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := weekStartsMonday.Parse("0 9 * * lun-ven")
+	expected, _ := standardParser.Parse("0 9 * * mon-fri")
+	if !got.(*SpecSchedule).Equal(expected.(*SpecSchedule)) {
+		t.Errorf("week starting Monday: %v", got.(*SpecSchedule).Diff(expected.(*SpecSchedule)))
+	}
+}
+
+func TestWithNameTableClashes(t *testing.T) {
+	tests := []struct {
+		name             string
+		months, weekdays map[string]uint
+	}{
+		{"English month", map[string]uint{"jan": 2}, nil},
+		{"English weekday", nil, map[string]uint{"Mon": 2}},
+		{"English L form", nil, map[string]uint{"fril": 5}},
+		{"L form", nil, map[string]uint{"so": 0, "sol": 6}},
+		{"number", map[string]uint{"13": 1}, nil},
+		{"bad month", map[string]uint{"zero": 0}, nil},
+		{"bad weekday", nil, map[string]uint{"seven": 7}},
+	}
+	for _, c := range tests {
+		if _, err := standardParser.WithNameTable(c.months, c.weekdays); err == nil {
+			t.Errorf("%s: expected an error", c.name)
+		}
+	}
+
+	if _, err := standardParser.WithNameTable(map[string]uint{"April": 4}, map[string]uint{"monday": 1}); err != nil {
+		t.Errorf("expected names matching English to be accepted: %v", err)
+	}
+	fr, _ := standardParser.WithNames("fr")
+	if _, err := fr.WithNameTable(nil, map[string]uint{"lun": 5}); err == nil {
+		t.Error("expected a clash with a French name")
+	}
+	if _, err := standardParser.WithNames("xx"); err == nil {
+		t.Error("expected an unknown locale to be rejected")
+	}
+}
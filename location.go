@@ -0,0 +1,149 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Location is a time zone that is serialized by name together with its
+// standard offset from UTC, so that it can be restored on a system whose time
+// zone database lacks the name: it then falls back to a fixed zone of that
+// name and offset, which is right except while daylight saving time is in
+// effect. As text it reads "Europe/Berlin +01:00", and as JSON
+// {"name":"Europe/Berlin","offset":3600}, the offset in seconds. A nil
+// Location is UTC.
+type Location struct {
+	*time.Location
+}
+
+// locationJSON is the JSON form of a Location.
+type locationJSON struct {
+	Name   string `json:"name"`
+	Offset int    `json:"offset"`
+}
+
+// standardOffset returns the offset of the location from UTC in standard
+// time this year: the smaller of its offsets in January and July, as daylight
+// saving time moves clocks forward.
+func standardOffset(loc *time.Location) int {
+	year := time.Now().Year()
+	_, jan := time.Date(year, time.January, 1, 0, 0, 0, 0, loc).Zone()
+	_, jul := time.Date(year, time.July, 1, 0, 0, 0, 0, loc).Zone()
+	if jul < jan {
+		return jul
+	}
+	return jan
+}
+
+// MarshalText encodes the location as its name and standard offset.
+func (l Location) MarshalText() ([]byte, error) {
+	loc := l.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	return []byte(loc.String() + " " + formatOffset(standardOffset(loc))), nil
+}
+
+// UnmarshalText decodes a location encoded by MarshalText. A name alone is
+// accepted too, but then there is nothing to fall back on if it can't be
+// loaded.
+func (l *Location) UnmarshalText(text []byte) error {
+	name, offset := string(text), ""
+	if i := strings.LastIndexByte(name, ' '); i >= 0 {
+		name, offset = name[:i], name[i+1:]
+	}
+	if offset == "" {
+		loc, err := loadLocation(name)
+		if err != nil {
+			return err
+		}
+		l.Location = loc
+		return nil
+	}
+	secs, err := parseOffset(offset)
+	if err != nil {
+		return fmt.Errorf("location %q: %w", text, err)
+	}
+	l.Location = loadOrFixed(name, secs)
+	return nil
+}
+
+// MarshalJSON encodes the location as an object with its name and standard
+// offset in seconds.
+func (l Location) MarshalJSON() ([]byte, error) {
+	loc := l.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	return json.Marshal(locationJSON{Name: loc.String(), Offset: standardOffset(loc)})
+}
+
+// UnmarshalJSON decodes a location encoded by MarshalJSON, or a string in the
+// form UnmarshalText accepts.
+func (l *Location) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		return l.UnmarshalText([]byte(text))
+	}
+	var v locationJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Name == "" {
+		return fmt.Errorf("location has no name")
+	}
+	l.Location = loadOrFixed(v.Name, v.Offset)
+	return nil
+}
+
+// loadOrFixed loads the location by name, or returns a fixed zone of that name
+// and offset if it can't be loaded.
+func loadOrFixed(name string, offset int) *time.Location {
+	if loc, err := loadLocation(name); err == nil {
+		return loc
+	}
+	return time.FixedZone(name, offset)
+}
+
+// formatOffset formats an offset in seconds as ±hh:mm, or ±hh:mm:ss if it
+// isn't a whole number of minutes.
+func formatOffset(secs int) string {
+	sign := '+'
+	if secs < 0 {
+		sign, secs = '-', -secs
+	}
+	s := fmt.Sprintf("%c%02d:%02d", sign, secs/3600, secs/60%60)
+	if secs%60 != 0 {
+		s += fmt.Sprintf(":%02d", secs%60)
+	}
+	return s
+}
+
+// parseOffset parses an offset formatted by formatOffset into seconds.
+func parseOffset(s string) (int, error) {
+	if len(s) < 2 || s[0] != '+' && s[0] != '-' {
+		return 0, fmt.Errorf("offset %q must start with + or -", s)
+	}
+	parts := strings.Split(s[1:], ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("offset %q is not of the form ±hh:mm", s)
+	}
+	secs := 0
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || len(part) != 2 || i > 0 && n > 59 {
+			return 0, fmt.Errorf("offset %q is not of the form ±hh:mm", s)
+		}
+		secs = secs*60 + n
+	}
+	if len(parts) == 2 {
+		secs *= 60
+	}
+	if s[0] == '-' {
+		secs = -secs
+	}
+	return secs, nil
+}
@@ -0,0 +1,95 @@
+package cron
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLocationRoundTrip(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		loc        *time.Location
+		text, json string
+	}{
+		{kolkata, "Asia/Kolkata +05:30", `{"name":"Asia/Kolkata","offset":19800}`},
+		{berlin, "Europe/Berlin +01:00", `{"name":"Europe/Berlin","offset":3600}`},
+		{nil, "UTC +00:00", `{"name":"UTC","offset":0}`},
+		{time.FixedZone("EST", -5*3600), "EST -05:00", `{"name":"EST","offset":-18000}`},
+	}
+	for _, c := range tests {
+		text, err := Location{c.loc}.MarshalText()
+		if err != nil || string(text) != c.text {
+			t.Errorf("expected %q, got %q, %v", c.text, text, err)
+		}
+		data, err := json.Marshal(Location{c.loc})
+		if err != nil || string(data) != c.json {
+			t.Errorf("expected %s, got %s, %v", c.json, data, err)
+		}
+
+		var fromText, fromJSON Location
+		if err := fromText.UnmarshalText(text); err != nil {
+			t.Error(err)
+		}
+		if err := json.Unmarshal(data, &fromJSON); err != nil {
+			t.Error(err)
+		}
+		expected := Location{c.loc}.String()
+		if fromText.String() != expected || fromJSON.String() != expected {
+			t.Errorf("expected %s back, got %s and %s", expected, fromText, fromJSON)
+		}
+	}
+}
+
+func TestLocationFallback(t *testing.T) {
+	defer func(load func(string) (*time.Location, error)) { loadLocation = load }(loadLocation)
+	loadLocation = func(name string) (*time.Location, error) {
+		if name == "Europe/Berlin" {
+			return nil, errors.New("unknown time zone " + name)
+		}
+		return time.LoadLocation(name)
+	}
+
+	var fromText, fromJSON Location
+	if err := fromText.UnmarshalText([]byte("Europe/Berlin +01:00")); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"name":"Europe/Berlin","offset":3600}`), &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	winter := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	for _, loc := range []Location{fromText, fromJSON} {
+		if name, offset := winter.In(loc.Location).Zone(); name != "Europe/Berlin" || offset != 3600 {
+			t.Errorf("expected a fixed zone named Europe/Berlin at +01:00, got %s %d", name, offset)
+		}
+		// Schedules in the zone still work, but for daylight saving time.
+		sched := mustParse(t, "0 9 * * *").(*SpecSchedule)
+		sched.Location = loc.Location
+		if next := sched.Next(winter); !next.Equal(time.Date(2024, 1, 16, 8, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected 09:00 at +01:00, got %v", next)
+		}
+		text, _ := loc.MarshalText()
+		if string(text) != "Europe/Berlin +01:00" {
+			t.Errorf("expected the fallback to round-trip, got %q", text)
+		}
+	}
+
+	// Without an offset there is nothing to fall back on.
+	var bare Location
+	if err := bare.UnmarshalText([]byte("Europe/Berlin")); err == nil {
+		t.Error("expected an error for a name that can't be loaded")
+	}
+	for _, bad := range []string{"Europe/Berlin 01:00", "Europe/Berlin +1:00", "Europe/Berlin +01:60"} {
+		if err := bare.UnmarshalText([]byte(bad)); err == nil {
+			t.Errorf("%q: expected an error", bad)
+		}
+	}
+}
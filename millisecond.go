@@ -0,0 +1,71 @@
+package cron
+
+import (
+	"math/big"
+	"time"
+)
+
+// nextMillisecond is Next for a schedule with a milliseconds field. It finds
+// the seconds at which the schedule runs with nextSecond, and then the
+// milliseconds within them.
+func (s *SpecSchedule) nextMillisecond(t time.Time) time.Time {
+	first, ok := firstMillisecond(s.Millisecond, 0)
+	if !ok {
+		return time.Time{}
+	}
+
+	// The rest of the current second, if it is one the schedule runs at.
+	t = t.Truncate(time.Millisecond).Add(time.Millisecond)
+	second := t.Truncate(time.Second)
+	if ms, ok := firstMillisecond(s.Millisecond, int(t.Sub(second)/time.Millisecond)); ok &&
+		s.nextSecond(second.Add(-time.Nanosecond)).Equal(second) {
+		return second.Add(time.Duration(ms) * time.Millisecond)
+	}
+
+	next := s.nextSecond(second)
+	if next.IsZero() {
+		return next
+	}
+	return next.Add(time.Duration(first) * time.Millisecond)
+}
+
+// latestMillisecond is Latest for a schedule with a milliseconds field.
+func (s *SpecSchedule) latestMillisecond(t time.Time) time.Time {
+	last, ok := lastMillisecond(s.Millisecond, int(milliseconds.max))
+	if !ok {
+		return time.Time{}
+	}
+
+	t = t.Truncate(time.Millisecond)
+	second := t.Truncate(time.Second)
+	if ms, ok := lastMillisecond(s.Millisecond, int(t.Sub(second)/time.Millisecond)); ok &&
+		s.latestSecond(second).Equal(second) {
+		return second.Add(time.Duration(ms) * time.Millisecond)
+	}
+
+	prev := s.latestSecond(second.Add(-time.Nanosecond))
+	if prev.IsZero() {
+		return prev
+	}
+	return prev.Add(time.Duration(last) * time.Millisecond)
+}
+
+// firstMillisecond returns the first millisecond at or after from that is set.
+func firstMillisecond(bits *big.Int, from int) (int, bool) {
+	for v := from; v <= int(milliseconds.max); v++ {
+		if bits.Bit(v) > 0 {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// lastMillisecond returns the last millisecond at or before from that is set.
+func lastMillisecond(bits *big.Int, from int) (int, bool) {
+	for v := from; v >= int(milliseconds.min); v-- {
+		if bits.Bit(v) > 0 {
+			return v, true
+		}
+	}
+	return 0, false
+}
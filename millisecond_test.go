@@ -0,0 +1,114 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var millisecondParser = NewParser(Millisecond | Second | Minute | Hour | Dom | Month | Dow)
+
+func TestMillisecondNext(t *testing.T) {
+	at := func(minute, second, ms int) time.Time {
+		return time.Date(2024, 3, 1, 10, minute, second, ms*int(time.Millisecond), time.UTC)
+	}
+	tests := []struct {
+		spec           string
+		time, expected time.Time
+	}{
+		{"*/250 * * * * * *", at(0, 0, 100), at(0, 0, 250)},
+		{"*/250 * * * * * *", at(0, 0, 250), at(0, 0, 500)},
+		{"*/250 * * * * * *", at(0, 0, 249).Add(999 * time.Microsecond), at(0, 0, 250)},
+		{"*/250 * * * * * *", at(0, 0, 750), at(0, 1, 0)},
+		{"*/250 * * * * * *", at(0, 59, 900), at(1, 0, 0)},
+
+		// Wrapping past the last millisecond carries into the seconds.
+		{"*/250 59 * * * * *", at(0, 58, 999), at(0, 59, 0)},
+		{"*/250 59 * * * * *", at(0, 59, 750), at(1, 59, 0)},
+		{"*/250 59 * * * * *", at(0, 30, 0), at(0, 59, 0)},
+		{"999 * * * * * *", at(0, 0, 999), at(0, 1, 999)},
+		{"500-510/5 0 * * * * *", at(0, 0, 506), at(0, 0, 510)},
+		{"500-510/5 0 * * * * *", at(0, 0, 510), at(1, 0, 500)},
+		{"0 * * * * * *", at(0, 0, 0), at(0, 1, 0)},
+	}
+	for _, c := range tests {
+		sched, err := millisecondParser.Parse("TZ=UTC " + c.spec)
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		if actual := sched.Next(c.time); !actual.Equal(c.expected) {
+			t.Errorf("%s, %v: expected %v, got %v", c.spec, c.time, c.expected, actual)
+		}
+	}
+}
+
+func TestMillisecondLatest(t *testing.T) {
+	at := func(minute, second, ms int) time.Time {
+		return time.Date(2024, 3, 1, 10, minute, second, ms*int(time.Millisecond), time.UTC)
+	}
+	tests := []struct {
+		spec           string
+		time, expected time.Time
+	}{
+		{"*/250 * * * * * *", at(0, 1, 100), at(0, 1, 0)},
+		{"*/250 * * * * * *", at(0, 1, 250), at(0, 1, 250)},
+		{"*/250 * * * * * *", at(0, 1, 999), at(0, 1, 750)},
+		{"500 0 * * * * *", at(0, 0, 400), at(-1, 0, 500)},
+		{"500 0 * * * * *", at(0, 30, 0), at(0, 0, 500)},
+		{"250,750 59 * * * * *", at(1, 0, 0), at(0, 59, 750)},
+	}
+	for _, c := range tests {
+		sched, err := millisecondParser.Parse("TZ=UTC " + c.spec)
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		if actual := sched.(*SpecSchedule).Latest(c.time); !actual.Equal(c.expected) {
+			t.Errorf("%s, %v: expected %v, got %v", c.spec, c.time, c.expected, actual)
+		}
+	}
+}
+
+func TestMillisecondField(t *testing.T) {
+	sched, err := millisecondParser.Parse("TZ=UTC */250 0 30 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sched.(*SpecSchedule)
+	if expected := "TZ=UTC */250 0 30 9 * * * *"; s.String() != expected {
+		t.Errorf("expected %s, got %s", expected, s.String())
+	}
+	if plain := mustParse(t, "TZ=UTC 30 9 * * *").(*SpecSchedule); s.Equal(plain) {
+		t.Error("expected milliseconds to be compared")
+	}
+
+	// Millisecond 0 is the same as none.
+	zero, _ := millisecondParser.Parse("TZ=UTC 0 0 30 9 * * *")
+	plain := mustParse(t, "TZ=UTC 30 9 * * *").(*SpecSchedule)
+	if !zero.(*SpecSchedule).Equal(plain) {
+		t.Errorf("expected millisecond 0 to equal none: %v", zero.(*SpecSchedule).Diff(plain))
+	}
+	if plain.Millisecond != nil {
+		t.Error("expected no milliseconds without the option")
+	}
+
+	sched, _ = NewParser(Millisecond | Second | Minute | Hour | Dom | Month | Dow | Descriptor).Parse("@hourly")
+	if sched.(*SpecSchedule).Millisecond != nil {
+		t.Error("expected descriptors to have no milliseconds")
+	}
+
+	for _, spec := range []string{"1000 * * * * * *", "*/0 * * * * * *", "* * * * * *", "x * * * * * *"} {
+		if _, err := millisecondParser.Parse(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+	errs := millisecondParser.Validate("1000 60 * * * * *")
+	if len(errs) != 2 || errs[0].Field != "millisecond" || errs[1].Field != "second" {
+		t.Errorf("expected millisecond and second errors, got %v", errs)
+	}
+	if _, err := ParseExpression("1000 * * * * * *", Millisecond|Second|Minute|Hour|Dom|Month|Dow); err == nil ||
+		!strings.Contains(err.Error(), "above maximum") {
+		t.Errorf("expected the milliseconds to be checked, got %v", err)
+	}
+}
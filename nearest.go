@@ -0,0 +1,30 @@
+package cron
+
+import "time"
+
+// Nearest returns the activation closest to t, before or after it: the closer
+// of Latest(t) and Next(t), or the earlier of the two if they are equally
+// close. If there is only one of them, it is returned, and if there is
+// neither, the zero time is.
+func (s *SpecSchedule) Nearest(t time.Time) time.Time {
+	latest, next := s.Latest(t), s.Next(t)
+	switch {
+	case latest.IsZero():
+		return next
+	case next.IsZero():
+		return latest
+	case next.Sub(t) < t.Sub(latest):
+		return next
+	}
+	return latest
+}
+
+// NearestWithin is like Nearest, but returns the zero time if the nearest
+// activation is more than d from t.
+func (s *SpecSchedule) NearestWithin(t time.Time, d time.Duration) time.Time {
+	nearest := s.Nearest(t)
+	if nearest.IsZero() || nearest.Sub(t) > d || t.Sub(nearest) > d {
+		return time.Time{}
+	}
+	return nearest
+}
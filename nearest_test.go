@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNearest(t *testing.T) {
+	sched := mustParse(t, "TZ=UTC 0 9,17 * * *").(*SpecSchedule)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 5, 1, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		time, expected time.Time
+	}{
+		{at(9, 0), at(9, 0)},
+		{at(10, 0), at(9, 0)},
+		{at(16, 0), at(17, 0)},
+		{at(13, 0), at(9, 0)}, // a tie goes to the earlier
+		{at(13, 0).Add(time.Second), at(17, 0)},
+		{at(1, 0), at(-7, 0)},
+		{at(12, 59).Add(59*time.Second + 500*time.Millisecond), at(9, 0)},
+	}
+	for _, c := range tests {
+		if actual := sched.Nearest(c.time); !actual.Equal(c.expected) {
+			t.Errorf("%v: expected %v, got %v", c.time, c.expected, actual)
+		}
+	}
+
+	// When one side is exhausted, the other is returned.
+	only, err := quartzParser.Parse("TZ=UTC 0 0 0 1 1 ? 2030")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newYear := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tm := range []time.Time{newYear.AddDate(0, -6, 0), newYear.AddDate(0, 6, 0)} {
+		if actual := only.(*SpecSchedule).Nearest(tm); !actual.Equal(newYear) {
+			t.Errorf("%v: expected %v, got %v", tm, newYear, actual)
+		}
+	}
+	never := mustParse(t, "0 0 30 2 *").(*SpecSchedule)
+	if actual := never.Nearest(at(9, 0)); !actual.IsZero() {
+		t.Errorf("expected the zero time, got %v", actual)
+	}
+}
+
+func TestNearestWithin(t *testing.T) {
+	sched := mustParse(t, "TZ=UTC 0 * * * *").(*SpecSchedule)
+	hour := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		time     time.Time
+		d        time.Duration
+		expected time.Time
+	}{
+		{hour.Add(5 * time.Minute), 5 * time.Minute, hour},
+		{hour.Add(5 * time.Minute), 4 * time.Minute, time.Time{}},
+		{hour.Add(-5 * time.Minute), 5 * time.Minute, hour},
+		{hour.Add(-5 * time.Minute), time.Minute, time.Time{}},
+		{hour, 0, hour},
+	}
+	for _, c := range tests {
+		if actual := sched.NearestWithin(c.time, c.d); !actual.Equal(c.expected) {
+			t.Errorf("%v within %v: expected %v, got %v", c.time, c.d, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package cron
+
+import "time"
+
+// daysPerMonth is the average length of a month.
+const daysPerMonth = 365.25 / 12
+
+// OccupancyReport describes when during the day and week a schedule
+// activates, for drawing heat maps. Counts are for a single day (or hour) on
+// which the schedule runs; Share says how often that is.
+type OccupancyReport struct {
+	// Hours counts the activations in each hour of a day, by weekday.
+	Hours [7][24]int
+
+	// Days counts the activations on a day, by weekday.
+	Days [7]int
+
+	// Minutes counts the activations at each minute of an hour that the
+	// schedule runs in.
+	Minutes [60]int
+
+	// Share estimates the fraction of days of each weekday that the schedule
+	// runs on: 1 for every Monday, and about 0.03 for the 1st of the month.
+	Share [7]float64
+}
+
+// Occupancy returns the schedule's OccupancyReport, computed from its fields
+// without finding any activations, so it takes the same time for any
+// schedule.
+//
+// A day of the month is taken to fall on each weekday equally often, so the
+// Share of each weekday is only exact when the schedule runs on every day of
+// the month. Days clamped by ClampDom are ignored, as are the month, year and
+// week of the year fields, and days that daylight saving time shortens or
+// lengthens.
+func (s *SpecSchedule) Occupancy() OccupancyReport {
+	var report OccupancyReport
+	perMinute := countBits(s.Second, seconds) * countBits(s.milliseconds(), milliseconds)
+	for m := 0; m < 60; m++ {
+		report.Minutes[m] = int(s.Minute.Bit(m)) * perMinute
+	}
+	perHour := countBits(s.Minute, minutes) * perMinute
+
+	// The share of days matching each day field, as in dayMatches.
+	domShare := 1.0
+	if !isAll(s.Dom, dom) {
+		days := countBits(s.Dom, dom)
+		for bit := 48; bit <= 55; bit++ {
+			days += int(s.Dom.Bit(bit))
+		}
+		for v := 1; v <= 31; v++ {
+			days += int(s.Dom.Bit(domWeekdayBit(v)))
+		}
+		days += int(s.Dom.Bit(lastWeekdayBit))
+		if domShare = float64(days) / daysPerMonth; domShare > 1 {
+			domShare = 1
+		}
+	}
+	both := s.bothDays()
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		var dowShare float64
+		switch {
+		case s.Dow.Bit(int(d)) > 0:
+			dowShare = 1
+		default: // the last, or nth, such weekdays of the month
+			n := s.Dow.Bit(49 + int(d))
+			for nth := 1; nth <= 5; nth++ {
+				n += s.Dow.Bit(nthWeekdayBit(nth, int(d)))
+			}
+			if dowShare = float64(n) * 7 / daysPerMonth; dowShare > 1 {
+				dowShare = 1
+			}
+		}
+		if both {
+			report.Share[d] = domShare * dowShare
+		} else {
+			report.Share[d] = domShare + dowShare - domShare*dowShare
+		}
+		if report.Share[d] == 0 {
+			continue
+		}
+		for h := 0; h < 24; h++ {
+			report.Hours[d][h] = int(s.Hour.Bit(h)) * perHour
+			report.Days[d] += report.Hours[d][h]
+		}
+	}
+	return report
+}
@@ -0,0 +1,87 @@
+package cron
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestOccupancy(t *testing.T) {
+	const monthly = 12 / 365.25
+	tests := []struct {
+		spec    string
+		hours   map[int]int // the activations in each hour the schedule runs in
+		minutes map[int]int // the activations at each minute it runs at
+		share   [7]float64  // by weekday
+	}{
+		{"*/15 * * * *", hoursFrom(0, 23, 4), map[int]int{0: 1, 15: 1, 30: 1, 45: 1}, [7]float64{1, 1, 1, 1, 1, 1, 1}},
+		{"30 9-17 * * MON-FRI", hoursFrom(9, 17, 1), map[int]int{30: 1}, [7]float64{0, 1, 1, 1, 1, 1, 0}},
+		{"0 0 1 * *", hoursFrom(0, 0, 1), map[int]int{0: 1}, [7]float64{monthly, monthly, monthly, monthly, monthly, monthly, monthly}},
+		{"0 0 1 * MON", hoursFrom(0, 0, 1), map[int]int{0: 1}, [7]float64{monthly, 1, monthly, monthly, monthly, monthly, monthly}},
+		{"0 0 * * 5L", hoursFrom(0, 0, 1), map[int]int{0: 1}, [7]float64{0, 0, 0, 0, 0, 7 * monthly, 0}},
+		{"0,30 12 ? * SAT,SUN", hoursFrom(12, 12, 2), map[int]int{0: 1, 30: 1}, [7]float64{1, 0, 0, 0, 0, 0, 1}},
+	}
+	for _, c := range tests {
+		report := mustParse(t, c.spec).(*SpecSchedule).Occupancy()
+		checkOccupancy(t, c.spec, report, c.hours, c.minutes, c.share)
+	}
+
+	sched, err := secondParser.Parse("*/20 * 8 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	minutes := make(map[int]int)
+	for m := 0; m < 60; m++ {
+		minutes[m] = 3
+	}
+	checkOccupancy(t, "*/20 * 8 * * *", sched.(*SpecSchedule).Occupancy(), hoursFrom(8, 8, 180), minutes,
+		[7]float64{1, 1, 1, 1, 1, 1, 1})
+
+	sched, err = millisecondParser.Parse("0,500 0 30 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOccupancy(t, "0,500 0 30 9 * * *", sched.(*SpecSchedule).Occupancy(), hoursFrom(9, 9, 2), map[int]int{30: 2},
+		[7]float64{1, 1, 1, 1, 1, 1, 1})
+}
+
+// checkOccupancy checks the report against the activations in each hour and
+// minute that a schedule runs in, and the share of each weekday it runs on.
+func checkOccupancy(t *testing.T, spec string, report OccupancyReport, hours, minutes map[int]int, share [7]float64) {
+	t.Helper()
+	perDay := 0
+	for _, n := range hours {
+		perDay += n
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if math.Abs(report.Share[d]-share[d]) > 1e-9 {
+			t.Errorf("%s: expected a share of %v on %v, got %v", spec, share[d], d, report.Share[d])
+		}
+		expected := perDay
+		if share[d] == 0 {
+			expected = 0
+		}
+		if report.Days[d] != expected {
+			t.Errorf("%s: expected %d on %v, got %d", spec, expected, d, report.Days[d])
+		}
+		for h := 0; h < 24; h++ {
+			if expected := hours[h]; share[d] > 0 && report.Hours[d][h] != expected {
+				t.Errorf("%s: expected %d at %v %02d:00, got %d", spec, expected, d, h, report.Hours[d][h])
+			}
+		}
+	}
+	for m := 0; m < 60; m++ {
+		if report.Minutes[m] != minutes[m] {
+			t.Errorf("%s: expected %d at minute %d, got %d", spec, minutes[m], m, report.Minutes[m])
+		}
+	}
+}
+
+// hoursFrom returns n activations in each hour from first to last.
+func hoursFrom(first, last, n int) map[int]int {
+	hours := make(map[int]int)
+	for h := first; h <= last; h++ {
+		hours[h] = n
+	}
+	return hours
+}
@@ -37,9 +37,175 @@ func WithChain(wrappers ...JobWrapper) Option {
 	}
 }
 
+// WithEntryWrappers specifies wrappers to apply to all jobs added to this
+// cron that need to know which entry they are wrapping. They are applied
+// inside the chain given by WithChain.
+func WithEntryWrappers(wrappers ...EntryJobWrapper) Option {
+	return func(c *Cron) {
+		c.entryWrappers = wrappers
+	}
+}
+
+// WithMaxConcurrency limits the number of jobs that may run at once. Jobs
+// that are due while the limit is reached wait for a running job to finish.
+func WithMaxConcurrency(n int) Option {
+	return func(c *Cron) {
+		c.maxConcurrency = n
+	}
+}
+
+// WithQueueSize limits the number of jobs that may wait to run under
+// WithMaxConcurrency, or the MaxConcurrent limit of WithTenancy, to n, with
+// the given policy for jobs beyond it. Dropped jobs are reported to the
+// OnDropped hook. Without such limits no job waits, and the queue size has no
+// effect.
+func WithQueueSize(n int, policy OverflowPolicy) Option {
+	return func(c *Cron) {
+		c.queueSize = n
+		c.overflow = policy
+	}
+}
+
+// WithTenancy divides the cron's entries between tenants, enforcing the
+// given quotas. Jobs waiting to run under WithMaxConcurrency are started
+// round-robin between tenants.
+func WithTenancy(t Tenancy) Option {
+	return func(c *Cron) {
+		c.tenancy = &t
+	}
+}
+
+// WithEntryHooks calls the given hooks as entries are added, changed, paused,
+// resumed and removed.
+func WithEntryHooks(h EntryHooks) Option {
+	return func(c *Cron) {
+		c.hooks = h
+	}
+}
+
+// WithHealthThresholds sets how many failures in a row make Health report an
+// entry as failing, and how many activations in a row finding its job still
+// running make it report the entry as overrunning. The defaults are 3 and 3.
+func WithHealthThresholds(failures, overruns int) Option {
+	return func(c *Cron) {
+		c.failureThreshold = failures
+		c.overrunThreshold = overruns
+	}
+}
+
+// WithDrainTimeout sets how long RunWithContext waits for running jobs to
+// finish once its context is done. The default is 30 seconds; zero or less
+// waits as long as they take.
+func WithDrainTimeout(d time.Duration) Option {
+	return func(c *Cron) {
+		c.drainTimeout = d
+	}
+}
+
+// WithStartupStagger spreads the first runs of the entries that fall due
+// within the window after Start across it, so that a Cron started just before
+// many of them are due doesn't run them all at once. Each entry's first run
+// is put off, if need be, until an offset into the window given by a hash of
+// its name (see WithName), or of its ID if it has none, so that it is the same
+// from one start to the next. Activations that fall before then are skipped,
+// while later ones, and entries first due after the window, running no more
+// than once a window, or added after Start, are unaffected. The delay is
+// logged, and kept in Entry.Stagger.
+func WithStartupStagger(window time.Duration) Option {
+	return func(c *Cron) {
+		c.startupStagger = window
+	}
+}
+
+// WithStatsWindow sets the span of recent runs over which EntryStats gives
+// RecentRuns, RecentFailures and SuccessRate. The default is an hour.
+func WithStatsWindow(d time.Duration) Option {
+	return func(c *Cron) {
+		c.statsWindow = d
+	}
+}
+
+// WithRemoveExhausted removes entries once their schedule has no further
+// activations and any run in progress has finished, e.g. one-off and
+// year-bounded entries after their last run, calling the OnRemoved hook with
+// the reason RemovedExhausted and a last snapshot of the entry, stats
+// included. Adding an entry whose schedule has no activations to come fails
+// with ErrExhausted if that can be told without changing the schedule (see
+// ErrExhausted). WithExhaustedRemoval overrides it for a single entry.
+func WithRemoveExhausted() Option {
+	return func(c *Cron) {
+		c.removeExhausted = true
+	}
+}
+
 // WithLogger uses the provided logger.
 func WithLogger(logger Logger) Option {
 	return func(c *Cron) {
 		c.logger = logger
 	}
 }
+
+// EntryOption represents a modification to the behavior of a single entry.
+type EntryOption func(*Entry)
+
+// WithEntryChain specifies Job wrappers to apply to this entry's job alone.
+// They are applied inside the wrappers configured for the cron.
+func WithEntryChain(wrappers ...JobWrapper) EntryOption {
+	return func(e *Entry) {
+		e.chain = wrappers
+	}
+}
+
+// WithReplacedChain specifies Job wrappers to apply to this entry's job in
+// place of the wrappers configured for the cron.
+func WithReplacedChain(wrappers ...JobWrapper) EntryOption {
+	return func(e *Entry) {
+		e.chain = wrappers
+		e.replaceChain = true
+	}
+}
+
+// WithMaxConcurrentRuns limits the number of invocations of the entry's job
+// that may run at once. Activations beyond that are skipped or queued,
+// according to the policy.
+func WithMaxConcurrentRuns(n int, policy OverlapPolicy) EntryOption {
+	return func(e *Entry) {
+		e.runs.max = n
+		e.runs.policy = policy
+	}
+}
+
+// WithMaxRuns removes the entry once its job has been started n times.
+// Activations that don't start the job at once, because its group is paused
+// or it is already running as many times as it is allowed to, don't count.
+func WithMaxRuns(n int) EntryOption {
+	return func(e *Entry) {
+		e.maxRuns = n
+	}
+}
+
+// WithName names the entry, e.g. for logs, and for WithStartupStagger, which
+// hashes it. Names need not be unique.
+func WithName(name string) EntryOption {
+	return func(e *Entry) {
+		e.Name = name
+	}
+}
+
+// WithExhaustedRemoval sets whether the entry is removed once its schedule
+// has no further activations, as WithRemoveExhausted does for every entry,
+// in place of the Cron's setting. Entries scheduled with a ScheduleChain are
+// removed unless this says otherwise.
+func WithExhaustedRemoval(remove bool) EntryOption {
+	return func(e *Entry) {
+		e.removeExhausted = remove
+	}
+}
+
+// WithSLA makes Health report the entry as stalled if it goes longer than d
+// without running, in place of checking that it ran at its latest activation.
+func WithSLA(d time.Duration) EntryOption {
+	return func(e *Entry) {
+		e.sla = d
+	}
+}
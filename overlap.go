@@ -0,0 +1,169 @@
+package cron
+
+import (
+	"math/big"
+	"time"
+)
+
+// MutuallyExclusive reports whether no two of the schedules ever activate at
+// the same instant, e.g. to check that maintenance windows don't collide. It
+// compares each pair with Overlaps, stopping at the first that overlaps.
+func MutuallyExclusive(schedules ...*SpecSchedule) bool {
+	for i, s := range schedules {
+		for _, other := range schedules[i+1:] {
+			if s.Overlaps(other) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Overlaps reports whether the schedules ever activate at the same instant,
+// in the years from 1970 to 2099 that schedules can run in.
+//
+// Schedules whose fields can't coincide, such as "0 2 * * *" and
+// "0 3 * * *" in the same location, are told apart from their fields alone.
+// Otherwise schedules in the same location are compared day by day, and
+// schedules in different locations by stepping through their activations,
+// which can take a second or more for frequent schedules that never meet.
+func (s *SpecSchedule) Overlaps(other *SpecSchedule) bool {
+	// Time zone offsets are whole minutes, so the seconds and milliseconds
+	// of both schedules must coincide wherever they are.
+	if !intersects(s.milliseconds(), other.milliseconds()) || !intersects(s.Second, other.Second) {
+		return false
+	}
+	loc := s.EffectiveLocation()
+	if loc.String() != other.EffectiveLocation().String() {
+		return s.overlapsAcrossZones(other)
+	}
+
+	if !intersects(s.Minute, other.Minute) || !intersects(s.Hour, other.Hour) {
+		return false
+	}
+	millisecond, second := firstBit(and(s.milliseconds(), other.milliseconds())), firstBit(and(s.Second, other.Second))
+	minute, hour := firstBit(and(s.Minute, other.Minute)), firstBit(and(s.Hour, other.Hour))
+	both := ScheduleComposite{Mode: AndMode, Schedules: []Schedule{s, other}}
+	years, months := and(s.Year, other.Year), and(s.Month, other.Month)
+	for year := minYear; year <= maxYear; year++ {
+		if years.Bit(year-minYear) == 0 {
+			continue
+		}
+		for month := time.January; month <= time.December; month++ {
+			if months.Bit(int(month)) == 0 {
+				continue
+			}
+			for day := time.Date(year, month, 1, 0, 0, 0, 0, loc); day.Month() == month; day = day.AddDate(0, 0, 1) {
+				if !s.dateMatches(day) || !other.dateMatches(day) {
+					continue
+				}
+				// The first time of day both have is nearly always a common
+				// activation, unless daylight saving time skips it.
+				t := time.Date(year, month, day.Day(), hour, minute, second, millisecond*int(time.Millisecond), loc)
+				if s.Matches(t) && other.Matches(t) {
+					return true
+				}
+				next := both.Next(day.Add(-time.Nanosecond))
+				if !next.IsZero() && next.Before(day.AddDate(0, 0, 1)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// overlapsAcrossZones is Overlaps for schedules in different locations.
+func (s *SpecSchedule) overlapsAcrossZones(other *SpecSchedule) bool {
+	// A year in one location may begin or end a day apart from the other.
+	first, last := yearSpan(s.Year)
+	f, l := yearSpan(other.Year)
+	if f > first {
+		first = f
+	}
+	if l < last {
+		last = l
+	}
+	if first < 0 || first > last+1 {
+		return false
+	}
+	if and(s.utcMinutesOfDay(first, last), other.utcMinutesOfDay(first, last)).Sign() == 0 {
+		return false
+	}
+
+	// Next gives up after five years, so search five years at a time.
+	both := ScheduleComposite{Mode: AndMode, Schedules: []Schedule{s, other}}
+	end := time.Date(minYear+last+1, time.January, 2, 0, 0, 0, 0, time.UTC)
+	for t := time.Date(minYear+first-1, time.December, 30, 0, 0, 0, 0, time.UTC); t.Before(end); t = t.AddDate(5, 0, 0) {
+		if next := both.Next(t); !next.IsZero() && next.Before(end) {
+			return true
+		}
+	}
+	return false
+}
+
+// utcMinutesOfDay returns the minutes of the day, in UTC, at which the
+// schedule may activate in the given years (as offsets from minYear), under
+// any of the offsets from UTC its location has in them.
+func (s *SpecSchedule) utcMinutesOfDay(first, last int) *big.Int {
+	const minutesPerDay = 24 * 60
+	loc := s.EffectiveLocation()
+	offsets := make(map[int]bool)
+	end := time.Date(minYear+last+1, time.January, 2, 0, 0, 0, 0, time.UTC)
+	for t := time.Date(minYear+first-1, time.December, 31, 12, 0, 0, 0, time.UTC); t.Before(end); t = t.Add(24 * time.Hour) {
+		_, offset := t.In(loc).Zone()
+		offsets[offset/60] = true
+	}
+
+	minutes := new(big.Int)
+	for hour := 0; hour < 24; hour++ {
+		if s.Hour.Bit(hour) == 0 {
+			continue
+		}
+		for minute := 0; minute < 60; minute++ {
+			if s.Minute.Bit(minute) == 0 {
+				continue
+			}
+			for offset := range offsets {
+				utc := ((hour*60+minute-offset)%minutesPerDay + minutesPerDay) % minutesPerDay
+				minutes.SetBit(minutes, utc, 1)
+			}
+		}
+	}
+	return minutes
+}
+
+// dateMatches reports whether the schedule activates on the date of t, at
+// any time of day.
+func (s *SpecSchedule) dateMatches(t time.Time) bool {
+	return t.Year() >= minYear && t.Year() <= maxYear && s.Year.Bit(t.Year()-minYear) > 0 &&
+		s.Month.Bit(int(t.Month())) > 0 && dayMatches(s, t) && weekMatches(s, t) && !s.excludes(t)
+}
+
+// and returns the bits set in both x and y.
+func and(x, y *big.Int) *big.Int {
+	return new(big.Int).And(x, y)
+}
+
+// intersects reports whether x and y have a value in common, ignoring the bit
+// marking a field given as "*".
+func intersects(x, y *big.Int) bool {
+	both := and(x, y)
+	return both.SetBit(both, maxBits, 0).Sign() > 0
+}
+
+// yearSpan returns the offsets from minYear of the first and last years set
+// in the field, or -1 for both if there are none.
+func yearSpan(field *big.Int) (first, last int) {
+	years := new(big.Int).Set(field)
+	years.SetBit(years, maxBits, 0)
+	return firstBit(years), years.BitLen() - 1
+}
+
+// firstBit returns the lowest bit set, or -1 if there is none.
+func firstBit(x *big.Int) int {
+	if x.Sign() == 0 {
+		return -1
+	}
+	return int(x.TrailingZeroBits())
+}
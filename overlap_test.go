@@ -0,0 +1,68 @@
+package cron
+
+import "testing"
+
+func TestMutuallyExclusive(t *testing.T) {
+	parse := func(specs ...string) []*SpecSchedule {
+		var schedules []*SpecSchedule
+		for _, spec := range specs {
+			sched, _, err := ParseFlexible(spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			schedules = append(schedules, sched.(*SpecSchedule))
+		}
+		return schedules
+	}
+
+	windows := parse("TZ=UTC 0 2 * * sun", "TZ=UTC 0 2 * * sat", "TZ=UTC 0 3 * * sun")
+	if !MutuallyExclusive(windows...) {
+		t.Error("expected the windows to be mutually exclusive")
+	}
+	// The 1st of the month is sometimes a Sunday.
+	if MutuallyExclusive(append(windows, parse("TZ=UTC 0 2 1 * *")...)...) {
+		t.Error("expected the 1st of the month to overlap Sunday")
+	}
+	if !MutuallyExclusive() || !MutuallyExclusive(windows[0]) {
+		t.Error("expected fewer than two schedules to be mutually exclusive")
+	}
+
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"TZ=UTC 0 2 * * *", "TZ=UTC 0 2 * * *", true},
+		{"TZ=UTC */15 * * * *", "TZ=UTC 5-55/10 * * * *", true},
+		{"TZ=UTC */20 * * * *", "TZ=UTC 10-50/20 * * * *", false},
+		// Days that look alike but never coincide.
+		{"TZ=UTC 0 2 * * mon#1", "TZ=UTC 0 2 8-14 * *", false},
+		{"TZ=UTC 0 2 * * mon#2", "TZ=UTC 0 2 8-14 * *", true},
+		{"TZ=UTC 0 2 l * *", "TZ=UTC 0 2 1-27 * *", false},
+		{"TZ=UTC 0 2 l * *", "TZ=UTC 0 2 29 2 *", true},
+		{"TZ=UTC 0 2 15w * *", "TZ=UTC 0 2 * * sat,sun", false},
+		{"TZ=UTC 0 0 2 * * * 2024 !2024-03-01", "TZ=UTC 0 0 2 1 3 * 2024", false},
+		{"TZ=UTC 0 0 2 * * * 2024-2025 !2024-03-01", "TZ=UTC 0 0 2 1 3 * 2024-2025", true},
+		{"TZ=UTC 0 0 2 * * * 2024", "TZ=UTC 0 0 2 * * * 2025", false},
+		// A schedule that never runs overlaps nothing.
+		{"TZ=UTC 0 2 31 2 *", "TZ=UTC 0 2 * * *", false},
+		// 02:30 doesn't exist on the day clocks go forward, but 03:00 does.
+		{"TZ=America/New_York 30,0 2,3 10 3 *", "TZ=America/New_York 0 3 * 3 sun#2", true},
+		// Different locations.
+		{"TZ=UTC 0 7 * * *", "TZ=Europe/London 0 8 * * *", true},
+		{"TZ=UTC 30 * * * *", "TZ=Asia/Kolkata 0 * * * *", true},
+		{"TZ=UTC 0 * * * *", "TZ=Asia/Kolkata 0 * * * *", false},
+		{"TZ=UTC 0 0 0 1 1 * 2025", "TZ=Asia/Tokyo 0 0 9 1 1 * 2025", true},
+		{"TZ=UTC 0 0 0 1 1 * 2025", "TZ=Asia/Tokyo 0 0 9 1 1 * 2024", false},
+		{"TZ=UTC 0 0 23 31 12 * 2024", "TZ=Asia/Tokyo 0 0 8 1 1 * 2025", true},
+		{"TZ=UTC 30 0 0 * * *", "TZ=Asia/Tokyo 0 0 9 * * *", false},
+	}
+	for _, c := range tests {
+		a, b := parse(c.a)[0], parse(c.b)[0]
+		if got := a.Overlaps(b); got != c.expected {
+			t.Errorf("%s and %s: expected %v, got %v", c.a, c.b, c.expected, got)
+		}
+		if got := b.Overlaps(a); got != c.expected {
+			t.Errorf("%s and %s: expected %v, got %v", c.b, c.a, c.expected, got)
+		}
+	}
+}
@@ -1,13 +1,20 @@
 package cron
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
+// ErrEmptyExpression is returned when parsing a spec that is empty or only
+// whitespace.
+var ErrEmptyExpression = errors.New("empty spec string")
+
 // Configuration options for creating a parser. Most options specify which
 // fields should be included, while others enable features. If a field is not
 // included the parser will assume a default value. These options do not change
@@ -15,19 +22,32 @@ import (
 type ParseOption int
 
 const (
-	Second         ParseOption = 1 << iota // Seconds field, default 0
-	SecondOptional                         // Optional seconds field, default 0
-	Minute                                 // Minutes field, default 0
-	Hour                                   // Hours field, default 0
-	Dom                                    // Day of month field, default *
-	Month                                  // Month field, default *
-	Dow                                    // Day of week field, default *
-	DowOptional                            // Optional day of week field, default *
-	Year                                   // Year field, default *
-	YearOptional                           // Optional years fiels, default 0
-	Descriptor                             // Allow descriptors such as @monthly, @weekly, etc.
+	Second           ParseOption = 1 << iota // Seconds field, default 0
+	SecondOptional                           // Optional seconds field, default 0
+	Minute                                   // Minutes field, default 0
+	Hour                                     // Hours field, default 0
+	Dom                                      // Day of month field, default *
+	Month                                    // Month field, default *
+	Dow                                      // Day of week field, default *
+	DowOptional                              // Optional day of week field, default *
+	Year                                     // Year field, default *
+	YearOptional                             // Optional years fiels, default 0
+	Descriptor                               // Allow descriptors such as @monthly, @weekly, etc.
+	WeekStartsMonday                         // Number days of the week from 0 (Monday) to 6 (Sunday)
+	TwoDigitYears                            // Accept two-digit years, e.g. 24 for 2024 (see WithYearPivot)
+	Week                                     // ISO 8601 week of year field, default *
+	DomAndDow                                // Match days satisfying both day of month and day of week
+	ClampDom                                 // Days of month past the end of a month match its last day
+	InlineComments                           // Allow a trailing comment after the fields, e.g. "# nightly"
+	Millisecond                              // Milliseconds field before all others, 0-999
+	LenientLists                             // Rejoin lists split by spaces, e.g. "9, 12" as "9,12"
+	ExcludeDates                             // Allow a trailing list of dates not to run on, e.g. "!2024-12-25"
 )
 
+// DefaultYearPivot is the two-digit year from which years are taken to be in
+// the 1900s rather than the 2000s: 70 is 1970 and 69 is 2069.
+const DefaultYearPivot = 70
+
 var places = []ParseOption{
 	Second,
 	Minute,
@@ -36,8 +56,15 @@ var places = []ParseOption{
 	Month,
 	Dow,
 	Year,
+	Week,
 }
 
+// fieldNames and fieldBounds describe each of the places, in order.
+var (
+	fieldNames  = []string{"second", "minute", "hour", "dom", "month", "dow", "year", "week"}
+	fieldBounds = []*bounds{&seconds, &minutes, &hours, &dom, &months, &dow, &years, &weeks}
+)
+
 var defaults = []string{
 	"0",
 	"0",
@@ -46,11 +73,17 @@ var defaults = []string{
 	"*",
 	"*",
 	"*",
+	"*",
 }
 
 // A custom Parser that can be configured.
 type Parser struct {
-	options ParseOption
+	options   ParseOption
+	yearPivot int // or 0 for DefaultYearPivot
+	// bounds holds the fields with custom bounds, if any. It is a pointer
+	// so that Parsers remain comparable.
+	bounds *map[ParseOption]bounds
+	names  *locale // extra month and weekday names, if any
 }
 
 // NewParser creates a Parser with custom options.
@@ -89,79 +122,278 @@ func NewParser(options ParseOption) Parser {
 	if optionals > 1 {
 		panic("multiple optionals may not be configured")
 	}
-	return Parser{options}
+	return Parser{options: options}
+}
+
+// WithYearPivot returns a copy of the parser that accepts two-digit years,
+// reading those from pivot to 99 as 19xx and those below pivot as 20xx. A
+// pivot of 0 selects DefaultYearPivot.
+func (p Parser) WithYearPivot(pivot int) Parser {
+	p.options |= TwoDigitYears
+	p.yearPivot = pivot
+	return p
+}
+
+// WithBounds returns a copy of the parser that accepts only values from min
+// to max in the given field, e.g. seconds 0-29 for a device that can only be
+// woken in the first half of each minute. Only the second, minute, hour, month
+// and week fields may be changed, as the others hold flags or offsets above
+// their values.
+//
+// Schedules run on the calendar, so the bounds must lie within the field's
+// own: a value the calendar never reaches, such as second 75, could never
+// activate a schedule.
+func (p Parser) WithBounds(place ParseOption, min, max uint) (Parser, error) {
+	i := placeIndex(place)
+	switch place {
+	case Second, Minute, Hour, Month, Week:
+	default:
+		if i >= 0 {
+			return p, fmt.Errorf("bounds of the %s field can't be changed", fieldNames[i])
+		}
+		return p, fmt.Errorf("not a single field: %d", place)
+	}
+	if r := fieldBounds[i]; min > max || min < r.min || max > r.max {
+		return p, fmt.Errorf("bad bounds for the %s field: %d-%d, not within %d-%d", fieldNames[i], min, max, r.min, r.max)
+	}
+	b := make(map[ParseOption]bounds)
+	if p.bounds != nil {
+		for place, r := range *p.bounds {
+			b[place] = r
+		}
+	}
+	r := *fieldBounds[i]
+	r.min, r.max = min, max
+	b[place] = r
+	p.bounds = &b
+	return p, nil
+}
+
+// placeIndex returns the index of the place in places, or -1 if it isn't one.
+func placeIndex(place ParseOption) int {
+	for i, p := range places {
+		if p == place {
+			return i
+		}
+	}
+	return -1
 }
 
 // Parse returns a new crontab schedule representing the given spec.
 // It returns a descriptive error if the spec is not valid.
 // It accepts crontab specs and features configured by NewParser.
 func (p Parser) Parse(spec string) (Schedule, error) {
-	if len(spec) == 0 {
-		return nil, fmt.Errorf("empty spec string")
+	sched, errs := p.parse(spec, false)
+	if len(errs) > 0 {
+		return nil, errs[0].Err
+	}
+	return sched, nil
+}
+
+// Validate reports every problem with the given spec rather than stopping at
+// the first one, which makes it suitable for linting configuration. Each field
+// is checked independently, so a spec with six bad fields yields six errors.
+// It returns nil if the spec is valid.
+func (p Parser) Validate(spec string) []ParseError {
+	_, errs := p.parse(spec, true)
+	return errs
+}
+
+// ParseError describes a problem found in a spec.
+type ParseError struct {
+	// Field is the name of the offending field (e.g. "minute", "dow"), or empty
+	// if the problem concerns the spec as a whole.
+	Field string
+
+	// Value is the token that could not be parsed.
+	Value string
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e ParseError) Error() string {
+	if e.Field == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s field %q: %v", e.Field, e.Value, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e ParseError) Unwrap() error { return e.Err }
+
+// parse does the work of Parse. If all is set, it keeps going after a bad
+// field so that every field error is reported.
+func (p Parser) parse(spec string, all bool) (Schedule, []ParseError) {
+	expr, err := p.expression(spec)
+	if err != nil {
+		return nil, []ParseError{{Err: err}}
+	}
+
+	loc := expr.loc
+	if loc == nil {
+		loc = time.Local
+	}
+	if expr.fields == nil {
+		sched, err := parseEvery(expr.descriptor)
+		if err != nil {
+			return nil, []ParseError{{Err: err}}
+		}
+		return sched, nil
+	}
+	sched, errs := expr.compile(loc, all)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return sched, nil
+}
+
+// expression splits the spec into its time zone and fields, filling in any
+// omitted fields, without interpreting the fields themselves. Descriptors are
+// expanded to their fields, apart from "@every", which has none.
+func (p Parser) expression(spec string) (*Expression, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, ErrEmptyExpression
+	}
+	expr := &Expression{raw: spec, options: p.options, yearPivot: p.yearPivot, locale: p.names}
+	if p.bounds != nil {
+		expr.bounds = *p.bounds
 	}
 
 	// Extract timezone if present
-	var loc = time.Local
 	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
 		var err error
 		i := strings.Index(spec, " ")
 		eq := strings.Index(spec, "=")
-		if loc, err = time.LoadLocation(spec[eq+1 : i]); err != nil {
+		if expr.loc, err = time.LoadLocation(spec[eq+1 : i]); err != nil {
 			return nil, fmt.Errorf("provided bad location %s: %v", spec[eq+1:i], err)
 		}
 		spec = strings.TrimSpace(spec[i:])
 	}
 
+	if p.options&InlineComments > 0 {
+		required := requiredFields(p.options)
+		if strings.HasPrefix(spec, "@") {
+			required = 1
+		}
+		spec, expr.comment = splitComment(spec, required)
+	}
+
+	// A trailing list of dates not to run on, e.g. "!2024-12-25,2025-01-01".
+	if i := strings.LastIndexFunc(spec, unicode.IsSpace); i >= 0 && strings.HasPrefix(spec[i+1:], "!") {
+		if p.options&ExcludeDates == 0 {
+			return nil, fmt.Errorf("parser does not accept excluded dates: %v", spec[i+1:])
+		}
+		var err error
+		if expr.excluded, err = parseExcludedDates(spec[i+2:]); err != nil {
+			return nil, err
+		}
+		spec = strings.TrimSpace(spec[:i])
+	}
+
+	// The milliseconds field is not one of the places, so take it first.
+	if p.options&Millisecond > 0 && !strings.HasPrefix(spec, "@") {
+		expr.millis = spec
+		spec = ""
+		if i := strings.IndexFunc(expr.millis, unicode.IsSpace); i >= 0 {
+			expr.millis, spec = expr.millis[:i], strings.TrimSpace(expr.millis[i:])
+		}
+	}
+
 	// Handle named schedules (descriptors), if configured
 	if strings.HasPrefix(spec, "@") {
 		if p.options&Descriptor == 0 {
 			return nil, fmt.Errorf("parser does not accept descriptors: %v", spec)
 		}
-		return parseDescriptor(spec, loc)
+		expr.descriptor = spec
+		if fields, ok := descriptors[spec]; ok {
+			expr.fields = append(strings.Fields(fields), defaults[7:]...)
+			// The fields number days of the week from Sunday, whatever the
+			// parser's numbering.
+			expr.options &^= WeekStartsMonday
+		} else if !strings.HasPrefix(spec, every) {
+			return nil, fmt.Errorf("unrecognized descriptor: %s", spec)
+		} else if expr.excluded != nil {
+			return nil, fmt.Errorf("intervals can't exclude dates: %s", spec)
+		}
+		return expr, nil
 	}
 
 	// Split on whitespace.
 	fields := strings.Fields(spec)
+	if p.options&LenientLists > 0 {
+		var err error
+		if fields, err = rejoinLists(fields, p.options); err != nil {
+			return nil, err
+		}
+	}
 
 	// Validate & fill in any omitted or optional fields
-	var err error
-	fields, err = normalizeFields(fields, p.options)
+	fields, err := normalizeFields(fields, p.options)
 	if err != nil {
 		return nil, err
 	}
+	expr.fields = fields
+	return expr, nil
+}
 
-	field := func(field string, r bounds) *big.Int {
-		if err != nil {
-			return nil
+// rejoinLists joins the fragments of lists that were split by spaces, such as
+// "9," and "12" or "9" and ",12", back into one field. If there are still too
+// many fields, it returns an error naming the first one too many.
+func rejoinLists(fields []string, options ParseOption) ([]string, error) {
+	var joined []string
+	for _, field := range fields {
+		if n := len(joined); n > 0 && (strings.HasSuffix(joined[n-1], ",") || strings.HasPrefix(field, ",")) {
+			joined[n-1] += field
+			continue
 		}
-		var bits *big.Int
-		bits, err = getField(field, r)
-		return bits
+		joined = append(joined, field)
 	}
+	max := requiredFields(options &^ Millisecond)
+	if options&(SecondOptional|DowOptional|YearOptional) > 0 {
+		max++
+	}
+	if len(joined) > max {
+		return nil, fmt.Errorf("expected at most %d fields, found %d: %q at position %d is one too many",
+			max, len(joined), joined[max], max+1)
+	}
+	return joined, nil
+}
 
-	var (
-		second     = field(fields[0], seconds)
-		minute     = field(fields[1], minutes)
-		hour       = field(fields[2], hours)
-		dayofmonth = field(fields[3], dom)
-		month      = field(fields[4], months)
-		dayofweek  = field(fields[5], dow)
-		year       = field(fields[6], years)
-	)
-	if err != nil {
-		return nil, err
+// requiredFields returns the number of fields a spec must have, not counting
+// an optional one.
+func requiredFields(options ParseOption) int {
+	n := 0
+	for _, place := range places {
+		if options&place > 0 {
+			n++
+		}
+	}
+	if options&Millisecond > 0 {
+		n++
 	}
+	return n
+}
 
-	return &SpecSchedule{
-		Second:   second,
-		Minute:   minute,
-		Hour:     hour,
-		Dom:      dayofmonth,
-		Month:    month,
-		Dow:      dayofweek,
-		Year:     year,
-		Location: loc,
-	}, nil
+// splitComment splits a trailing comment from the spec. A comment is a token
+// beginning with "#" that comes after at least the given number of fields, so
+// that a "#" within a field is never mistaken for one. It returns the spec
+// without the comment, and the comment's text.
+func splitComment(spec string, fields int) (string, string) {
+	n, inToken := 0, false
+	for i, r := range spec {
+		switch {
+		case unicode.IsSpace(r):
+			inToken = false
+		case !inToken:
+			if r == '#' && n >= fields {
+				return strings.TrimSpace(spec[:i]), strings.TrimSpace(spec[i+1:])
+			}
+			inToken = true
+			n++
+		}
+	}
+	return spec, ""
 }
 
 // normalizeFields takes a subset set of the time fields and returns the full set
@@ -207,17 +439,24 @@ func normalizeFields(fields []string, options ParseOption) ([]string, error) {
 
 	// Populate the optional field if not provided
 	if min < max && len(fields) == min {
+		// The number of fields given after the optional one.
+		after := 0
+		if options&Week > 0 {
+			after++
+		}
+		insert := func(i int, field string) []string {
+			return append(fields[:i], append([]string{field}, fields[i:]...)...)
+		}
 		switch {
 		case options&DowOptional > 0:
 			if options&Year > 0 {
-				fields = append(fields[:len(fields)-1], defaults[5], fields[len(fields)-1])
-			} else {
-				fields = append(fields, defaults[5]) // TODO: improve access to default
+				after++
 			}
+			fields = insert(len(fields)-after, defaults[5])
 		case options&SecondOptional > 0:
 			fields = append([]string{defaults[0]}, fields...)
 		case options&YearOptional > 0:
-			fields = append(fields, defaults[6])
+			fields = insert(len(fields)-after, defaults[6])
 		default:
 			return nil, fmt.Errorf("unknown optional field")
 		}
@@ -240,6 +479,72 @@ var standardParser = NewParser(
 	Minute | Hour | Dom | Month | Dow | Descriptor,
 )
 
+// FieldLayout is the set of fields ParseFlexible takes a spec to have, which
+// it tells apart by their number.
+type FieldLayout int
+
+const (
+	StandardLayout    FieldLayout = 5 // minute, hour, day of month, month and day of week
+	SecondsLayout     FieldLayout = 6 // seconds, then the standard fields
+	SecondsYearLayout FieldLayout = 7 // seconds, the standard fields, then the year
+)
+
+func (l FieldLayout) String() string {
+	switch l {
+	case StandardLayout:
+		return "standard"
+	case SecondsLayout:
+		return "seconds"
+	case SecondsYearLayout:
+		return "seconds and year"
+	}
+	return fmt.Sprintf("FieldLayout(%d)", int(l))
+}
+
+// layoutParsers holds the parser for each FieldLayout.
+var layoutParsers = map[FieldLayout]Parser{
+	StandardLayout:    NewParser(Minute | Hour | Dom | Month | Dow | ExcludeDates),
+	SecondsLayout:     NewParser(Second | Minute | Hour | Dom | Month | Dow | ExcludeDates),
+	SecondsYearLayout: NewParser(Second | Minute | Hour | Dom | Month | Dow | Year | ExcludeDates),
+}
+
+// specFields returns the fields of the spec, not counting a time zone or a
+// list of excluded dates.
+func specFields(spec string) []string {
+	fields := strings.Fields(spec)
+	if len(fields) > 0 && (strings.HasPrefix(fields[0], "TZ=") || strings.HasPrefix(fields[0], "CRON_TZ=")) {
+		fields = fields[1:]
+	}
+	if len(fields) > 1 && strings.HasPrefix(fields[len(fields)-1], "!") {
+		fields = fields[:len(fields)-1]
+	}
+	return fields
+}
+
+// layoutOf returns the layout of the spec and its parser.
+func layoutOf(spec string) (FieldLayout, Parser, error) {
+	fields := specFields(spec)
+	if len(fields) == 0 {
+		return 0, Parser{}, ErrEmptyExpression
+	}
+	layout := FieldLayout(len(fields))
+	p, ok := layoutParsers[layout]
+	if !ok {
+		return 0, Parser{}, fmt.Errorf("expected 5 to 7 fields, found %d: %s", len(fields), fields)
+	}
+	return layout, p, nil
+}
+
+// parserForFieldCount returns the parser for the layout of the spec, or the
+// standard parser if it is a descriptor.
+func parserForFieldCount(spec string) (Parser, error) {
+	if fields := specFields(spec); len(fields) > 0 && strings.HasPrefix(fields[0], "@") {
+		return standardParser, nil
+	}
+	_, p, err := layoutOf(spec)
+	return p, err
+}
+
 // ParseStandard returns a new crontab schedule representing the given
 // standardSpec (https://en.wikipedia.org/wiki/Cron). It requires 5 entries
 // representing: minute, hour, day of month, month and day of week, in that
@@ -252,6 +557,152 @@ func ParseStandard(standardSpec string) (Schedule, error) {
 	return standardParser.Parse(standardSpec)
 }
 
+// ParseWithLocation parses a standard 5-field spec, as ParseStandard does,
+// and interprets it in loc. A time zone given in the spec itself (TZ= or
+// CRON_TZ=) takes precedence over loc, as it does in Expression.Compile; if
+// neither is given, time.Local is used. Interval descriptors ("@every 5m")
+// are rejected, since they have no location.
+func ParseWithLocation(spec string, loc *time.Location) (*SpecSchedule, error) {
+	expr, err := standardParser.expression(spec)
+	if err != nil {
+		return nil, err
+	}
+	if expr.fields == nil {
+		return nil, fmt.Errorf("interval is not a cron expression: %s", spec)
+	}
+	return expr.Compile(loc)
+}
+
+// MustParseWithLocation is like ParseWithLocation but panics if the spec
+// cannot be parsed. It simplifies the initialization of global variables.
+func MustParseWithLocation(spec string, loc *time.Location) *SpecSchedule {
+	sched, err := ParseWithLocation(spec, loc)
+	if err != nil {
+		panic(err)
+	}
+	return sched
+}
+
+// ParseFlexible parses a spec whose layout is told by its number of fields:
+// 5 for a standard spec, 6 for one starting with seconds, and 7 for one that
+// also ends with a year. It reports the layout it used. Any other number of
+// fields is an error, as are descriptors. Parse, by contrast, accepts only the
+// fields its parser was created with.
+func ParseFlexible(spec string) (Schedule, FieldLayout, error) {
+	layout, p, err := layoutOf(spec)
+	if err != nil {
+		return nil, 0, err
+	}
+	sched, err := p.Parse(spec)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sched, layout, nil
+}
+
+// ParseField returns the bits for a single field of a cron spec, such as
+// "1-5/2" or "MON-FRI", using the same rules as the parser. The field's
+// values must lie within [min, max], and may be given by any of the names.
+func ParseField(fieldSpec string, min, max uint, names map[string]uint) (*big.Int, error) {
+	return getField(fieldSpec, bounds{min, max, names})
+}
+
+// FormatField returns the canonical representation of the bits of a field
+// with the given bounds: "*" if every value is set, a step such as "*/15" or
+// "5-45/10" if the values are evenly spaced, and otherwise a list of values and
+// ranges. Values are written as numbers, apart from those with numeric names
+// (such as years) and those outside [min, max], for which the shortest name is
+// used. Bits outside [min, max] without a name, such as the ClampDom flags,
+// are left out, as no token gives them.
+func FormatField(bits *big.Int, min, max uint, names map[string]uint) string {
+	var values, extra []uint
+	for i := min; i <= max; i++ {
+		if bits.Bit(int(i)) > 0 {
+			values = append(values, i)
+		}
+	}
+
+	// Choose a name for each value.
+	name := make(map[uint]string)
+	for n, v := range names {
+		if v >= min && v <= max && !isNumber(n) {
+			continue
+		}
+		if cur, ok := name[v]; !ok || len(n) < len(cur) || len(n) == len(cur) && n < cur {
+			name[v] = n
+		}
+	}
+	for i := max + 1; i < maxBits; i++ {
+		if _, ok := name[i]; ok && bits.Bit(int(i)) > 0 {
+			extra = append(extra, i)
+		}
+	}
+	format := func(v uint) string {
+		if n, ok := name[v]; ok {
+			return n
+		}
+		return strconv.Itoa(int(v))
+	}
+
+	var tokens []string
+	switch n := len(values); {
+	case n == 0:
+	case n == int(max-min+1):
+		tokens = append(tokens, "*")
+	case n >= 3 && isStep(values):
+		first, last, step := values[0], values[n-1], values[1]-values[0]
+		switch {
+		case first == min && last+step > max:
+			tokens = append(tokens, fmt.Sprintf("*/%d", step))
+		case last+step > max:
+			tokens = append(tokens, fmt.Sprintf("%s/%d", format(first), step))
+		default:
+			tokens = append(tokens, fmt.Sprintf("%s-%s/%d", format(first), format(last), step))
+		}
+	default:
+		for i := 0; i < n; {
+			j := i
+			for j+1 < n && values[j+1] == values[j]+1 {
+				j++
+			}
+			if j == i {
+				tokens = append(tokens, format(values[i]))
+			} else {
+				tokens = append(tokens, format(values[i])+"-"+format(values[j]))
+			}
+			i = j + 1
+		}
+	}
+	for _, v := range extra {
+		tokens = append(tokens, format(v))
+	}
+	return strings.Join(tokens, ",")
+}
+
+// isStep returns true if the values are evenly spaced, more than one apart.
+func isStep(values []uint) bool {
+	step := values[1] - values[0]
+	if step < 2 {
+		return false
+	}
+	for i := 2; i < len(values); i++ {
+		if values[i]-values[i-1] != step {
+			return false
+		}
+	}
+	return true
+}
+
+// isNumber returns true if s consists only of digits.
+func isNumber(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
 // getField returns an Int with the bits set representing all of the times that
 // the field represents or error parsing field value.  A "field" is a comma-separated
 // list of "ranges".
@@ -259,7 +710,15 @@ func getField(field string, r bounds) (*big.Int, error) {
 	var bits big.Int
 	ranges := strings.FieldsFunc(field, func(r rune) bool { return r == ',' })
 	for _, expr := range ranges {
-		bit, err := getRange(expr, r)
+		var (
+			bit *big.Int
+			err error
+		)
+		if isWeekday(expr, r) {
+			bit, err = getWeekday(expr, r)
+		} else {
+			bit, err = getRange(expr, r)
+		}
 		if err != nil {
 			return &bits, err
 		}
@@ -268,9 +727,60 @@ func getField(field string, r bounds) (*big.Int, error) {
 	return &bits, nil
 }
 
+// isWeekday reports whether expr gives a nearest weekday in the day of month
+// field, as in "15W" and "LW", or an nth weekday in the day of week field, as
+// in "MON#2".
+func isWeekday(expr string, r bounds) bool {
+	switch {
+	case r.min == 1 && r.max == 31:
+		return strings.HasSuffix(strings.ToLower(expr), "w")
+	case r.min == 0 && r.max == 6:
+		return strings.Contains(expr, "#")
+	}
+	return false
+}
+
+// getWeekday returns the bit for an expression accepted by isWeekday. A
+// nearest weekday is never in another month, and an nth weekday is from the
+// 1st to the 5th of the month.
+func getWeekday(expr string, r bounds) (*big.Int, error) {
+	bits := new(big.Int)
+	if day, nth, ok := strings.Cut(expr, "#"); ok {
+		w, err := parseIntOrName(day, r.names)
+		if err != nil {
+			return nil, err
+		}
+		if w > r.max {
+			return nil, fmt.Errorf("day of week (%d) above maximum (%d): %s", w, r.max, expr)
+		}
+		n, err := mustParseInt(nth)
+		if err != nil {
+			return nil, err
+		}
+		if n < 1 || n > 5 {
+			return nil, fmt.Errorf("nth weekday (%d) must be from 1 to 5: %s", n, expr)
+		}
+		return bits.SetBit(bits, nthWeekdayBit(int(n), int(w)), 1), nil
+	}
+
+	day := strings.ToLower(expr[:len(expr)-1])
+	if day == "l" {
+		return bits.SetBit(bits, lastWeekdayBit, 1), nil
+	}
+	v, err := mustParseInt(day)
+	if err != nil {
+		return nil, err
+	}
+	if v < r.min || v > r.max {
+		return nil, fmt.Errorf("day of month (%d) must be from %d to %d: %s", v, r.min, r.max, expr)
+	}
+	return bits.SetBit(bits, domWeekdayBit(int(v)), 1), nil
+}
+
 // getRange returns the bits indicated by the given expression:
-//   number | number "-" number [ "/" number ]
-// or error parsing range.
+//   number | [ number ] "-" [ number ] [ "/" number ]
+// or error parsing range. A range may leave out one of its endpoints, but not
+// both.
 func getRange(expr string, r bounds) (*big.Int, error) {
 	var (
 		start, end, step uint
@@ -285,18 +795,28 @@ func getRange(expr string, r bounds) (*big.Int, error) {
 		start = r.min
 		end = r.max
 		extra = maxBits
+	} else if len(lowAndHigh) == 2 && lowAndHigh[0] == "" && lowAndHigh[1] == "" {
+		return nil, fmt.Errorf("range has no endpoints (use * for every value): %s", expr)
 	} else {
-		start, err = parseIntOrName(lowAndHigh[0], r.names)
-		if err != nil {
-			return nil, err
+		// Either endpoint of a range may be left out, for the field's minimum
+		// or maximum, as in "5-" and "-30".
+		start = r.min
+		if len(lowAndHigh) == 1 || lowAndHigh[0] != "" {
+			start, err = parseYearOrIntOrName(lowAndHigh[0], r)
+			if err != nil {
+				return nil, err
+			}
 		}
 		switch len(lowAndHigh) {
 		case 1:
 			end = start
 		case 2:
-			end, err = parseIntOrName(lowAndHigh[1], r.names)
-			if err != nil {
-				return nil, err
+			end = r.max
+			if lowAndHigh[1] != "" {
+				end, err = parseYearOrIntOrName(lowAndHigh[1], r)
+				if err != nil {
+					return nil, err
+				}
 			}
 		default:
 			return nil, fmt.Errorf("too many hyphens: %s", expr)
@@ -327,11 +847,12 @@ func getRange(expr string, r bounds) (*big.Int, error) {
 		return nil, fmt.Errorf("beginning of range (%d) below minimum (%d): %s", start, r.min, expr)
 	}
 	if end > r.max {
-		if r.max != 31 && r.max != 6 { // not dom and not dow
-			return nil, fmt.Errorf("end of range (%d) above maximum (%d): %s", end, r.max, expr)
-		} else if r.max == 31 && (end > 55 || end < 48) {
-			return nil, fmt.Errorf("end of range (%d) above maximum (%d): %s", end, r.max, expr)
-		} else if r.max == 6 && (end > 55 || end < 49) {
+		// Only the names of the last days of the month (dom) and last weekdays
+		// (dow) lie beyond the maximum, and only as single values: "1-5l" would
+		// otherwise set every flag in between, and "50" the flag for "5l".
+		lastDay := singleDigit && len(rangeAndStep) == 1 && !isNumber(lowAndHigh[0]) &&
+			(r.max == 31 && end >= 48 && end <= 55 || r.max == 6 && end >= 49 && end <= 55)
+		if !lastDay {
 			return nil, fmt.Errorf("end of range (%d) above maximum (%d): %s", end, r.max, expr)
 		}
 	}
@@ -349,14 +870,140 @@ func getRange(expr string, r bounds) (*big.Int, error) {
 	return bits, nil
 }
 
-// parseIntOrName returns the (possibly-named) integer contained in expr.
+// clampFlags returns the clamp flags for the days of month from 29 to 31 given
+// as single values or range endpoints in the field. Values in steps are not
+// clamped, as "*/10" should not run on both the 28th and the 31st.
+func clampFlags(field string) *big.Int {
+	flags := new(big.Int)
+	for _, expr := range strings.Split(field, ",") {
+		if strings.Contains(expr, "/") {
+			continue
+		}
+		for i, end := range strings.Split(expr, "-") {
+			if i > 0 && end == "" { // an open range, to the 31st
+				end = "31"
+			}
+			if v, err := strconv.Atoi(end); err == nil && v >= 29 && v <= 31 {
+				flags.SetBit(flags, domClampBit(v), 1)
+			}
+		}
+	}
+	return flags
+}
+
+// cutDomAndDow returns the day of month field without a leading "&", which
+// requires days to match the day of week field as well, as DomAndDow does for
+// every spec, and whether it had one.
+func cutDomAndDow(field string) (string, bool) {
+	return strings.CutPrefix(field, "&")
+}
+
+// parseYearOrIntOrName is parseIntOrName, apart from in the year field, where
+// only the years themselves are accepted rather than their offsets.
+func parseYearOrIntOrName(expr string, r bounds) (uint, error) {
+	if r.max == years.max && r.names != nil { // year
+		if _, ok := r.names[strings.ToLower(expr)]; !ok {
+			return 0, fmt.Errorf("unrecognized year: %s", expr)
+		}
+	}
+	return parseIntOrName(expr, r.names)
+}
+
+// parseIntOrName returns the (possibly-named) integer contained in expr. Names
+// may be abbreviated to any prefix of at least three letters that is not
+// shared by names of other values. If expr is neither a number nor a name, the
+// error suggests the closest name.
 func parseIntOrName(expr string, names map[string]uint) (uint, error) {
-	if names != nil {
-		if namedInt, ok := names[strings.ToLower(expr)]; ok {
-			return namedInt, nil
+	if names == nil {
+		return mustParseInt(expr)
+	}
+	name := strings.ToLower(expr)
+	if namedInt, ok := names[name]; ok {
+		return namedInt, nil
+	}
+	if namedInt, ok := namePrefix(name, names); ok {
+		return namedInt, nil
+	}
+	num, err := mustParseInt(expr)
+	if err != nil {
+		if closest := closestName(name, names); closest != "" {
+			return 0, fmt.Errorf("%v (did you mean %s?)", err, closest)
 		}
 	}
-	return mustParseInt(expr)
+	return num, err
+}
+
+// namePrefix returns the value of the names beginning with prefix, if they
+// all have the same one.
+func namePrefix(prefix string, names map[string]uint) (uint, bool) {
+	if utf8.RuneCountInString(prefix) < 3 || isNumber(prefix) {
+		return 0, false
+	}
+	var (
+		value uint
+		found bool
+	)
+	for name, v := range names {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if found && v != value {
+			return 0, false
+		}
+		value, found = v, true
+	}
+	return value, found
+}
+
+// closestName returns the name nearest to the given one by edit distance, if
+// any is close enough to be a plausible misspelling. Numeric names, such as
+// years, are not suggested.
+func closestName(name string, names map[string]uint) string {
+	var (
+		closest string
+		best    = 4 // one more than the furthest a suggestion may be
+	)
+	for n := range names {
+		if isNumber(n) {
+			continue
+		}
+		d := editDistance(name, n)
+		if d >= utf8.RuneCountInString(name) {
+			continue
+		}
+		if d < best || d == best && n < closest {
+			closest, best = n, d
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if d := prev[j] + 1; d < cur[j] {
+				cur[j] = d
+			}
+			if d := cur[j-1] + 1; d < cur[j] {
+				cur[j] = d
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
 }
 
 // mustParseInt parses the given expression as an int or returns an error.
@@ -389,79 +1036,26 @@ func all(r bounds) *big.Int {
 	return bits.SetBit(bits, maxBits, 1)
 }
 
-// parseDescriptor returns a predefined schedule for the expression, or error if none matches.
-func parseDescriptor(descriptor string, loc *time.Location) (Schedule, error) {
-	switch descriptor {
-	case "@yearly", "@annually":
-		return &SpecSchedule{
-			Second:   big.NewInt(1 << seconds.min),
-			Minute:   big.NewInt(1 << minutes.min),
-			Hour:     big.NewInt(1 << hours.min),
-			Dom:      big.NewInt(1 << dom.min),
-			Month:    big.NewInt(1 << months.min),
-			Dow:      all(dow),
-			Year:     all(years),
-			Location: loc,
-		}, nil
-
-	case "@monthly":
-		return &SpecSchedule{
-			Second:   big.NewInt(1 << seconds.min),
-			Minute:   big.NewInt(1 << minutes.min),
-			Hour:     big.NewInt(1 << hours.min),
-			Dom:      big.NewInt(1 << dom.min),
-			Month:    all(months),
-			Dow:      all(dow),
-			Year:     all(years),
-			Location: loc,
-		}, nil
-
-	case "@weekly":
-		return &SpecSchedule{
-			Second:   big.NewInt(1 << seconds.min),
-			Minute:   big.NewInt(1 << minutes.min),
-			Hour:     big.NewInt(1 << hours.min),
-			Dom:      all(dom),
-			Month:    all(months),
-			Dow:      big.NewInt(1 << dow.min),
-			Year:     all(years),
-			Location: loc,
-		}, nil
-
-	case "@daily", "@midnight":
-		return &SpecSchedule{
-			Second:   big.NewInt(1 << seconds.min),
-			Minute:   big.NewInt(1 << minutes.min),
-			Hour:     big.NewInt(1 << hours.min),
-			Dom:      all(dom),
-			Month:    all(months),
-			Dow:      all(dow),
-			Year:     all(years),
-			Location: loc,
-		}, nil
-
-	case "@hourly":
-		return &SpecSchedule{
-			Second:   big.NewInt(1 << seconds.min),
-			Minute:   big.NewInt(1 << minutes.min),
-			Hour:     all(hours),
-			Dom:      all(dom),
-			Month:    all(months),
-			Dow:      all(dow),
-			Year:     all(years),
-			Location: loc,
-		}, nil
-
-	}
-
-	const every = "@every "
-	if strings.HasPrefix(descriptor, every) {
-		duration, err := time.ParseDuration(descriptor[len(every):])
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse duration %s: %s", descriptor, err)
-		}
-		return Every(duration), nil
-	}
+// descriptors maps each predefined schedule to its fields.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 * *",
+	"@annually": "0 0 0 1 1 * *",
+	"@monthly":  "0 0 0 1 * * *",
+	"@weekly":   "0 0 0 * * 0 *",
+	"@daily":    "0 0 0 * * * *",
+	"@midnight": "0 0 0 * * * *",
+	"@hourly":   "0 0 * * * * *",
+	"@minutely": "0 * * * * * *",
+	"@secondly": "* * * * * * *",
+}
 
-	return nil, fmt.Errorf("unrecognized descriptor: %s", descriptor)
+const every = "@every "
+
+// parseEvery returns the interval schedule for an "@every" descriptor.
+func parseEvery(descriptor string) (Schedule, error) {
+	duration, err := time.ParseDuration(descriptor[len(every):])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse duration %s: %s", descriptor, err)
+	}
+	return Every(duration), nil
 }
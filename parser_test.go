@@ -1,6 +1,7 @@
 package cron
 
 import (
+	"errors"
 	"math/big"
 	"reflect"
 	"strings"
@@ -10,8 +11,6 @@ import (
 
 var secondParser = NewParser(Second | Minute | Hour | Dom | Month | DowOptional | Descriptor)
 
-var quartzParser = NewParser(Second | Minute | Hour | Dom | Month | Dow | YearOptional)
-
 func TestRange(t *testing.T) {
 	var zero *big.Int
 	ranges := []struct {
@@ -38,7 +37,17 @@ func TestRange(t *testing.T) {
 		{"0-99", 0, 130, big.NewInt(0).SetBytes([]byte{0xf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}), ""},
 		{"63-100", 1, 130, big.NewInt(0).SetBytes([]byte{0x1f, 0xff, 0xff, 0xff, 0xff, 0x80, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}), ""},
 
+		{"5-", 0, 7, big.NewInt(1<<5 | 1<<6 | 1<<7), ""},
+		{"-2", 0, 7, big.NewInt(1<<0 | 1<<1 | 1<<2), ""},
+		{"-2", 1, 7, big.NewInt(1<<1 | 1<<2), ""},
+		{"2-/2", 0, 7, big.NewInt(1<<2 | 1<<4 | 1<<6), ""},
+		{"-5/2", 0, 7, big.NewInt(1<<0 | 1<<2 | 1<<4), ""},
+
 		{"5--5", 0, 0, zero, "too many hyphens"},
+		{"-", 0, 7, zero, "no endpoints"},
+		{"-/2", 0, 7, zero, "no endpoints"},
+		{"8-", 0, 7, zero, "beyond end of range"},
+		{"-8", 0, 7, zero, "above maximum"},
 		{"jan-x", 0, 0, zero, "failed to parse int from"},
 		{"2-x", 1, 5, zero, "failed to parse int from"},
 		{"*/-12", 0, 0, zero, "negative number"},
@@ -64,6 +73,48 @@ func TestRange(t *testing.T) {
 	}
 }
 
+func TestDayBounds(t *testing.T) {
+	valid := []string{"1", "31", "l", "L", "5l", "7l", "1-31", "1-31/2", "*/40", "l,5l,15"}
+	for _, field := range valid {
+		if _, err := ParseStandard("0 0 " + field + " * *"); err != nil {
+			t.Errorf("%s: unexpected error %v", field, err)
+		}
+	}
+	for _, field := range []string{"0", "1", "6", "sunl", "sat", "satl"} {
+		if _, err := ParseStandard("0 0 * * " + field); err != nil {
+			t.Errorf("%s: unexpected error %v", field, err)
+		}
+	}
+
+	// Out of range days are rejected rather than clamped, including the
+	// values that the names of the last days stand for.
+	tests := []struct {
+		spec, err string
+	}{
+		{"0 0 32 * *", "above maximum"},
+		{"0 0 0 * *", "below minimum"},
+		{"0 0 0-5 * *", "below minimum"},
+		{"0 0 1-32 * *", "above maximum"},
+		{"0 0 8l * *", "failed to parse int"},
+		{"0 0 32l * *", "failed to parse int"},
+		{"0 0 50 * *", "above maximum"},
+		{"0 0 1-5l * *", "above maximum"},
+		{"0 0 30-l * *", "above maximum"},
+		{"0 0 5l-l * *", "above maximum"},
+		{"0 0 5l/2 * *", "beyond end of range"},
+		{"0 0 * * 7", "above maximum"},
+		{"0 0 * * 7l", "failed to parse int"},
+		{"0 0 * * 50", "above maximum"},
+		{"0 0 * * 1-fril", "above maximum"},
+		{"0 0 * * monl-fril", "above maximum"},
+	}
+	for _, c := range tests {
+		if _, err := ParseStandard(c.spec); err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: expected %q, got %v", c.spec, c.err, err)
+		}
+	}
+}
+
 func TestField(t *testing.T) {
 	fields := []struct {
 		expr     string
@@ -85,6 +136,131 @@ func TestField(t *testing.T) {
 	}
 }
 
+func TestOpenRanges(t *testing.T) {
+	tests := []struct {
+		spec, expected string
+	}{
+		{"5- * * * *", "5-59 * * * *"},
+		{"-30 * * * *", "0-30 * * * *"},
+		{"10-/5 * * * *", "10-59/5 * * * *"},
+		{"0 9 -15 * *", "0 9 1-15 * *"},
+		{"0 9 * oct- *", "0 9 * oct-dec *"},
+		{"0 9 * * wed-", "0 9 * * wed-sat"},
+		{"0 9 * * -wed", "0 9 * * sun-wed"},
+		{"0 9 * * mon,thu-", "0 9 * * mon,thu-sat"},
+	}
+	for _, c := range tests {
+		actual, err := ParseStandard(c.spec)
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		expected, _ := ParseStandard(c.expected)
+		if !actual.(*SpecSchedule).Equal(expected.(*SpecSchedule)) {
+			t.Errorf("%s: %v", c.spec, actual.(*SpecSchedule).Diff(expected.(*SpecSchedule)))
+		}
+		// String always gives both endpoints.
+		if actual.(*SpecSchedule).String() != expected.(*SpecSchedule).String() {
+			t.Errorf("%s: expected %s, got %s", c.spec, expected.(*SpecSchedule).String(), actual.(*SpecSchedule).String())
+		}
+	}
+
+	sched, err := quartzParser.Parse("0 0 0 * * ? 2030-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := sched.(*SpecSchedule).String(), "0 0 0 * * * 2030-2099"; !strings.HasSuffix(actual, expected) {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+
+	// Like "29-31", "29-" runs on the last day of shorter months.
+	clamped, _ := NewParser(Minute | Hour | Dom | Month | Dow | ClampDom).Parse("0 9 29- * *")
+	expected, _ := NewParser(Minute | Hour | Dom | Month | Dow | ClampDom).Parse("0 9 29-31 * *")
+	if !clamped.(*SpecSchedule).Equal(expected.(*SpecSchedule)) {
+		t.Errorf("29-: %v", clamped.(*SpecSchedule).Diff(expected.(*SpecSchedule)))
+	}
+
+	for _, spec := range []string{"- * * * *", "-/5 * * * *", "0 9 * * -", "0 9 * * wed--"} {
+		if _, err := ParseStandard(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}
+
+func TestParseField(t *testing.T) {
+	actual, err := ParseField("MON-FRI", 0, 6, dow.names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sched, err := secondParser.Parse("* * * * * MON-FRI")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := sched.(*SpecSchedule).Dow; actual.Cmp(expected) != 0 {
+		t.Errorf("expected %b, got %b", expected, actual)
+	}
+
+	if _, err := ParseField("7", 0, 6, nil); err == nil {
+		t.Error("expected an error for a value out of range")
+	}
+}
+
+func TestFormatField(t *testing.T) {
+	tests := []struct {
+		field    string
+		r        bounds
+		expected string
+	}{
+		{"*", minutes, "*"},
+		{"0-59", minutes, "*"},
+		{"*/15", minutes, "*/15"},
+		{"0,15,30,45", minutes, "*/15"},
+		{"5/15", minutes, "5/15"},
+		{"5-35/10", minutes, "5-35/10"},
+		{"1,2,3,7,9,10", hours, "1-3,7,9-10"},
+		{"0,30", minutes, "0,30"},
+		{"5", minutes, "5"},
+		{"jan-mar,dec", months, "1-3,12"},
+		{"MON-FRI", dow, "1-5"},
+		{"1,15,L,2L", dom, "1,15,2l,l"},
+		{"sunL,6L", dow, "0l,6l"},
+		{"2024-2026", years, "2024-2026"},
+		{"1990/10", years, "1990/10"},
+	}
+	for _, c := range tests {
+		bits, err := getField(c.field, c.r)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", c.field, err)
+			continue
+		}
+		actual := FormatField(bits, c.r.min, c.r.max, c.r.names)
+		if actual != c.expected {
+			t.Errorf("%s => expected %s, got %s", c.field, c.expected, actual)
+		}
+
+		// Formatting round trips, apart from the star bit.
+		parsed, err := ParseField(actual, c.r.min, c.r.max, c.r.names)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", actual, err)
+			continue
+		}
+		parsed.SetBit(parsed, maxBits, 0)
+		bits.SetBit(bits, maxBits, 0)
+		if parsed.Cmp(bits) != 0 {
+			t.Errorf("%s => expected %b, got %b", actual, bits, parsed)
+		}
+	}
+
+	// Flags without a token are left out.
+	clamped, err := NewParser(Minute | Hour | Dom | Month | Dow | ClampDom).Parse("0 9 15,31 * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := FormatField(clamped.(*SpecSchedule).Dom, dom.min, dom.max, dom.names); actual != "15,31" {
+		t.Errorf("expected 15,31, got %s", actual)
+	}
+}
+
 func TestAll(t *testing.T) {
 	allBits := []struct {
 		r        bounds
@@ -147,6 +323,241 @@ func TestParseScheduleErrors(t *testing.T) {
 	}
 }
 
+func TestParseWithLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		spec     string
+		loc      *time.Location
+		expected string
+	}{
+		{"0 9 * * *", tokyo, "Asia/Tokyo"},
+		{"0 9 * * *", time.UTC, "UTC"},
+		{"@daily", tokyo, "Asia/Tokyo"},
+		{"0 9 * * *", nil, "Local"},
+		{"CRON_TZ=America/New_York 0 9 * * *", tokyo, "America/New_York"},
+		{"TZ=UTC 0 9 * * *", nil, "UTC"},
+	}
+	for _, c := range tests {
+		sched, err := ParseWithLocation(c.spec, c.loc)
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		if sched.Location.String() != c.expected {
+			t.Errorf("%s in %v: expected %s, got %s", c.spec, c.loc, c.expected, sched.Location)
+		}
+		expected, _ := ParseStandard(c.spec)
+		if !sched.InLocation(time.UTC).Equal(expected.(*SpecSchedule).InLocation(time.UTC)) {
+			t.Errorf("%s: expected the same fields as ParseStandard", c.spec)
+		}
+	}
+
+	next := MustParseWithLocation("0 9 * * *", tokyo).Next(time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC))
+	if expected := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); !next.Equal(expected) {
+		t.Errorf("expected 9am in Tokyo to be %v, got %v", expected, next)
+	}
+
+	for _, spec := range []string{"", "0 9 * *", "@every 1h", "TZ=Nowhere/Special 0 9 * * *"} {
+		if _, err := ParseWithLocation(spec, tokyo); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	MustParseWithLocation("0 9 * *", tokyo)
+}
+
+func TestParseFlexible(t *testing.T) {
+	tests := []struct {
+		spec     string
+		layout   FieldLayout
+		expected string
+	}{
+		{"30 9 * * MON", StandardLayout, "0 30 9 * * MON *"},
+		{"15 30 9 * * MON", SecondsLayout, "15 30 9 * * MON *"},
+		{"15 30 9 * * MON 2030", SecondsYearLayout, "15 30 9 * * MON 2030"},
+		{"TZ=UTC 30 9 * * MON", StandardLayout, "TZ=UTC 0 30 9 * * MON *"},
+		{"  15   30 9 * *\tMON ", SecondsLayout, "15 30 9 * * MON *"},
+	}
+	for _, c := range tests {
+		sched, layout, err := ParseFlexible(c.spec)
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		if layout != c.layout {
+			t.Errorf("%s: expected the %s layout, got %s", c.spec, c.layout, layout)
+		}
+		expected, err := layoutParsers[SecondsYearLayout].Parse(c.expected)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := sched.(*SpecSchedule).Diff(expected.(*SpecSchedule)); diff != nil {
+			t.Errorf("%s: %v", c.spec, diff)
+		}
+	}
+
+	for _, spec := range []string{"", "* * * *", "* * * * * * * *", "@daily", "@every 1h", "60 * * * *", "TZ=UTC"} {
+		if sched, layout, err := ParseFlexible(spec); err == nil {
+			t.Errorf("%q: expected an error, got %v as %s", spec, sched, layout)
+		}
+	}
+
+	if _, err := standardParser.Parse("0 30 9 * * MON"); err == nil {
+		t.Error("expected Parse to remain strict")
+	}
+}
+
+func TestEmptyExpression(t *testing.T) {
+	for _, spec := range []string{"", "   ", "\t", "\t\n"} {
+		if _, err := standardParser.Parse(spec); !errors.Is(err, ErrEmptyExpression) {
+			t.Errorf("%q: expected ErrEmptyExpression, got %v", spec, err)
+		}
+		if _, err := ParseStandard(spec); !errors.Is(err, ErrEmptyExpression) {
+			t.Errorf("%q: expected ErrEmptyExpression from ParseStandard, got %v", spec, err)
+		}
+	}
+	if errs := secondParser.Validate("  "); len(errs) != 1 || !errors.Is(errs[0], ErrEmptyExpression) {
+		t.Errorf("expected ErrEmptyExpression from Validate, got %v", errs)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	errs := secondParser.Validate("99 60 25 32 13 8")
+	expected := []string{"second", "minute", "hour", "dom", "month", "dow"}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+	for i, err := range errs {
+		if err.Field != expected[i] {
+			t.Errorf("error %d: expected field %s, got %s", i, expected[i], err.Field)
+		}
+		if !strings.Contains(err.Error(), "above maximum") {
+			t.Errorf("error %d: expected an out of range error, got %v", i, err)
+		}
+	}
+
+	errs = secondParser.Validate("0 5 x * * y")
+	if len(errs) != 2 || errs[0].Field != "hour" || errs[1].Field != "dow" {
+		t.Errorf("expected hour and dow errors, got %v", errs)
+	}
+	if errs[0].Value != "x" {
+		t.Errorf("expected offending token x, got %s", errs[0].Value)
+	}
+
+	errs = secondParser.Validate("* * * *")
+	if len(errs) != 1 || errs[0].Field != "" {
+		t.Errorf("expected a single spec-level error, got %v", errs)
+	}
+
+	if errs := secondParser.Validate("0 5 * * * *"); errs != nil {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestWeekStartsMonday(t *testing.T) {
+	mondayParser := NewParser(Minute | Hour | Dom | Month | Dow | WeekStartsMonday)
+	tests := []struct {
+		dow            string
+		sunday, monday []int
+	}{
+		{"0", []int{0}, []int{1}},
+		{"6", []int{6}, []int{0}},
+		{"0-4", []int{0, 1, 2, 3, 4}, []int{1, 2, 3, 4, 5}},
+		{"5-6", []int{5, 6}, []int{6, 0}},
+		{"*/2", []int{0, 2, 4, 6}, []int{1, 3, 5, 0}},
+		{"mon-fri", []int{1, 2, 3, 4, 5}, []int{1, 2, 3, 4, 5}},
+		{"mon-fri/2", []int{1, 3, 5}, []int{1, 3, 5}},
+		{"sun", []int{0}, []int{0}},
+		{"0L", []int{49}, []int{50}},
+		{"6L", []int{55}, []int{49}},
+		{"sunL", []int{49}, []int{49}},
+		{"0#2", []int{nthWeekdayBit(2, 0)}, []int{nthWeekdayBit(2, 1)}},
+		{"sun#1", []int{nthWeekdayBit(1, 0)}, []int{nthWeekdayBit(1, 0)}},
+	}
+	for _, c := range tests {
+		for _, p := range []struct {
+			parser   Parser
+			expected []int
+		}{{standardParser, c.sunday}, {mondayParser, c.monday}} {
+			sched, err := p.parser.Parse("0 0 * * " + c.dow)
+			if err != nil {
+				t.Errorf("%s => unexpected error %v", c.dow, err)
+				continue
+			}
+			expected := big.NewInt(0)
+			for _, bit := range p.expected {
+				expected.SetBit(expected, bit, 1)
+			}
+			actual := new(big.Int).SetBit(sched.(*SpecSchedule).Dow, maxBits, 0)
+			if actual.Cmp(expected) != 0 {
+				t.Errorf("%s => expected %b, got %b", c.dow, expected, actual)
+			}
+		}
+	}
+
+	// Descriptors mean the same whatever the numbering: @weekly is Sunday.
+	weekly, err := NewParser(Minute | Hour | Dom | Month | Dow | Descriptor | WeekStartsMonday).Parse("@weekly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC) // a Monday
+	if next, expected := weekly.Next(from), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC); !next.Equal(expected) {
+		t.Errorf("expected @weekly on Sunday %v, got %v", expected, next)
+	}
+}
+
+func TestTwoDigitYears(t *testing.T) {
+	yearParser := NewParser(Minute | Hour | Dom | Month | Dow | Year)
+	tests := []struct {
+		parser   Parser
+		year     string
+		expected []int // or nil for an error
+	}{
+		{yearParser, "2024", []int{2024}},
+		{yearParser, "24", nil},
+		{yearParser, "2024-2026", []int{2024, 2025, 2026}},
+		{NewParser(Minute | Hour | Dom | Month | Dow | Year | TwoDigitYears), "24", []int{2024}},
+		{yearParser.WithYearPivot(0), "24", []int{2024}},
+		{yearParser.WithYearPivot(0), "99", []int{1999}},
+		{yearParser.WithYearPivot(0), "70", []int{1970}},
+		{yearParser.WithYearPivot(0), "00", []int{2000}},
+		{yearParser.WithYearPivot(0), "69", []int{2069}},
+		{yearParser.WithYearPivot(0), "98-02", []int{1998, 1999, 2000, 2001, 2002}},
+		{yearParser.WithYearPivot(0), "2024", []int{2024}},
+		{yearParser.WithYearPivot(0), "4", nil},
+		{yearParser.WithYearPivot(30), "30", nil}, // 1930
+		{yearParser.WithYearPivot(30), "29", []int{2029}},
+		{yearParser.WithYearPivot(100), "99", []int{2099}},
+	}
+	for _, c := range tests {
+		sched, err := c.parser.Parse("0 0 * * * " + c.year)
+		if c.expected == nil {
+			if err == nil {
+				t.Errorf("%s => expected an error", c.year)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", c.year, err)
+			continue
+		}
+		expected := big.NewInt(0)
+		for _, year := range c.expected {
+			expected.SetBit(expected, year-minYear, 1)
+		}
+		if actual := sched.(*SpecSchedule).Year; actual.Cmp(expected) != 0 {
+			t.Errorf("%s => expected %b, got %b", c.year, expected, actual)
+		}
+	}
+}
+
 func TestParseSchedule(t *testing.T) {
 	tokyo, _ := time.LoadLocation("Asia/Tokyo")
 	entries := []struct {
@@ -189,7 +600,7 @@ func TestParseSchedule(t *testing.T) {
 		if err != nil {
 			t.Errorf("%s => unexpected error %v", c.expr, err)
 		}
-		if !reflect.DeepEqual(actual, c.expected) {
+		if !reflect.DeepEqual(withoutSource(actual), c.expected) {
 			t.Errorf("%s => expected %b, got %b", c.expr, c.expected, actual)
 		}
 	}
@@ -211,7 +622,7 @@ func TestOptionalSecondSchedule(t *testing.T) {
 		if err != nil {
 			t.Errorf("%s => unexpected error %v", c.expr, err)
 		}
-		if !reflect.DeepEqual(actual, c.expected) {
+		if !reflect.DeepEqual(withoutSource(actual), c.expected) {
 			t.Errorf("%s => expected %b, got %b", c.expr, c.expected, actual)
 		}
 	}
@@ -228,55 +639,67 @@ func TestNormalizeFields(t *testing.T) {
 			"AllFields_NoOptional",
 			[]string{"0", "5", "*", "*", "*", "*"},
 			Second | Minute | Hour | Dom | Month | Dow | Descriptor,
-			[]string{"0", "5", "*", "*", "*", "*", "*"},
+			[]string{"0", "5", "*", "*", "*", "*", "*", "*"},
 		},
 		{
 			"AllQuartzFields_NoOptional",
 			[]string{"1", "1", "1", "1", "1", "1", "1999"},
 			Second | Minute | Hour | Dom | Month | Dow | Year,
-			[]string{"1", "1", "1", "1", "1", "1", "1999"},
+			[]string{"1", "1", "1", "1", "1", "1", "1999", "*"},
 		},
 		{
 			"AllQuartzFields_YearOptional",
 			[]string{"1", "1", "1", "1", "1", "1"},
 			Second | Minute | Hour | Dom | Month | Dow | YearOptional,
-			[]string{"1", "1", "1", "1", "1", "1", "*"},
+			[]string{"1", "1", "1", "1", "1", "1", "*", "*"},
 		},
 		{
 			"AllFields_SecondOptional_Provided",
 			[]string{"0", "5", "*", "*", "*", "*"},
 			SecondOptional | Minute | Hour | Dom | Month | Dow | Descriptor,
-			[]string{"0", "5", "*", "*", "*", "*", "*"},
+			[]string{"0", "5", "*", "*", "*", "*", "*", "*"},
 		},
 		{
 			"AllFields_SecondOptional_NotProvided",
 			[]string{"5", "*", "*", "*", "*"},
 			SecondOptional | Minute | Hour | Dom | Month | Dow | Descriptor,
-			[]string{"0", "5", "*", "*", "*", "*", "*"},
+			[]string{"0", "5", "*", "*", "*", "*", "*", "*"},
 		},
 		{
 			"SubsetFields_NoOptional",
 			[]string{"5", "15", "*"},
 			Hour | Dom | Month,
-			[]string{"0", "0", "5", "15", "*", "*", "*"},
+			[]string{"0", "0", "5", "15", "*", "*", "*", "*"},
 		},
 		{
 			"SubsetFields_DowOptional_Provided",
 			[]string{"5", "15", "*", "4"},
 			Hour | Dom | Month | DowOptional,
-			[]string{"0", "0", "5", "15", "*", "4", "*"},
+			[]string{"0", "0", "5", "15", "*", "4", "*", "*"},
 		},
 		{
 			"SubsetFields_DowOptional_NotProvided",
 			[]string{"5", "15", "*"},
 			Hour | Dom | Month | DowOptional,
-			[]string{"0", "0", "5", "15", "*", "*", "*"},
+			[]string{"0", "0", "5", "15", "*", "*", "*", "*"},
 		},
 		{
 			"SubsetFields_SecondOptional_NotProvided",
 			[]string{"5", "15", "*"},
 			SecondOptional | Hour | Dom | Month,
-			[]string{"0", "0", "5", "15", "*", "*", "*"},
+			[]string{"0", "0", "5", "15", "*", "*", "*", "*"},
+		},
+		{
+			"DowOptional_WithYearAndWeek",
+			[]string{"5", "15", "*", "2024", "1"},
+			Hour | Dom | Month | DowOptional | Year | Week,
+			[]string{"0", "0", "5", "15", "*", "*", "2024", "1"},
+		},
+		{
+			"YearOptional_WithWeek",
+			[]string{"1", "1", "1", "1", "1", "1", "26"},
+			Second | Minute | Hour | Dom | Month | Dow | YearOptional | Week,
+			[]string{"1", "1", "1", "1", "1", "1", "*", "26"},
 		},
 	}
 
@@ -346,7 +769,7 @@ func TestStandardSpecSchedule(t *testing.T) {
 	}{
 		{
 			expr:     "5 * * * *",
-			expected: &SpecSchedule{big.NewInt(1 << seconds.min), big.NewInt(1 << 5), all(hours), all(dom), all(months), all(dow), all(years), time.Local},
+			expected: &SpecSchedule{big.NewInt(1 << seconds.min), big.NewInt(1 << 5), all(hours), all(dom), all(months), all(dow), all(years), time.Local, nil, nil, nil, "", ""},
 		},
 		{
 			expr:     "@every 5m",
@@ -370,7 +793,7 @@ func TestStandardSpecSchedule(t *testing.T) {
 		if len(c.err) == 0 && err != nil {
 			t.Errorf("%s => unexpected error %v", c.expr, err)
 		}
-		if !reflect.DeepEqual(actual, c.expected) {
+		if !reflect.DeepEqual(withoutSource(actual), c.expected) {
 			t.Errorf("%s => expected %b, got %b", c.expr, c.expected, actual)
 		}
 	}
@@ -385,15 +808,15 @@ func TestNoDescriptorParser(t *testing.T) {
 }
 
 func every5min(loc *time.Location) *SpecSchedule {
-	return &SpecSchedule{big.NewInt(1 << 0), big.NewInt(1 << 5), all(hours), all(dom), all(months), all(dow), all(years), loc}
+	return &SpecSchedule{big.NewInt(1 << 0), big.NewInt(1 << 5), all(hours), all(dom), all(months), all(dow), all(years), loc, nil, nil, nil, "", ""}
 }
 
 func every5min5s(loc *time.Location) *SpecSchedule {
-	return &SpecSchedule{big.NewInt(1 << 5), big.NewInt(1 << 5), all(hours), all(dom), all(months), all(dow), all(years), loc}
+	return &SpecSchedule{big.NewInt(1 << 5), big.NewInt(1 << 5), all(hours), all(dom), all(months), all(dow), all(years), loc, nil, nil, nil, "", ""}
 }
 
 func midnight(loc *time.Location) *SpecSchedule {
-	return &SpecSchedule{big.NewInt(1), big.NewInt(1), big.NewInt(1), all(dom), all(months), all(dow), all(years), loc}
+	return &SpecSchedule{big.NewInt(1), big.NewInt(1), big.NewInt(1), all(dom), all(months), all(dow), all(years), loc, nil, nil, nil, "", ""}
 }
 
 func everyNYearSince(loc *time.Location, since, n int) *SpecSchedule {
@@ -413,6 +836,17 @@ func everyNYearSince(loc *time.Location, since, n int) *SpecSchedule {
 	}
 }
 
+// withoutSource returns the schedule with the spec it was parsed from
+// forgotten, to compare with a schedule built by hand.
+func withoutSource(sched Schedule) Schedule {
+	if s, ok := sched.(*SpecSchedule); ok {
+		c := *s
+		c.raw, c.comment = "", ""
+		return &c
+	}
+	return sched
+}
+
 func annual(loc *time.Location) *SpecSchedule {
 	return &SpecSchedule{
 		Second:   big.NewInt(1 << seconds.min),
@@ -425,3 +859,274 @@ func annual(loc *time.Location) *SpecSchedule {
 		Location: loc,
 	}
 }
+
+func TestDomAndDow(t *testing.T) {
+	andParser := NewParser(Second | Minute | Hour | Dom | Month | Dow | DomAndDow)
+	sched, err := andParser.Parse("0 0 0 1-7 * MON")
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	for _, day := range []string{"2024-01-01", "2024-02-05", "2024-03-04", "2024-04-01", "2024-05-06"} {
+		next = sched.Next(next.Add(-time.Nanosecond))
+		if actual := next.Format("2006-01-02"); actual != day {
+			t.Errorf("expected the first Monday %s, got %s", day, actual)
+		}
+		next = next.Add(time.Second)
+	}
+
+	// String gives the option as "&", which any parser accepts.
+	spec := sched.(*SpecSchedule).String()
+	if expected := "0 0 0 &1-7 * 1 *"; spec != expected {
+		t.Errorf("expected %s, got %s", expected, spec)
+	}
+	reparsed, _, err := ParseFlexible(spec)
+	if err != nil || !reparsed.(*SpecSchedule).Equal(sched.(*SpecSchedule)) {
+		t.Errorf("expected %s to parse back to the same schedule, got %v", spec, err)
+	}
+	if amp, err := secondParser.Parse("0 0 0 &1-7 * MON"); err != nil || !amp.(*SpecSchedule).Equal(sched.(*SpecSchedule)) {
+		t.Errorf("expected \"&\" to act as DomAndDow, got %v", err)
+	}
+
+	// Without the option, either field matches.
+	sched, _ = secondParser.Parse("0 0 0 1-7 * MON")
+	if next := sched.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)); next.Day() != 2 {
+		t.Errorf("expected the 2nd of January, got %v", next)
+	}
+
+	// With a "*" day field, both fields must match anyway.
+	star, _ := andParser.Parse("0 0 0 * * MON")
+	plain, _ := secondParser.Parse("0 0 0 * * MON")
+	if !star.(*SpecSchedule).Equal(plain.(*SpecSchedule)) {
+		t.Error("expected the option to make no difference to a \"*\" day of month")
+	}
+}
+
+func TestClampDom(t *testing.T) {
+	clampParser := NewParser(Second | Minute | Hour | Dom | Month | Dow | ClampDom)
+	tests := []struct {
+		spec     string
+		from     time.Time
+		expected []string
+	}{
+		{"0 0 0 31 * *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local),
+			[]string{"2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30", "2024-05-31"}},
+		{"0 0 0 31 * *", time.Date(2023, 2, 1, 0, 0, 0, 0, time.Local),
+			[]string{"2023-02-28", "2023-03-31"}},
+		{"0 0 0 30,31 * *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local),
+			[]string{"2024-01-30", "2024-01-31", "2024-02-29", "2024-03-30", "2024-03-31", "2024-04-30", "2024-05-30"}},
+		{"0 0 0 15,29-31 * *", time.Date(2023, 2, 1, 0, 0, 0, 0, time.Local),
+			[]string{"2023-02-15", "2023-02-28", "2023-03-15", "2023-03-29"}},
+		{"0 0 0 */10 * *", time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local),
+			[]string{"2024-02-11", "2024-02-21", "2024-03-01"}},
+	}
+	for _, c := range tests {
+		sched, err := clampParser.Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next := c.from
+		for _, day := range c.expected {
+			prev := next
+			next = sched.Next(next)
+			if actual := next.Format("2006-01-02"); actual != day {
+				t.Errorf("%s: expected %s, got %s", c.spec, day, actual)
+			}
+			if latest := sched.Latest(next); !latest.Equal(next) {
+				t.Errorf("%s: expected latest %v, got %v", c.spec, next, latest)
+			}
+			if latest := sched.Latest(next.Add(-time.Second)); prev != c.from && !latest.Equal(prev) {
+				t.Errorf("%s: expected the activation before %v to be %v, got %v", c.spec, next, prev, latest)
+			}
+		}
+	}
+
+	// Without the option, short months are skipped.
+	sched, _ := secondParser.Parse("0 0 0 31 * *")
+	if next := sched.Next(time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local)); next.Month() != time.March {
+		t.Errorf("expected the 31st of March, got %v", next)
+	}
+}
+
+func TestFullNames(t *testing.T) {
+	tests := []struct {
+		full, abbreviated string
+	}{
+		{"0 9 * * monday-friday", "0 9 * * mon-fri"},
+		{"0 9 * * Wednesday", "0 9 * * wed"},
+		{"0 9 * january,JULY *", "0 9 * jan,jul *"},
+		{"0 9 * sept-december *", "0 9 * sep-dec *"},
+		{"0 9 * * thur,tues", "0 9 * * thu,tue"},
+		{"0 9 * * satu/2", "0 9 * * sat/2"},
+	}
+	for _, c := range tests {
+		full, err := ParseStandard(c.full)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", c.full, err)
+			continue
+		}
+		abbreviated, _ := ParseStandard(c.abbreviated)
+		if !full.(*SpecSchedule).Equal(abbreviated.(*SpecSchedule)) {
+			t.Errorf("%s => expected the same schedule as %s", c.full, c.abbreviated)
+		}
+	}
+
+	// Canonical forms don't depend on the spelling.
+	sched, _ := ParseStandard("0 9 * january-march monday-friday")
+	s := sched.(*SpecSchedule)
+	if actual := FormatField(s.Month, months.min, months.max, months.names); actual != "1-3" {
+		t.Errorf("expected 1-3, got %s", actual)
+	}
+	if actual := FormatField(s.Dow, dow.min, dow.max, dow.names); actual != "1-5" {
+		t.Errorf("expected 1-5, got %s", actual)
+	}
+
+	bad := []struct {
+		spec, err string
+	}{
+		{"0 9 * * wendesday", "did you mean wednesday?"},
+		{"0 9 * * tu", "failed to parse int from tu"}, // ambiguous, and too short
+		{"0 9 * * thu-fryday", "did you mean friday?"},
+		{"0 9 * decmber *", "did you mean december?"},
+		{"0 9 * * xyz", "failed to parse int from xyz"},
+	}
+	for _, c := range bad {
+		_, err := ParseStandard(c.spec)
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s => expected %q, got %v", c.spec, c.err, err)
+		}
+	}
+	if _, err := ParseStandard("0 9 * * xyz"); strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("expected no suggestion for xyz, got %v", err)
+	}
+}
+
+func TestNamedSteps(t *testing.T) {
+	tests := []struct {
+		spec  string
+		month []int
+		dow   []int
+	}{
+		{"0 9 * * MON-FRI/2", nil, []int{1, 3, 5}},
+		{"0 9 * * mon-fri/2", nil, []int{1, 3, 5}},
+		{"0 9 * * Monday-Friday/2", nil, []int{1, 3, 5}},
+		{"0 9 * * sun-sat/3", nil, []int{0, 3, 6}},
+		{"0 9 * * tue/2", nil, []int{2, 4, 6}},
+		{"0 9 * JAN-DEC/2 *", []int{1, 3, 5, 7, 9, 11}, nil},
+		{"0 9 * feb-dec/2 *", []int{2, 4, 6, 8, 10, 12}, nil},
+		{"0 9 * jan/3 *", []int{1, 4, 7, 10}, nil},
+		{"0 9 * mar-aug/4 mon-wed/2", []int{3, 7}, []int{1, 3}},
+	}
+	for _, c := range tests {
+		sched, err := ParseStandard(c.spec)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", c.spec, err)
+			continue
+		}
+		s := sched.(*SpecSchedule)
+		for _, f := range []struct {
+			field    *big.Int
+			r        bounds
+			expected []int
+		}{{s.Month, months, c.month}, {s.Dow, dow, c.dow}} {
+			if f.expected == nil {
+				if !isAll(f.field, f.r) {
+					t.Errorf("%s => expected every value, got %b", c.spec, f.field)
+				}
+				continue
+			}
+			expected := big.NewInt(0)
+			for _, bit := range f.expected {
+				expected.SetBit(expected, bit, 1)
+			}
+			if f.field.Cmp(expected) != 0 {
+				t.Errorf("%s => expected %b, got %b", c.spec, expected, f.field)
+			}
+		}
+	}
+
+	// The range must still run forwards.
+	if _, err := ParseStandard("0 9 * * fri-mon/2"); err == nil {
+		t.Error("fri-mon/2 => expected an error")
+	}
+}
+
+func TestWithBounds(t *testing.T) {
+	deviceParser, err := secondParser.WithBounds(Second, 0, 29)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := deviceParser.Parse("29 * * * * *"); err != nil {
+		t.Errorf("expected second 29 to be accepted, got %v", err)
+	}
+	if _, err := deviceParser.Parse("30 * * * * *"); err == nil {
+		t.Error("expected second 30 to be rejected")
+	}
+	if _, err := secondParser.Parse("30 * * * * *"); err != nil {
+		t.Error("expected the original parser to be unchanged")
+	}
+
+	// A step runs up to the narrowed maximum.
+	sched, err := deviceParser.Parse("*/10 * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	for _, expected := range []int{10, 20, 60, 70, 80, 120} {
+		next = sched.Next(next)
+		if actual := int(next.Sub(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)).Seconds()); actual != expected {
+			t.Errorf("expected %ds, got %ds", expected, actual)
+		}
+	}
+
+	for _, c := range []struct {
+		place    ParseOption
+		min, max uint
+	}{{Dom, 1, 35}, {Dow, 0, 7}, {Second, 10, 5}, {Second, 0, 99}, {Month, 0, 12}, {Second | Minute, 0, 99}} {
+		if _, err := secondParser.WithBounds(c.place, c.min, c.max); err == nil {
+			t.Errorf("%d %d-%d => expected an error", c.place, c.min, c.max)
+		}
+	}
+}
+
+func TestLenientLists(t *testing.T) {
+	lenient := NewParser(Minute | Hour | Dom | Month | Dow | LenientLists)
+	for spec, expected := range map[string]string{
+		"0 0 9, 12 * *":         "0 0 9,12 * *",
+		"0 0 9 ,12 * *":         "0 0 9,12 * *",
+		"0 0 9 , 12 * *":        "0 0 9,12 * *",
+		"0, 30 9, 12, 15 * * *": "0,30 9,12,15 * * *",
+		"0 0 9,12 * mon, fri":   "0 0 9,12 * mon,fri",
+		"0 0 9,12 * *":          "0 0 9,12 * *",
+	} {
+		sched, err := lenient.Parse(spec)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", spec, err)
+			continue
+		}
+		if !sched.(*SpecSchedule).Equal(mustParse(t, expected).(*SpecSchedule)) {
+			t.Errorf("%s => expected %s, got %s", spec, expected, sched)
+		}
+	}
+
+	// The strict parser counts the fragments as fields.
+	if _, err := standardParser.Parse("0 0 9, 12 * *"); err == nil || !strings.Contains(err.Error(), "found 6") {
+		t.Errorf("expected a field count error, got %v", err)
+	}
+
+	bad := []struct {
+		parser    Parser
+		spec, err string
+	}{
+		{lenient, "0 0 9 12 * *", `expected at most 5 fields, found 6: "*" at position 6 is one too many`},
+		{lenient, "0 0 9, 12 * * 2024", `"2024" at position 6 is one too many`},
+		{NewParser(Second | Minute | Hour | Dom | Month | Dow | LenientLists), "0 0 9, 12 * *", "expected exactly 6 fields, found 5: [0 0 9,12 * *]"},
+		{NewParser(SecondOptional | Minute | Hour | Dom | Month | Dow | LenientLists), "0 0 0 9, 12 * * *", `"*" at position 7 is one too many`},
+	}
+	for _, c := range bad {
+		_, err := c.parser.Parse(c.spec)
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s => expected %q, got %v", c.spec, c.err, err)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package cron
+
+import (
+	"math/big"
+	"time"
+)
+
+// periodSamples is the number of activations Period looks at.
+const periodSamples = 100
+
+// Period returns the most common gap between consecutive activations of the
+// schedule, and whether every gap is the same. Simple daily and weekly
+// schedules are recognized from their fields; otherwise the next activations
+// from now are sampled. It returns 0 and false if the schedule activates at
+// most once. Gaps are never all the same if dates are excluded, as the
+// excluded dates leave longer ones, even if they aren't sampled.
+func (s *SpecSchedule) Period() (time.Duration, bool) {
+	if s.singleTimeOfDay() && isAll(s.Dom, dom) && isAll(s.Month, months) &&
+		isAll(s.Year, years) && s.WeekOfYear == nil && len(s.excluded) == 0 {
+		switch {
+		case isAll(s.Dow, dow):
+			return 24 * time.Hour, true
+		case countBits(s.Dow, dow) == 1 && s.Dow.Bit(maxBits) == 0 && s.bothDays() &&
+			!hasFlags(s.Dom, dom) && !hasFlags(s.Dow, dow):
+			// Only a "*" day of month, or DomAndDow, leaves the day of week to
+			// decide: "1-31" matches every day under cron's OR rule.
+			return 7 * 24 * time.Hour, true
+		}
+	}
+
+	counts := make(map[time.Duration]int)
+	prev := s.Next(time.Now())
+	for i := 1; i < periodSamples && !prev.IsZero(); i++ {
+		next := s.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		counts[next.Sub(prev)]++
+		prev = next
+	}
+	var mode time.Duration
+	for gap, n := range counts {
+		if n > counts[mode] || n == counts[mode] && gap < mode {
+			mode = gap
+		}
+	}
+	return mode, len(counts) == 1 && len(s.excluded) == 0
+}
+
+// IsHourly reports whether the schedule activates exactly once an hour.
+func (s *SpecSchedule) IsHourly() bool {
+	period, exact := s.Period()
+	return exact && period == time.Hour
+}
+
+// IsDaily reports whether the schedule activates exactly once a day.
+func (s *SpecSchedule) IsDaily() bool {
+	period, exact := s.Period()
+	return exact && period == 24*time.Hour
+}
+
+// IsMonthly reports whether the schedule activates about once a month, i.e.
+// its most common gap is between 28 and 31 days (give or take an hour, for
+// daylight savings).
+func (s *SpecSchedule) IsMonthly() bool {
+	period, _ := s.Period()
+	return period >= 28*24*time.Hour-time.Hour && period <= 31*24*time.Hour+time.Hour
+}
+
+// IsPeriodic reports whether every pair of consecutive activations is the
+// same duration apart, judged from the fields alone: the schedule must repeat
+// a single time within a uniform cycle of milliseconds, seconds, minutes,
+// hours, days, or weeks, and run in every month and year. A field's values are uniform if
+// there is just one, or if they step evenly and wrap around to the first by
+// the same step, like "*/15" or "5-59/15" for minutes but not "*/7".
+// Gaps are measured in wall-clock time in the schedule's location, so a daily
+// schedule is periodic even though days around a daylight savings change are
+// an hour shorter or longer. Excluded dates leave gaps, so a schedule with any
+// is not periodic.
+func (s *SpecSchedule) IsPeriodic() bool {
+	if !isAll(s.Month, months) || !isAll(s.Year, years) || s.WeekOfYear != nil || len(s.excluded) > 0 {
+		return false
+	}
+
+	domAll, dowAll := isAll(s.Dom, dom), isAll(s.Dow, dow)
+	and := s.bothDays()
+	daily := domAll && dowAll || !and && (domAll || dowAll)
+	weekly := and && domAll && countBits(s.Dow, dow) == 1
+
+	// From the largest unit down, skip the units that are all set. The
+	// first one that isn't must be uniform, and those below it single.
+	units := []shape{
+		{all: daily, uniform: daily || weekly},
+		fieldShape(s.Hour, hours),
+		fieldShape(s.Minute, minutes),
+		fieldShape(s.Second, seconds),
+		fieldShape(s.milliseconds(), milliseconds),
+	}
+	for i, u := range units {
+		if u.all {
+			continue
+		}
+		if !u.uniform {
+			return false
+		}
+		for _, below := range units[i+1:] {
+			if !below.single {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+// shape describes the values set in one unit of a schedule: whether it has
+// them all, evenly spaced ones, or just one.
+type shape struct{ all, uniform, single bool }
+
+// fieldShape returns the shape of a time of day field.
+func fieldShape(bits *big.Int, r bounds) (u shape) {
+	var values []uint
+	for i := r.min; i <= r.max; i++ {
+		if bits.Bit(int(i)) == 1 {
+			values = append(values, i)
+		}
+	}
+	u.all = len(values) == int(r.max-r.min+1)
+	u.single = len(values) == 1
+	u.uniform = u.single
+	if len(values) > 1 {
+		step := values[1] - values[0]
+		u.uniform = values[len(values)-1]+step == r.max+1+values[0]-r.min
+		for i := 2; i < len(values) && u.uniform; i++ {
+			u.uniform = values[i]-values[i-1] == step
+		}
+	}
+	return u
+}
+
+// singleTimeOfDay reports whether the schedule activates at one time of day.
+func (s *SpecSchedule) singleTimeOfDay() bool {
+	return countBits(s.milliseconds(), milliseconds) == 1 &&
+		countBits(s.Second, seconds) == 1 &&
+		countBits(s.Minute, minutes) == 1 &&
+		countBits(s.Hour, hours) == 1
+}
+
+// isAll reports whether every value of the field is set.
+func isAll(bits *big.Int, r bounds) bool {
+	return countBits(bits, r) == int(r.max-r.min+1)
+}
+
+// countBits returns the number of values of the field that are set, ignoring
+// any flags beyond its bounds.
+func countBits(bits *big.Int, r bounds) int {
+	n := 0
+	for i := r.min; i <= r.max; i++ {
+		n += int(bits.Bit(int(i)))
+	}
+	return n
+}
@@ -0,0 +1,132 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriod(t *testing.T) {
+	tests := []struct {
+		spec    string
+		period  time.Duration
+		exact   bool
+		hourly  bool
+		daily   bool
+		monthly bool
+	}{
+		{"30 9 * * *", 24 * time.Hour, true, false, true, false},
+		{"0 18 * * FRI", 7 * 24 * time.Hour, true, false, false, false},
+		{"TZ=UTC 15 * * * *", time.Hour, true, true, false, false},
+		{"TZ=UTC */5 * * * *", 5 * time.Minute, true, false, false, false},
+		{"TZ=UTC 0 9 * * MON-FRI", 24 * time.Hour, false, false, false, false},
+		{"TZ=UTC 0 0 1 * *", 31 * 24 * time.Hour, false, false, false, true},
+		{"TZ=UTC 0 9 1-31 * MON", 24 * time.Hour, true, false, true, false},
+		{"TZ=UTC 0 9 * * MON,FRI#2", 7 * 24 * time.Hour, false, false, false, false},
+	}
+
+	for _, c := range tests {
+		sched := mustParse(t, c.spec).(*SpecSchedule)
+		period, exact := sched.Period()
+		if period != c.period || exact != c.exact {
+			t.Errorf("%s: got %v, %v; want %v, %v", c.spec, period, exact, c.period, c.exact)
+		}
+		if got := sched.IsHourly(); got != c.hourly {
+			t.Errorf("%s: IsHourly = %v", c.spec, got)
+		}
+		if got := sched.IsDaily(); got != c.daily {
+			t.Errorf("%s: IsDaily = %v", c.spec, got)
+		}
+		if got := sched.IsMonthly(); got != c.monthly {
+			t.Errorf("%s: IsMonthly = %v", c.spec, got)
+		}
+	}
+}
+
+func TestPeriodNoActivations(t *testing.T) {
+	sched := mustParse(t, "0 0 30 2 *").(*SpecSchedule)
+	if period, exact := sched.Period(); period != 0 || exact {
+		t.Errorf("got %v, %v; want 0, false", period, exact)
+	}
+}
+
+func TestIsPeriodic(t *testing.T) {
+	tests := []struct {
+		spec     string
+		periodic bool
+	}{
+		{"@every 1h", true},
+		{"* * * * *", true},
+		{"0 12 * * *", true},
+		{"@hourly", true},
+		{"*/15 * * * *", true},
+		{"0 */6 * * *", true},
+		{"0 0 * * SUN", true},
+		{"0 0 1-31 * *", true},
+		{"0 0 1-31 * MON", true},
+		{"5-59/15 * * * *", true},
+		{"5-50/15 * * * *", true},
+
+		{"0 9-17 * * *", false},
+		{"0 9,17 * * *", false},
+		{"0 9 * * MON-FRI", false},
+		{"*/7 * * * *", false},
+		{"0 */5 * * *", false},
+		{"5-45/15 * * * *", false},
+		{"* 9 * * *", false},
+		{"*/15 9 * * *", false},
+		{"0 0 1 * *", false},
+		{"0 0 * * MON,FRI", false},
+		{"0 0 * JAN *", false},
+		{"0 0 */2 * *", false},
+		{"0 0 1 * MON", false},
+	}
+
+	for _, c := range tests {
+		sched := mustParse(t, c.spec).(interface{ IsPeriodic() bool })
+		if got := sched.IsPeriodic(); got != c.periodic {
+			t.Errorf("%s: IsPeriodic = %v, want %v", c.spec, got, c.periodic)
+		}
+	}
+}
+
+func TestPeriodExcludedDates(t *testing.T) {
+	sched, err := excludeParser.Parse("TZ=UTC 0 9 * * * !2024-12-25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sched.(*SpecSchedule)
+	if period, exact := s.Period(); period != 24*time.Hour || exact {
+		t.Errorf("got %v, %v; want 24h, false", period, exact)
+	}
+	if s.IsDaily() || s.IsPeriodic() {
+		t.Error("expected a schedule with excluded dates to be neither daily nor periodic")
+	}
+}
+
+func TestIsPeriodicMilliseconds(t *testing.T) {
+	tests := []struct {
+		spec     string
+		periodic bool
+	}{
+		{"*/250 * * * * * *", true},
+		{"500 0 0 9 * * *", true},
+		{"0,100 * * * * * *", false},
+		{"0,500 0 0 9 * * *", false},
+	}
+	for _, c := range tests {
+		sched, err := millisecondParser.Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := sched.(*SpecSchedule).IsPeriodic(); got != c.periodic {
+			t.Errorf("%s: IsPeriodic = %v, want %v", c.spec, got, c.periodic)
+		}
+	}
+	sched, err := millisecondParser.Parse("TZ=UTC 0,500 0 0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sched.(*SpecSchedule).IsDaily() {
+		t.Error("expected a schedule running twice a second at 9:00 not to be daily")
+	}
+}
@@ -0,0 +1,42 @@
+package cron
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// QuarterSchedule returns a schedule that activates at hour:minute on the
+// first day of each quarter of a year that starts in startMonth, i.e. in
+// startMonth and every third month after it. The schedule is in time.Local.
+func QuarterSchedule(startMonth time.Month, hour, minute int) (*SpecSchedule, error) {
+	if startMonth < time.January || startMonth > time.December {
+		return nil, fmt.Errorf("month (%d) out of range: 1-12", startMonth)
+	}
+	if hour < 0 || hour > 23 {
+		return nil, fmt.Errorf("hour (%d) out of range: 0-23", hour)
+	}
+	if minute < 0 || minute > 59 {
+		return nil, fmt.Errorf("minute (%d) out of range: 0-59", minute)
+	}
+	month := new(big.Int)
+	for i := 0; i < 4; i++ {
+		month.SetBit(month, (int(startMonth)-1+3*i)%12+1, 1)
+	}
+	return &SpecSchedule{
+		Second:   big.NewInt(1),
+		Minute:   new(big.Int).SetBit(new(big.Int), minute, 1),
+		Hour:     new(big.Int).SetBit(new(big.Int), hour, 1),
+		Dom:      big.NewInt(1 << 1),
+		Month:    month,
+		Dow:      all(dow),
+		Year:     all(years),
+		Location: time.Local,
+	}, nil
+}
+
+// CalendarQuarterSchedule returns a schedule that activates at hour:minute on
+// the first day of January, April, July and October.
+func CalendarQuarterSchedule(hour, minute int) (*SpecSchedule, error) {
+	return QuarterSchedule(time.January, hour, minute)
+}
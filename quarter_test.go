@@ -0,0 +1,64 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuarterSchedule(t *testing.T) {
+	calendar, err := CalendarQuarterSchedule(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fiscal, err := QuarterSchedule(time.February, 9, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		sched    *SpecSchedule
+		expected []time.Time
+	}{
+		{calendar, []time.Time{
+			time.Date(2024, 4, 1, 0, 0, 0, 0, time.Local),
+			time.Date(2024, 7, 1, 0, 0, 0, 0, time.Local),
+			time.Date(2024, 10, 1, 0, 0, 0, 0, time.Local),
+			time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local),
+		}},
+		{fiscal, []time.Time{
+			time.Date(2024, 2, 1, 9, 30, 0, 0, time.Local),
+			time.Date(2024, 5, 1, 9, 30, 0, 0, time.Local),
+			time.Date(2024, 8, 1, 9, 30, 0, 0, time.Local),
+			time.Date(2024, 11, 1, 9, 30, 0, 0, time.Local),
+			time.Date(2025, 2, 1, 9, 30, 0, 0, time.Local),
+		}},
+	}
+	for _, c := range tests {
+		next := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+		for _, expected := range c.expected {
+			if next = c.sched.Next(next); !next.Equal(expected) {
+				t.Errorf("expected %v, got %v", expected, next)
+			}
+		}
+	}
+
+	if sched, _ := QuarterSchedule(time.November, 0, 0); !sched.Equal(mustQuarter(t, time.February)) {
+		t.Error("expected quarters starting in November and February to be the same")
+	}
+	for _, c := range []struct {
+		month        time.Month
+		hour, minute int
+	}{{0, 0, 0}, {13, 0, 0}, {time.January, 24, 0}, {time.January, -1, 0}, {time.January, 0, 60}} {
+		if _, err := QuarterSchedule(c.month, c.hour, c.minute); err == nil {
+			t.Errorf("%d %d:%d => expected an error", c.month, c.hour, c.minute)
+		}
+	}
+}
+
+func mustQuarter(t *testing.T, month time.Month) *SpecSchedule {
+	t.Helper()
+	sched, err := QuarterSchedule(month, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sched
+}
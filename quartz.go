@@ -0,0 +1,91 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// quartzParser parses Quartz expressions once their day fields are rewritten.
+var quartzParser = NewParser(Second | Minute | Hour | Dom | Month | Dow | YearOptional)
+
+// ParseQuartz parses a Quartz cron expression, of second, minute, hour, day of
+// month, month, day of week and an optional year, such as "0 0 12 ? * MON-FRI"
+// or "0 15 10 L * ? 2025". The schedule is in time.Local; InLocation moves it
+// to another location.
+//
+// As in Quartz, exactly one of the day fields must be "?", so the other alone
+// chooses the days, and the day of week is numbered from 1 (Sunday) to 7
+// (Saturday). The day of month accepts "L" for the last day, "L-3" for three
+// days before it, "15W" for the weekday nearest the 15th and "LW" for the last
+// weekday; the day of week accepts "6L" for the last Friday of the month and
+// "6#3" for the third.
+func ParseQuartz(expr string) (*SpecSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 6 && len(fields) != 7 {
+		return nil, fmt.Errorf("expected 6 or 7 fields, found %d: %s", len(fields), expr)
+	}
+	if err := fromQuartzDays(fields, 3, 5); err != nil {
+		return nil, err
+	}
+	sched, err := quartzParser.Parse(strings.Join(fields, " "))
+	if err != nil {
+		return nil, err
+	}
+	return sched.(*SpecSchedule), nil
+}
+
+// fromQuartzDays rewrites the day of month and day of week fields, at the
+// given indexes of fields, from Quartz's syntax to this package's. It returns
+// an error unless exactly one of them is "?".
+func fromQuartzDays(fields []string, dom, dow int) error {
+	if (fields[dom] == "?") == (fields[dow] == "?") {
+		return fmt.Errorf("exactly one of day of month and day of week must be ?: %s", strings.Join(fields, " "))
+	}
+
+	parts := strings.Split(fields[dom], ",")
+	for i, part := range parts {
+		// "L-3" is three days before the last, which is "3L" here.
+		if len(part) > 2 && strings.EqualFold(part[:2], "l-") && isNumber(part[2:]) {
+			parts[i] = part[2:] + "L"
+		}
+	}
+	fields[dom] = strings.Join(parts, ",")
+
+	var err error
+	fields[dow], err = fromQuartzDow(fields[dow])
+	return err
+}
+
+// fromQuartzDow renumbers a day of week field from 1-7, starting on Sunday, to
+// 0-6. "L" alone is Saturday, the last day of the week.
+func fromQuartzDow(field string) (string, error) {
+	parts := strings.Split(field, ",")
+	for i, part := range parts {
+		day, nth, isNth := strings.Cut(part, "#")
+		rangeAndStep := strings.SplitN(day, "/", 2)
+		ends := strings.Split(rangeAndStep[0], "-")
+		for j, end := range ends {
+			last := ""
+			if strings.EqualFold(end, "l") {
+				end = "7"
+			} else if n := len(end); n > 1 && isNumber(end[:n-1]) && strings.EqualFold(end[n-1:], "l") {
+				end, last = end[:n-1], "L"
+			}
+			if !isNumber(end) {
+				continue
+			}
+			n, _ := strconv.Atoi(end)
+			if n < 1 || n > 7 {
+				return "", fmt.Errorf("day of week (%d) must be from 1 to 7: %s", n, field)
+			}
+			ends[j] = strconv.Itoa(n-1) + last
+		}
+		rangeAndStep[0] = strings.Join(ends, "-")
+		parts[i] = strings.Join(rangeAndStep, "/")
+		if isNth {
+			parts[i] += "#" + nth
+		}
+	}
+	return strings.Join(parts, ","), nil
+}
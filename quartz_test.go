@@ -0,0 +1,94 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseQuartz(t *testing.T) {
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2024, month, day, hour, min, 0, 0, time.UTC)
+	}
+	// From is Friday 2024-03-01 at 00:00 UTC.
+	from := at(time.March, 1, 0, 0)
+
+	// Mostly the examples of the Quartz CronTrigger tutorial.
+	tests := []struct {
+		expr     string
+		expected []time.Time
+	}{
+		{"0 0 12 * * ?", []time.Time{at(3, 1, 12, 0), at(3, 2, 12, 0)}},
+		{"0 15 10 ? * *", []time.Time{at(3, 1, 10, 15), at(3, 2, 10, 15)}},
+		{"0 15 10 * * ? 2025", []time.Time{time.Date(2025, 1, 1, 10, 15, 0, 0, time.UTC)}},
+		{"0 0/5 14,18 * * ?", []time.Time{at(3, 1, 14, 0), at(3, 1, 14, 5)}},
+		{"0 0-5 14 * * ?", []time.Time{at(3, 1, 14, 0), at(3, 1, 14, 1)}},
+		{"0 10,44 14 ? 3 WED", []time.Time{at(3, 6, 14, 10), at(3, 6, 14, 44), at(3, 13, 14, 10)}},
+		{"0 0 12 ? * MON-FRI", []time.Time{at(3, 1, 12, 0), at(3, 4, 12, 0)}},
+		{"0 0 12 ? * 2-6", []time.Time{at(3, 1, 12, 0), at(3, 4, 12, 0)}},
+		{"0 0 12 ? * 1,7", []time.Time{at(3, 2, 12, 0), at(3, 3, 12, 0), at(3, 9, 12, 0)}},
+		{"0 15 10 15 * ?", []time.Time{at(3, 15, 10, 15), at(4, 15, 10, 15)}},
+		{"0 15 10 L * ?", []time.Time{at(3, 31, 10, 15), at(4, 30, 10, 15)}},
+		{"0 15 10 L-2 * ?", []time.Time{at(3, 29, 10, 15), at(4, 28, 10, 15)}},
+		{"0 15 10 ? * 6L", []time.Time{at(3, 29, 10, 15), at(4, 26, 10, 15)}},
+		{"0 15 10 ? * 6L 2024-2025", []time.Time{at(3, 29, 10, 15)}},
+		{"0 15 10 ? * 6#3", []time.Time{at(3, 15, 10, 15), at(4, 19, 10, 15)}},
+		{"0 15 10 ? * MON#1,FRI#5", []time.Time{at(3, 4, 10, 15), at(3, 29, 10, 15), at(4, 1, 10, 15)}},
+		{"0 0 12 1/5 * ?", []time.Time{at(3, 1, 12, 0), at(3, 6, 12, 0), at(3, 11, 12, 0)}},
+		{"0 11 11 11 11 ?", []time.Time{at(11, 11, 11, 11)}},
+		// The weekday nearest a Saturday is the Friday before, and nearest a
+		// Sunday the Monday after, but never in another month.
+		{"0 0 12 16W * ?", []time.Time{at(3, 15, 12, 0), at(4, 16, 12, 0), at(5, 16, 12, 0), at(6, 17, 12, 0)}},
+		{"0 0 12 1W * ?", []time.Time{at(3, 1, 12, 0), at(4, 1, 12, 0), at(5, 1, 12, 0), at(6, 3, 12, 0)}},
+		{"0 0 12 31W * ?", []time.Time{at(3, 29, 12, 0), at(5, 31, 12, 0), at(7, 31, 12, 0), at(8, 30, 12, 0)}},
+		{"0 0 12 LW * ?", []time.Time{at(3, 29, 12, 0), at(4, 30, 12, 0), at(5, 31, 12, 0), at(6, 28, 12, 0)}},
+	}
+	for _, c := range tests {
+		sched, err := ParseQuartz(c.expr)
+		if err != nil {
+			t.Errorf("%s: %v", c.expr, err)
+			continue
+		}
+		sched = sched.InLocation(time.UTC)
+		next := from
+		for _, expected := range c.expected {
+			if next = sched.Next(next); !next.Equal(expected) {
+				t.Errorf("%s: expected %v, got %v", c.expr, expected, next)
+				break
+			}
+		}
+		if latest := sched.Latest(c.expected[0]); !latest.Equal(c.expected[0]) {
+			t.Errorf("%s: expected latest %v, got %v", c.expr, c.expected[0], latest)
+		}
+
+		// String gives the days in the syntax of the other parsers.
+		again, _, err := ParseFlexible(sched.String())
+		if err != nil {
+			t.Errorf("%s: %s: %v", c.expr, sched, err)
+		} else if !again.(*SpecSchedule).Equal(sched) {
+			t.Errorf("%s: expected %s to parse to the same schedule", c.expr, sched)
+		}
+	}
+}
+
+func TestParseQuartzErrors(t *testing.T) {
+	for _, expr := range []string{
+		"0 0 12 * *",
+		"0 0 12 * * ? 2025 1",
+		"0 0 12 * * *",
+		"0 0 12 ? * ?",
+		"0 0 12 1 * MON",
+		"0 0 12 ? * 0",
+		"0 0 12 ? * 8",
+		"0 0 12 ? * 6#6",
+		"0 0 12 ? * 6#0",
+		"0 0 12 ? * 6L#2",
+		"0 0 12 32W * ?",
+		"0 0 12 0W * ?",
+		"0 0 12 L-9 * ?",
+		"0 0 12 * * ? 2100",
+	} {
+		if sched, err := ParseQuartz(expr); err == nil {
+			t.Errorf("%s: expected an error, got %v", expr, sched)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package cron
+
+import (
+	"math/big"
+	"time"
+)
+
+// Rebase returns a copy of the schedule restricted to the dates from from to
+// to, inclusive, as they read in their own locations, e.g. for a campaign that
+// runs for a limited time. The years are restricted to from.Year() through
+// to.Year(); if those are the same, the months to from.Month() through
+// to.Month(); and if those are the same too, the days to from.Day() through
+// to.Day(). A range within a month is therefore exact, while a longer one is
+// rounded out to whole months or years: rebased to December 15, 2024 through
+// January 15, 2025, the copy runs throughout 2024 and 2025.
+//
+// The copy runs no later than the end of to's year, so HasExpired reports it
+// expired from then on. A schedule with no activations in the range, such as
+// one that has expired already, gives a copy that never runs.
+func (s *SpecSchedule) Rebase(from, to time.Time) *SpecSchedule {
+	r := s.clone()
+	r.Year = and(r.Year, span(from.Year()-minYear, to.Year()-minYear, years))
+	if from.Year() != to.Year() {
+		return r
+	}
+	r.Month = and(r.Month, span(int(from.Month()), int(to.Month()), months))
+	if from.Month() != to.Month() {
+		return r
+	}
+	r.restrictDays(from.Year(), from.Month(), from.Day(), to.Day())
+	return r
+}
+
+// HasExpired reports whether the schedule has no activations after t.
+func (s *SpecSchedule) HasExpired(t time.Time) bool {
+	_, ok := s.NextOK(t)
+	return !ok
+}
+
+// span returns the bits from lo to hi, clamped to the bounds r, or none if
+// lo is after hi.
+func span(lo, hi int, r bounds) *big.Int {
+	if lo < int(r.min) {
+		lo = int(r.min)
+	}
+	if hi > int(r.max) {
+		hi = int(r.max)
+	}
+	if lo > hi {
+		return new(big.Int)
+	}
+	return getBits(uint(lo), uint(hi), 1)
+}
+
+// restrictDays restricts the schedule to the days first to last of the month.
+// The day of month field is narrowed if it has only plain days and a day field
+// given as "*", so that days must match both fields; otherwise narrowing it
+// would change which days it matches, and the days outside are excluded
+// instead.
+func (s *SpecSchedule) restrictDays(year int, month time.Month, first, last int) {
+	days := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if first <= 1 && last >= days {
+		return // the whole month
+	}
+	special := new(big.Int).Rsh(s.Dom, 32)
+	special.SetBit(special, maxBits-32, 0)
+	special.SetBit(special, domAndDowBit-32, 0)
+	if special.Sign() == 0 && s.bothDays() {
+		dowStar := s.Dow.Bit(maxBits) > 0
+		s.Dom = and(s.Dom, span(first, last, dom))
+		if !dowStar {
+			s.Dom.SetBit(s.Dom, domAndDowBit, 1)
+		}
+		return
+	}
+
+	excluded := make(map[int]bool, len(s.excluded)+31)
+	for key := range s.excluded {
+		excluded[key] = true
+	}
+	for d := 1; d <= days; d++ {
+		if d < first || d > last {
+			excluded[dateKey(year, month, d)] = true
+		}
+	}
+	s.excluded = excluded
+}
@@ -0,0 +1,70 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRebase(t *testing.T) {
+	utc := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+	}
+	days := func(year int, month time.Month, ds ...int) []time.Time {
+		var times []time.Time
+		for _, d := range ds {
+			times = append(times, utc(year, month, d))
+		}
+		return times
+	}
+	firstFifteen := days(2025, time.January, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15)
+
+	tests := []struct {
+		spec     string
+		from, to time.Time
+		expected []time.Time
+	}{
+		{"TZ=UTC 0 12 * * *", utc(2025, 1, 1), utc(2025, 1, 15), firstFifteen},
+		// The OR of the day fields, and last days, are kept.
+		{"TZ=UTC 0 12 10 * mon", utc(2025, 1, 1), utc(2025, 1, 15), days(2025, time.January, 6, 10, 13)},
+		{"TZ=UTC 0 12 * * mon", utc(2025, 1, 1), utc(2025, 1, 15), days(2025, time.January, 6, 13)},
+		{"TZ=UTC 0 12 L * *", utc(2025, 1, 20), utc(2025, 1, 31), days(2025, time.January, 31)},
+		{"TZ=UTC 0 12 L * *", utc(2025, 1, 1), utc(2025, 1, 15), nil},
+		{"TZ=UTC 0 12 15 * *", utc(2024, 10, 20), utc(2024, 12, 1), []time.Time{utc(2024, 10, 15), utc(2024, 11, 15), utc(2024, 12, 15)}},
+		{"TZ=UTC 0 12 * * *", utc(2025, 1, 15), utc(2025, 1, 1), nil},
+	}
+	for _, c := range tests {
+		sched := mustParse(t, c.spec).(*SpecSchedule).Rebase(c.from, c.to)
+		actual, _ := sched.Between(utc(2020, 1, 1), utc(2030, 1, 1))
+		if !equalTimes(actual, c.expected) {
+			t.Errorf("%s rebased to %v-%v: expected %v, got %v", c.spec, c.from, c.to, c.expected, actual)
+		}
+		if end := time.Date(c.to.Year(), 12, 31, 23, 59, 59, 0, time.UTC); !sched.HasExpired(end) {
+			t.Errorf("%s rebased to %v-%v: expected it to have expired", c.spec, c.from, c.to)
+		}
+	}
+
+	// Daily at noon, for December 2024 only.
+	daily := mustParse(t, "TZ=UTC 0 12 * * *").(*SpecSchedule)
+	december := daily.Rebase(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC))
+	actual, _ := december.Between(utc(2024, 1, 1), utc(2026, 1, 1))
+	if len(actual) != 31 || !actual[0].Equal(utc(2024, 12, 1)) || !actual[30].Equal(utc(2024, 12, 31)) {
+		t.Errorf("expected every day of December 2024, got %v", actual)
+	}
+	if expected := "TZ=UTC 0 0 12 * 12 * 2024"; december.String() != expected {
+		t.Errorf("expected %s, got %s", expected, december)
+	}
+	if daily.HasExpired(utc(2024, 12, 31)) || len(daily.ActiveYears()) != maxYear-minYear+1 {
+		t.Error("expected the original schedule to be unchanged")
+	}
+
+	// A schedule that has expired already never runs.
+	yearParser := NewParser(Minute | Hour | Dom | Month | Dow | Year)
+	expired, err := yearParser.Parse("TZ=UTC 0 12 * * * 2020")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rebased := expired.(*SpecSchedule).Rebase(utc(2024, 12, 1), utc(2024, 12, 31))
+	if next, ok := rebased.NextOK(utc(2000, 1, 1)); ok {
+		t.Errorf("expected no activations, got %v", next)
+	}
+}
@@ -0,0 +1,93 @@
+package cron
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ScheduleRegistry holds schedules by name, such as "nightly-backup", so that
+// they are parsed once and defined in one place. It is safe for concurrent
+// use. DefaultRegistry is one for the whole program; tests may create their
+// own.
+type ScheduleRegistry struct {
+	parser ScheduleParser
+
+	mu        sync.RWMutex
+	schedules map[string]*SpecSchedule
+}
+
+// DefaultRegistry is a registry of standard specs for the whole program.
+var DefaultRegistry = NewScheduleRegistry(nil)
+
+// NewScheduleRegistry returns an empty registry whose specs are parsed by the
+// given parser, or by ParseStandard's if it is nil.
+func NewScheduleRegistry(parser ScheduleParser) *ScheduleRegistry {
+	if parser == nil {
+		parser = standardParser
+	}
+	return &ScheduleRegistry{parser: parser, schedules: make(map[string]*SpecSchedule)}
+}
+
+// Register parses the spec and adds the schedule under the given name. It
+// returns an error if the spec doesn't parse to a cron schedule (an interval,
+// such as "@every 5m", doesn't), or if the name is already registered.
+func (r *ScheduleRegistry) Register(name, spec string) error {
+	sched, err := r.parser.Parse(spec)
+	if err != nil {
+		return fmt.Errorf("schedule %q: %v", name, err)
+	}
+	s, ok := sched.(*SpecSchedule)
+	if !ok {
+		return fmt.Errorf("schedule %q: not a cron schedule: %s", name, spec)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.schedules[name]; ok {
+		return fmt.Errorf("schedule %q is already registered", name)
+	}
+	r.schedules[name] = s
+	return nil
+}
+
+// MustRegister is like Register but panics if the schedule can't be
+// registered. It simplifies the initialization of global variables.
+func (r *ScheduleRegistry) MustRegister(name, spec string) {
+	if err := r.Register(name, spec); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns a copy of the schedule registered under the given name, and
+// whether there is one. Changing the copy does not change the registry.
+func (r *ScheduleRegistry) Lookup(name string) (*SpecSchedule, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.schedules[name]
+	if !ok {
+		return nil, false
+	}
+	c := s.clone()
+	c.raw, c.comment = s.raw, s.comment
+	return c, true
+}
+
+// Delete removes the schedule registered under the given name, if any.
+func (r *ScheduleRegistry) Delete(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.schedules, name)
+}
+
+// ListNames returns the names of the registered schedules, in sorted order.
+func (r *ScheduleRegistry) ListNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.schedules))
+	for name := range r.schedules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,98 @@
+package cron
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestScheduleRegistry(t *testing.T) {
+	r := NewScheduleRegistry(nil)
+	r.MustRegister("nightly-backup", "0 2 * * *")
+	if err := r.Register("hourly-report", "@hourly"); err != nil {
+		t.Fatal(err)
+	}
+
+	sched, ok := r.Lookup("nightly-backup")
+	if !ok {
+		t.Fatal("expected the schedule to be registered")
+	}
+	if expected := mustParse(t, "0 2 * * *").(*SpecSchedule); !sched.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, sched)
+	}
+	if names := r.ListNames(); !reflect.DeepEqual(names, []string{"hourly-report", "nightly-backup"}) {
+		t.Errorf("expected sorted names, got %v", names)
+	}
+
+	// Lookup returns a copy.
+	sched.Hour.SetBit(sched.Hour, 3, 1)
+	if again, _ := r.Lookup("nightly-backup"); again.Hour.Bit(3) != 0 {
+		t.Error("expected the registered schedule to be unchanged")
+	}
+
+	if err := r.Register("nightly-backup", "0 3 * * *"); err == nil {
+		t.Error("expected an error registering a name twice")
+	}
+	if err := r.Register("bad", "0 25 * * *"); err == nil {
+		t.Error("expected an error for a bad spec")
+	}
+	if err := r.Register("interval", "@every 5m"); err == nil {
+		t.Error("expected an error for an interval")
+	}
+
+	r.Delete("nightly-backup")
+	r.Delete("missing")
+	if _, ok := r.Lookup("nightly-backup"); ok {
+		t.Error("expected the schedule to be deleted")
+	}
+	if err := r.Register("nightly-backup", "0 3 * * *"); err != nil {
+		t.Errorf("expected a deleted name to be free, got %v", err)
+	}
+
+	seconds := NewScheduleRegistry(NewParser(Second | Minute | Hour | Dom | Month | Dow))
+	if err := seconds.Register("every-30s", "*/30 * * * * *"); err != nil {
+		t.Error(err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustRegister to panic")
+		}
+	}()
+	r.MustRegister("hourly-report", "@hourly")
+}
+
+func TestScheduleRegistryConcurrency(t *testing.T) {
+	r := NewScheduleRegistry(nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				name := fmt.Sprintf("job-%d-%d", i, j)
+				r.MustRegister(name, fmt.Sprintf("%d * * * *", j))
+				if _, ok := r.Lookup(name); !ok {
+					t.Errorf("expected %s to be registered", name)
+				}
+				r.ListNames()
+				if j%2 == 0 {
+					r.Delete(name)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if n := len(r.ListNames()); n != 8*25 {
+		t.Errorf("expected %d schedules, got %d", 8*25, n)
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	DefaultRegistry.MustRegister("test-default", "0 0 * * *")
+	defer DefaultRegistry.Delete("test-default")
+	if _, ok := DefaultRegistry.Lookup("test-default"); !ok {
+		t.Error("expected the schedule to be registered")
+	}
+}
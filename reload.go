@@ -0,0 +1,118 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// loadLocation loads a location by name. It is swapped out in tests.
+var loadLocation = time.LoadLocation
+
+// ReloadLocations reloads the time zone of every entry whose Schedule is a
+// SpecSchedule, by name, so that a long-running Cron picks up changes to the
+// time zone database, such as a country moving its daylight saving time. The
+// Next of each entry is computed again under the new rules. An activation that
+// already ran is not run again, even if the new rules move it later; one that
+// the new rules move into the past is skipped.
+//
+// Schedules in time.Local or UTC are left as they are, as are those whose
+// location has no name, such as one made by time.FixedZone with an empty name,
+// which are logged as errors. It returns the errors from loading locations,
+// joined, having reloaded every location that could be loaded. Schedules of
+// other types, including those wrapping a SpecSchedule, are left alone.
+//
+// OnUpdated hooks are called for each entry whose next activation moved. To
+// reload periodically, call ReloadLocations from a job of the Cron.
+func (c *Cron) ReloadLocations() error {
+	locs := make(map[*time.Location]*time.Location)
+	var errs []error
+	for _, e := range c.Entries() {
+		s, ok := e.Schedule.(*SpecSchedule)
+		if !ok || s.Location == nil || s.Location == time.Local || s.Location == time.UTC {
+			continue
+		}
+		if _, ok := locs[s.Location]; ok {
+			continue
+		}
+		name := s.Location.String()
+		if name == "" {
+			c.logger.Error(fmt.Errorf("location has no name"), "location not reloaded", "entry", e.ID)
+			continue
+		}
+		loc, err := loadLocation(name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reloading location %s: %w", name, err))
+			continue
+		}
+		locs[s.Location] = loc
+	}
+
+	c.runningMu.Lock()
+	var updated []entryUpdate
+	if !c.running {
+		updated = c.relocate(locs, time.Time{})
+	} else {
+		r := relocation{locs, make(chan []entryUpdate, 1)}
+		c.reload <- r
+		updated = <-r.updated
+	}
+	c.runningMu.Unlock()
+	c.entriesUpdated(updated)
+	return errors.Join(errs...)
+}
+
+// relocation asks the run loop to swap the locations of schedules, and to
+// reply with the entries whose next activation moved.
+type relocation struct {
+	locs    map[*time.Location]*time.Location
+	updated chan []entryUpdate
+}
+
+// entryUpdate holds snapshots of an entry from before and after a change.
+type entryUpdate struct {
+	old, new Entry
+}
+
+// relocate swaps each old location in locs for the new one in the schedules of
+// the entries, returning those whose next activation moved. If now is the zero
+// time the Cron isn't running, and Next is left for the run loop to compute.
+func (c *Cron) relocate(locs map[*time.Location]*time.Location, now time.Time) []entryUpdate {
+	var updated []entryUpdate
+	for _, e := range c.entries {
+		s, ok := e.Schedule.(*SpecSchedule)
+		if !ok || locs[s.Location] == nil {
+			continue
+		}
+		old := snapshotOf(e)
+		relocated := s.clone()
+		relocated.Location = locs[s.Location]
+		relocated.raw, relocated.comment = s.raw, s.comment // the same zone, reloaded
+		e.Schedule = relocated
+		if now.IsZero() {
+			continue
+		}
+
+		// Compute from the last activation, as the new rules place it, if
+		// that is later: it has run already.
+		from := now
+		if !e.Prev.IsZero() {
+			if prev := sameClock(e.Prev.In(s.Location), relocated.Location); prev.After(from) {
+				from = prev
+			}
+		}
+		e.Next = relocated.Next(from)
+		if !e.Next.Equal(old.Next) {
+			c.logger.Info("relocated", "now", now, "entry", e.ID, "next", e.Next)
+			updated = append(updated, entryUpdate{old, snapshotOf(e)})
+		}
+	}
+	return updated
+}
+
+// sameClock returns the time in loc that shows the same date and clock as t.
+func sameClock(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	return time.Date(year, month, day, hour, min, sec, t.Nanosecond(), loc)
+}
@@ -0,0 +1,101 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReloadLocations(t *testing.T) {
+	// The zone moves from UTC+2 to UTC+1 once its rules are reloaded.
+	zone := time.FixedZone("Test/Zone", 2*60*60)
+	defer func(load func(string) (*time.Location, error)) { loadLocation = load }(loadLocation)
+	loadLocation = func(name string) (*time.Location, error) {
+		if name == "Test/Zone" {
+			return time.FixedZone(name, 60*60), nil
+		}
+		return nil, fmt.Errorf("unknown time zone %s", name)
+	}
+
+	var (
+		mu      sync.Mutex
+		updated = make(map[EntryID]Entry)
+	)
+	hooks := EntryHooks{OnUpdated: func(old, new Entry) {
+		mu.Lock()
+		defer mu.Unlock()
+		updated[new.ID] = old
+	}}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := newFakeClock(start)
+	cron := New(WithLocation(time.UTC), WithLogger(DiscardLogger), WithEntryHooks(hooks))
+	cron.clock = fc
+	at := func(spec string, loc *time.Location) EntryID {
+		return cron.Schedule(MustParseWithLocation(spec, loc), FuncJob(func() {}))
+	}
+	ran := at("0 9 * * *", zone)
+	moved := at("0 12 * * *", zone)
+	unnamed := at("0 9 * * *", time.FixedZone("", 2*60*60))
+	utc := at("0 9 * * *", time.UTC)
+	unknown := at("0 9 * * *", time.FixedZone("Bad/Zone", 0))
+	cron.Start()
+	defer cron.Stop()
+
+	// 09:00 in the zone runs at 07:00 UTC; after the change 09:00 is at 08:00
+	// UTC, which is yet to come, but must not run again.
+	timer := <-fc.timers
+	fc.advance(timer)
+	fc.mu.Lock()
+	fc.now = time.Date(2024, 1, 1, 7, 30, 0, 0, time.UTC)
+	fc.mu.Unlock()
+	if err := cron.ReloadLocations(); err == nil || !strings.Contains(err.Error(), "Bad/Zone") {
+		t.Errorf("expected an error for Bad/Zone, got %v", err)
+	}
+
+	day := func(d, hour int) time.Time { return time.Date(2024, 1, d, hour, 0, 0, 0, time.UTC) }
+	for id, expected := range map[EntryID]time.Time{
+		ran:     day(2, 8),
+		moved:   day(1, 11),
+		unnamed: day(2, 7),
+		utc:     day(1, 9),
+		unknown: day(1, 9),
+	} {
+		if next := cron.Entry(id).Next; !next.Equal(expected) {
+			t.Errorf("entry %d: expected next %v, got %v", id, expected, next)
+		}
+	}
+	if loc := cron.Entry(ran).Schedule.(*SpecSchedule).Location; loc == zone || loc.String() != "Test/Zone" {
+		t.Errorf("expected the reloaded Test/Zone, got %v", loc)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(updated) != 2 || !updated[ran].Next.Equal(day(2, 7)) || !updated[moved].Next.Equal(day(1, 10)) {
+		t.Errorf("expected updates of entries %d and %d, got %v", ran, moved, updated)
+	}
+}
+
+func TestReloadLocationsStopped(t *testing.T) {
+	defer func(load func(string) (*time.Location, error)) { loadLocation = load }(loadLocation)
+	loadLocation = func(name string) (*time.Location, error) { return time.FixedZone(name, 0), nil }
+
+	updates := 0
+	cron := New(WithEntryHooks(EntryHooks{OnUpdated: func(old, new Entry) { updates++ }}))
+	sched := MustParseWithLocation("0 9 * * *", time.FixedZone("Test/Zone", 60*60))
+	id := cron.Schedule(sched, FuncJob(func() {}))
+	if err := cron.ReloadLocations(); err != nil {
+		t.Fatal(err)
+	}
+	s := cron.Entry(id).Schedule.(*SpecSchedule)
+	if _, offset := time.Date(2024, 1, 1, 0, 0, 0, 0, s.Location).Zone(); offset != 0 {
+		t.Errorf("expected the reloaded location, got offset %d", offset)
+	}
+	if sched.Location == s.Location || s.Source() != sched.Source() {
+		t.Errorf("expected a copy of %s in the new location, got %s", sched.Source(), s.Source())
+	}
+	if updates != 0 {
+		t.Errorf("expected no updates, got %d", updates)
+	}
+}
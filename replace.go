@@ -0,0 +1,101 @@
+package cron
+
+import "math/big"
+
+// WithSeconds returns a copy of the schedule that runs at the given seconds of
+// the minute, as a bit set, leaving the original unchanged. It panics if the
+// bits aren't a valid seconds field; TryWithSeconds returns the error instead.
+// The other With methods do the same for the other fields, so that they may be
+// chained: s.WithHours(mornings).WithDow(weekdays).
+func (s *SpecSchedule) WithSeconds(bits *big.Int) *SpecSchedule {
+	return mustReplace(s.TryWithSeconds(bits))
+}
+
+// WithMinutes is like WithSeconds, for the minutes of the hour.
+func (s *SpecSchedule) WithMinutes(bits *big.Int) *SpecSchedule {
+	return mustReplace(s.TryWithMinutes(bits))
+}
+
+// WithHours is like WithSeconds, for the hours of the day.
+func (s *SpecSchedule) WithHours(bits *big.Int) *SpecSchedule {
+	return mustReplace(s.TryWithHours(bits))
+}
+
+// WithDom is like WithSeconds, for the days of the month. Bits beyond 31 flag
+// the last days, clamped days and nearest weekdays of the month, and days
+// that must match the day of week as well (DomAndDow).
+func (s *SpecSchedule) WithDom(bits *big.Int) *SpecSchedule {
+	return mustReplace(s.TryWithDom(bits))
+}
+
+// WithMonth is like WithSeconds, for the months of the year, from 1.
+func (s *SpecSchedule) WithMonth(bits *big.Int) *SpecSchedule {
+	return mustReplace(s.TryWithMonth(bits))
+}
+
+// WithDow is like WithSeconds, for the days of the week, from 0 (Sunday). Bits
+// beyond 6 flag the last and nth weekdays of the month.
+func (s *SpecSchedule) WithDow(bits *big.Int) *SpecSchedule {
+	return mustReplace(s.TryWithDow(bits))
+}
+
+// WithYear is like WithSeconds, for the years, counted from 1970.
+func (s *SpecSchedule) WithYear(bits *big.Int) *SpecSchedule {
+	return mustReplace(s.TryWithYear(bits))
+}
+
+// TryWithSeconds is like WithSeconds, but returns a FieldError, as Validate
+// would, rather than panic.
+func (s *SpecSchedule) TryWithSeconds(bits *big.Int) (*SpecSchedule, error) {
+	return s.replace(Second, bits)
+}
+
+// TryWithMinutes is like WithMinutes, but returns an error rather than panic.
+func (s *SpecSchedule) TryWithMinutes(bits *big.Int) (*SpecSchedule, error) {
+	return s.replace(Minute, bits)
+}
+
+// TryWithHours is like WithHours, but returns an error rather than panic.
+func (s *SpecSchedule) TryWithHours(bits *big.Int) (*SpecSchedule, error) {
+	return s.replace(Hour, bits)
+}
+
+// TryWithDom is like WithDom, but returns an error rather than panic.
+func (s *SpecSchedule) TryWithDom(bits *big.Int) (*SpecSchedule, error) {
+	return s.replace(Dom, bits)
+}
+
+// TryWithMonth is like WithMonth, but returns an error rather than panic.
+func (s *SpecSchedule) TryWithMonth(bits *big.Int) (*SpecSchedule, error) {
+	return s.replace(Month, bits)
+}
+
+// TryWithDow is like WithDow, but returns an error rather than panic.
+func (s *SpecSchedule) TryWithDow(bits *big.Int) (*SpecSchedule, error) {
+	return s.replace(Dow, bits)
+}
+
+// TryWithYear is like WithYear, but returns an error rather than panic.
+func (s *SpecSchedule) TryWithYear(bits *big.Int) (*SpecSchedule, error) {
+	return s.replace(Year, bits)
+}
+
+// replace returns a copy of the schedule with a copy of bits in place of the
+// field at the given place, once they are validated against its bounds.
+func (s *SpecSchedule) replace(place ParseOption, bits *big.Int) (*SpecSchedule, error) {
+	i := placeIndex(place)
+	if errs := validateField(fieldNames[i], place, bits, *fieldBounds[i]); len(errs) > 0 {
+		return nil, errs[0]
+	}
+	c := s.clone()
+	field := []**big.Int{&c.Second, &c.Minute, &c.Hour, &c.Dom, &c.Month, &c.Dow, &c.Year}[i]
+	*field = new(big.Int).Set(bits)
+	return c, nil
+}
+
+func mustReplace(s *SpecSchedule, err error) *SpecSchedule {
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
@@ -0,0 +1,79 @@
+package cron
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestWithFields(t *testing.T) {
+	bits := func(values ...uint) *big.Int {
+		b := new(big.Int)
+		for _, v := range values {
+			b.SetBit(b, int(v), 1)
+		}
+		return b
+	}
+	s := mustParse(t, "TZ=UTC 0 9 * * *").(*SpecSchedule)
+	weekdays := getBits(1, 5, 1)
+	afternoons := s.WithHours(bits(14, 16)).WithDow(weekdays).WithMinutes(bits(30))
+
+	// From Saturday the 6th, the next run is Monday afternoon.
+	saturday := time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC)
+	if next := afternoons.Next(saturday); !next.Equal(time.Date(2024, 1, 8, 14, 30, 0, 0, time.UTC)) {
+		t.Errorf("expected Monday at 14:30, got %v", next)
+	}
+	if next := s.Next(saturday); !next.Equal(time.Date(2024, 1, 7, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the original to run on Sunday at 9:00, got %v", next)
+	}
+
+	// The copy doesn't share the bits it was given, nor the original's.
+	weekdays.SetBit(weekdays, 0, 1)
+	if afternoons.Dow.Bit(0) != 0 {
+		t.Error("expected the copy to keep its own bits")
+	}
+	s.Hour.SetBit(s.Hour, 10, 1)
+	if afternoons.Hour.Bit(10) != 0 || afternoons.Minute.Bit(0) != 0 {
+		t.Error("expected the copy to be independent of the original")
+	}
+
+	// Special bits and years are replaced alike.
+	last := mustParse(t, "TZ=UTC 0 12 * * *").(*SpecSchedule).
+		WithDom(bits(55)).WithMonth(bits(2)).WithYear(bits(2028 - minYear)).WithSeconds(bits(15))
+	if next := last.Next(saturday); !next.Equal(time.Date(2028, 2, 29, 12, 0, 15, 0, time.UTC)) {
+		t.Errorf("expected the last day of February 2028, got %v", next)
+	}
+
+	// Bits outside the field are errors.
+	tests := []struct {
+		try  func(*big.Int) (*SpecSchedule, error)
+		bits *big.Int
+		code ErrorCode
+	}{
+		{s.TryWithSeconds, bits(60), ErrBitOutOfRange},
+		{s.TryWithMinutes, bits(0, 75), ErrBitOutOfRange},
+		{s.TryWithHours, bits(24), ErrBitOutOfRange},
+		{s.TryWithDom, bits(0), ErrBitOutOfRange},
+		{s.TryWithMonth, bits(13), ErrBitOutOfRange},
+		{s.TryWithDow, bits(7), ErrBitOutOfRange},
+		{s.TryWithYear, bits(maxYear - minYear + 1), ErrBitOutOfRange},
+		{s.TryWithHours, new(big.Int), ErrNoActiveBits},
+		{s.TryWithHours, nil, ErrNoActiveBits},
+		{s.TryWithHours, bits(9, maxBits), ErrInvalidSentinel},
+	}
+	for i, c := range tests {
+		sched, err := c.try(c.bits)
+		var fe FieldError
+		if sched != nil || !errors.As(err, &fe) || fe.Code != c.code {
+			t.Errorf("%d: expected a %s error, got %v, %v", i, c.code, sched, err)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected WithSeconds to panic")
+		}
+	}()
+	s.WithSeconds(bits(60))
+}
@@ -0,0 +1,90 @@
+package cron
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// RobfigSpec has the fields of robfig/cron's SpecSchedule, which this package
+// was forked from, so that schedules compiled by either can be converted
+// without this package depending on that one. A Go type conversion turns one
+// into the other:
+//
+//	sched := cron.FromRobfig(cron.RobfigSpec(*robfigSched))
+//	robfigSched := robfig.SpecSchedule(r)
+//
+// Each field holds a bit per value, with the top bit set for a wildcard.
+type RobfigSpec struct {
+	Second, Minute, Hour, Dom, Month, Dow uint64
+
+	// Override location for this schedule.
+	Location *time.Location
+}
+
+// robfigStar is the bit robfig/cron sets for a field given as "*" or "?".
+const robfigStar = 63
+
+// FromRobfig returns the schedule compiled by robfig/cron as a SpecSchedule,
+// which runs at exactly the same times in every year and week. A nil location
+// is taken to be time.Local.
+func FromRobfig(r RobfigSpec) *SpecSchedule {
+	loc := r.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	sched := &SpecSchedule{Year: all(years), Location: loc}
+	fields := []**big.Int{&sched.Second, &sched.Minute, &sched.Hour, &sched.Dom, &sched.Month, &sched.Dow}
+	for i, bits := range []uint64{r.Second, r.Minute, r.Hour, r.Dom, r.Month, r.Dow} {
+		field := new(big.Int)
+		for v := fieldBounds[i].min; v <= fieldBounds[i].max; v++ {
+			field.SetBit(field, int(v), uint(bits>>v&1))
+		}
+		field.SetBit(field, maxBits, uint(bits>>robfigStar&1))
+		*fields[i] = field
+	}
+	return sched
+}
+
+// ToRobfig returns the schedule in the form compiled by robfig/cron. That
+// form has no years, weeks of the year, excluded dates, last days of the month,
+// nearest or nth weekdays or DomAndDow, so the conversion can lose them in the
+// same way as ToStandard, with a Warning for each:
+//
+//   - years and weeks of the year are dropped (WarnYearsDropped and
+//     WarnWeeksDropped), as are excluded dates (WarnDatesDropped);
+//   - last days and weekdays of the month, and days clamped by ClampDom, are
+//     widened to every day they could fall on (WarnLastDaysWidened), as are
+//     nearest weekdays and nth weekdays of the month (WarnWeekdaysWidened);
+//   - days required to match both day fields, by DomAndDow, are widened to
+//     days matching either (WarnDomAndDowWidened).
+//
+// Seconds and the location are kept, so the result runs at least whenever
+// the schedule does. It returns an error if the schedule runs at milliseconds
+// past the second, which robfig/cron can't express.
+func (s *SpecSchedule) ToRobfig() (RobfigSpec, []Warning, error) {
+	if s.subSecond() {
+		return RobfigSpec{}, nil, fmt.Errorf("runs at milliseconds %s, which robfig/cron can't express",
+			FormatField(s.milliseconds(), milliseconds.min, milliseconds.max, nil))
+	}
+	var warnings []Warning
+	fields := s.clone().fields()
+	s.widen(fields, collect(&warnings))
+
+	var bits [6]uint64
+	for i := range bits {
+		for v := fieldBounds[i].min; v <= fieldBounds[i].max; v++ {
+			bits[i] |= uint64(fields[i].Bit(int(v))) << v
+		}
+		bits[i] |= uint64(fields[i].Bit(maxBits)) << robfigStar
+	}
+	return RobfigSpec{
+		Second:   bits[0],
+		Minute:   bits[1],
+		Hour:     bits[2],
+		Dom:      bits[3],
+		Month:    bits[4],
+		Dow:      bits[5],
+		Location: s.EffectiveLocation(),
+	}, warnings, nil
+}
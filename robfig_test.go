@@ -0,0 +1,89 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// robfigMidnight is "0 0 * * *" as robfig/cron compiles it.
+var robfigMidnight = RobfigSpec{
+	Second:   1 << 0,
+	Minute:   1 << 0,
+	Hour:     1 << 0,
+	Dom:      1<<32 - 2 | 1<<robfigStar,
+	Month:    1<<13 - 2 | 1<<robfigStar,
+	Dow:      1<<7 - 1 | 1<<robfigStar,
+	Location: time.UTC,
+}
+
+func TestFromRobfig(t *testing.T) {
+	expected := mustParse(t, "TZ=UTC 0 0 * * *").(*SpecSchedule)
+	if actual := FromRobfig(robfigMidnight); !actual.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, actual.Diff(expected))
+	}
+
+	// Without a wildcard, either day field may match, as in this package.
+	r := robfigMidnight
+	r.Dom, r.Dow = 1<<1, 1<<1
+	expected = mustParse(t, "TZ=UTC 0 0 1 * MON").(*SpecSchedule)
+	if actual := FromRobfig(r); !actual.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, actual.Diff(expected))
+	}
+
+	r.Location = nil
+	if actual := FromRobfig(r); actual.Location != time.Local {
+		t.Errorf("expected time.Local, got %v", actual.Location)
+	}
+}
+
+func TestToRobfig(t *testing.T) {
+	actual, warnings, err := mustParse(t, "TZ=UTC 0 0 * * *").(*SpecSchedule).ToRobfig()
+	if actual != robfigMidnight || len(warnings) != 0 || err != nil {
+		t.Errorf("expected %+v, got %+v with %v, %v", robfigMidnight, actual, warnings, err)
+	}
+	if back, _, _ := FromRobfig(actual).ToRobfig(); back != actual {
+		t.Errorf("expected %+v to round-trip, got %+v", actual, back)
+	}
+
+	tests := []struct {
+		spec     string
+		expected string
+		codes    []WarningCode
+	}{
+		{"TZ=UTC 30 0 0 * * * *", "TZ=UTC 30 0 0 * * * *", nil},
+		{"TZ=UTC 0 0 0 L * * *", "TZ=UTC 0 0 0 28-31 * * *", []WarningCode{WarnLastDaysWidened}},
+		{"TZ=UTC 0 0 0 * * 5L *", "TZ=UTC 0 0 0 * * 5 *", []WarningCode{WarnLastDaysWidened}},
+		{"TZ=UTC 0 0 0 * * * 2030", "TZ=UTC 0 0 0 * * * *", []WarningCode{WarnYearsDropped}},
+	}
+	for _, c := range tests {
+		sched, err := quartzParser.Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, warnings, err := sched.(*SpecSchedule).ToRobfig()
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		expected, _ := quartzParser.Parse(c.expected)
+		if converted := FromRobfig(actual); !converted.Equal(expected.(*SpecSchedule)) {
+			t.Errorf("%s: %v", c.spec, converted.Diff(expected.(*SpecSchedule)))
+		}
+		if len(warnings) != len(c.codes) {
+			t.Errorf("%s: expected %v, got %v", c.spec, c.codes, warnings)
+			continue
+		}
+		for i, w := range warnings {
+			if w.Code != c.codes[i] {
+				t.Errorf("%s: expected %v, got %v", c.spec, c.codes[i], w.Code)
+			}
+		}
+	}
+	millis, err := millisecondParser.Parse("TZ=UTC 0,500 0 0 0 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := millis.(*SpecSchedule).ToRobfig(); err == nil {
+		t.Error("expected an error for a schedule running at milliseconds past the second")
+	}
+}
@@ -0,0 +1,94 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrAlreadyRunning is returned by RunWithContext if the Cron is already
+// running, by Start, Run or another call to RunWithContext.
+var ErrAlreadyRunning = errors.New("cron: already running")
+
+// ErrDrainTimeout is returned by RunWithContext, wrapped with the number of
+// jobs left running, if they don't finish within the drain timeout.
+var ErrDrainTimeout = errors.New("cron: timed out waiting for jobs to finish")
+
+const defaultDrainTimeout = 30 * time.Second
+
+// RunWithContext runs the scheduler until ctx is done, for a main function
+// that ties everything to one context. ContextJobs, such as those added with
+// AddTyped, are passed ctx, so in-flight runs see it cancelled too.
+//
+// Once ctx is done, the scheduler is stopped and RunWithContext waits for
+// running jobs to finish, up to the timeout set by WithDrainTimeout. It
+// returns nil if they all did, or ErrDrainTimeout, leaving the rest to finish
+// in the background. If the Cron is stopped first, by Stop, it drains the
+// same way and returns ErrStopped, joined with any drain error.
+func (c *Cron) RunWithContext(ctx context.Context) error {
+	c.runningMu.Lock()
+	if c.running {
+		c.runningMu.Unlock()
+		return ErrAlreadyRunning
+	}
+	c.running = true
+	c.setJobContext(ctx)
+	c.runningMu.Unlock()
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		c.run()
+	}()
+
+	var err error
+	select {
+	case <-ctx.Done():
+		c.Stop()
+	case <-stopped:
+		err = ErrStopped
+	}
+	return errors.Join(err, c.drain())
+}
+
+// drain waits for running jobs to finish, up to the drain timeout.
+func (c *Cron) drain() error {
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		c.jobWaiter.Wait()
+	}()
+	if c.drainTimeout <= 0 {
+		<-finished
+		return nil
+	}
+	timer := time.NewTimer(c.drainTimeout)
+	defer timer.Stop()
+	select {
+	case <-finished:
+		return nil
+	case <-timer.C:
+	}
+	running := 0
+	for _, e := range c.Entries() {
+		running += e.Running
+	}
+	return fmt.Errorf("%w: %d still running after %v", ErrDrainTimeout, running, c.drainTimeout)
+}
+
+func (c *Cron) setJobContext(ctx context.Context) {
+	c.waitMu.Lock()
+	defer c.waitMu.Unlock()
+	c.jobCtx = ctx
+}
+
+// jobContext returns the context to pass to ContextJobs.
+func (c *Cron) jobContext() context.Context {
+	c.waitMu.Lock()
+	defer c.waitMu.Unlock()
+	if c.jobCtx == nil {
+		return context.Background()
+	}
+	return c.jobCtx
+}
@@ -0,0 +1,96 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunWithContext(t *testing.T) {
+	fc := newFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	cron := New(WithParser(secondParser), WithChain(), WithLocation(time.UTC))
+	cron.clock = fc
+	started := make(chan struct{})
+	var jobErr error
+	finished := make(chan struct{})
+	AddTyped(cron, "* * * * * ?", "payload", func(ctx context.Context, _ string) error {
+		close(started)
+		<-ctx.Done()
+		jobErr = ctx.Err()
+		close(finished)
+		return nil
+	}, WithMaxRuns(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error)
+	go func() { result <- cron.RunWithContext(ctx) }()
+	fc.advance(<-fc.timers)
+	<-started
+
+	if err := cron.RunWithContext(ctx); !errors.Is(err, ErrAlreadyRunning) {
+		t.Errorf("expected ErrAlreadyRunning while running, got %v", err)
+	}
+	cancel()
+	if err := <-result; err != nil {
+		t.Errorf("expected a clean drain, got %v", err)
+	}
+	<-finished
+	if !errors.Is(jobErr, context.Canceled) {
+		t.Errorf("expected the job to see its context cancelled, got %v", jobErr)
+	}
+}
+
+func TestRunWithContextDrainTimeout(t *testing.T) {
+	fc := newFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	cron := New(WithParser(secondParser), WithChain(), WithLocation(time.UTC), WithDrainTimeout(20*time.Millisecond))
+	cron.clock = fc
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	// A job that ignores cancellation.
+	cron.AddFunc("* * * * * ?", func() {
+		close(started)
+		<-release
+	}, WithMaxRuns(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error)
+	go func() { result <- cron.RunWithContext(ctx) }()
+	fc.advance(<-fc.timers)
+	<-started
+	cancel()
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, ErrDrainTimeout) {
+			t.Errorf("expected ErrDrainTimeout, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("RunWithContext didn't return after the drain timeout")
+	}
+}
+
+func TestRunWithContextStopped(t *testing.T) {
+	cron := New()
+	cron.Start()
+	if err := cron.RunWithContext(context.Background()); !errors.Is(err, ErrAlreadyRunning) {
+		t.Errorf("expected ErrAlreadyRunning after Start, got %v", err)
+	}
+	cron.Stop()
+
+	result := make(chan error)
+	go func() { result <- cron.RunWithContext(context.Background()) }()
+	for !cron.isRunning() {
+		time.Sleep(time.Millisecond)
+	}
+	cron.Stop()
+	if err := <-result; !errors.Is(err, ErrStopped) {
+		t.Errorf("expected ErrStopped, got %v", err)
+	}
+}
+
+func (c *Cron) isRunning() bool {
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	return c.running
+}
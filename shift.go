@@ -0,0 +1,132 @@
+package cron
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Shift returns a copy of the schedule whose activations are each d later (or,
+// if d is negative, earlier), such as a job that runs 30 minutes after an
+// hourly one. The times of day are moved with carry, so shifting "0 45 9 * *"
+// by 30 minutes gives "0 15 10 * *", and a shift of 90 seconds moves a minute's
+// activations to 30 seconds past the next minute. Times are wall clock times
+// in the schedule's location, so a shift is not exact across a daylight saving
+// time transition.
+//
+// It returns an error if d is not a whole number of milliseconds, or if the
+// shifted times can't be expressed by the fields: for example, shifting 9:00
+// and 9:45 by 30 minutes gives 9:30 and 10:15, which are not every combination
+// of an hour and a minute, and shifting milliseconds 0 and 500 by 700ms moves
+// one into the next second but not the other. Activations moved to another
+// day may change the day of the week, but not the day of the month, the
+// month, the year or an excluded date; such shifts are an error unless those
+// fields include every value and no dates are excluded.
+func (s *SpecSchedule) Shift(d time.Duration) (*SpecSchedule, error) {
+	if d%time.Millisecond != 0 {
+		return nil, fmt.Errorf("shift %v is not a whole number of milliseconds", d)
+	}
+	millis, carrySecond, err := s.shiftMilliseconds(int(d % time.Second / time.Millisecond))
+	if err != nil {
+		return nil, fmt.Errorf("shifting by %v: %w", d, err)
+	}
+	const day = 24 * 60 * 60
+	offset := int(d/time.Second) + carrySecond
+
+	var (
+		second, minute, hour = new(big.Int), new(big.Int), new(big.Int)
+		times                = s.TimesOfDay()
+		carry                int
+		carries              = make(map[int]bool)
+	)
+	for _, tod := range times {
+		t := int(tod/time.Second) + offset
+		carry = t / day
+		if t < 0 && t%day != 0 {
+			carry--
+		}
+		t -= carry * day
+		carries[carry] = true
+		second.SetBit(second, t%60, 1)
+		minute.SetBit(minute, t/60%60, 1)
+		hour.SetBit(hour, t/3600, 1)
+	}
+	// The shifted times are distinct, so they are every combination of the
+	// fields only if there are as many of them.
+	if countBits(second, seconds)*countBits(minute, minutes)*countBits(hour, hours) != len(times) {
+		return nil, fmt.Errorf("shifting by %v gives times of day that the fields can't express", d)
+	}
+
+	shifted := s.clone()
+	shifted.Second, shifted.Minute, shifted.Hour = second, minute, hour
+	shifted.Millisecond = millis
+	for _, field := range []struct {
+		bits *big.Int
+		r    bounds
+	}{{second, seconds}, {minute, minutes}, {hour, hours}} {
+		if isAll(field.bits, field.r) {
+			field.bits.SetBit(field.bits, maxBits, 1)
+		}
+	}
+	if len(carries) == 0 || len(carries) == 1 && carry == 0 || s.everyDay() {
+		return shifted, nil
+	}
+	if len(carries) > 1 {
+		return nil, fmt.Errorf("shifting by %v moves some activations to another day", d)
+	}
+	if !isAll(s.Dom, dom) || !isAll(s.Month, months) || !isAll(s.Year, years) || s.WeekOfYear != nil ||
+		len(s.excluded) > 0 {
+		return nil, fmt.Errorf("shifting by %v moves activations to another day of the month", d)
+	}
+	if lastBits(s.Dow) != 0 {
+		return nil, fmt.Errorf("shifting by %v moves activations off the last weekday of the month", d)
+	}
+	for bit := nthWeekdayBit(1, 0); bit <= nthWeekdayBit(5, 6); bit++ {
+		if s.Dow.Bit(bit) > 0 {
+			return nil, fmt.Errorf("shifting by %v moves activations off the nth weekday of the month", d)
+		}
+	}
+	dow := new(big.Int)
+	for v := 0; v < 7; v++ {
+		dow.SetBit(dow, ((v+carry)%7+7)%7, s.Dow.Bit(v))
+	}
+	dow.SetBit(dow, maxBits, s.Dow.Bit(maxBits))
+	shifted.Dow = dow
+	return shifted, nil
+}
+
+// shiftMilliseconds returns the schedule's milliseconds field moved by the
+// given offset, less than a second either way, and the number of seconds that
+// moves every activation into, which must be the same for them all.
+func (s *SpecSchedule) shiftMilliseconds(offset int) (*big.Int, int, error) {
+	if offset == 0 {
+		return s.Millisecond, 0, nil
+	}
+	millis := new(big.Int)
+	carries := make(map[int]bool)
+	carry := 0
+	for v := 0; v <= int(milliseconds.max); v++ {
+		if s.milliseconds().Bit(v) == 0 {
+			continue
+		}
+		t := v + offset
+		carry = 0
+		if t < 0 {
+			carry = -1
+		} else if t > int(milliseconds.max) {
+			carry = 1
+		}
+		carries[carry] = true
+		millis.SetBit(millis, t-carry*1000, 1)
+	}
+	if len(carries) > 1 {
+		return nil, 0, fmt.Errorf("some activations move to another second")
+	}
+	return millis, carry, nil
+}
+
+// everyDay reports whether the schedule runs on every day.
+func (s *SpecSchedule) everyDay() bool {
+	return isAll(s.Dom, dom) && isAll(s.Dow, dow) && isAll(s.Month, months) &&
+		isAll(s.Year, years) && s.WeekOfYear == nil && len(s.excluded) == 0
+}
@@ -0,0 +1,117 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShift(t *testing.T) {
+	tests := []struct {
+		spec     string
+		d        time.Duration
+		expected string
+	}{
+		{"TZ=UTC 0 9 * * *", 30 * time.Minute, "TZ=UTC 30 9 * * *"},
+		{"TZ=UTC */10 * * * *", 5 * time.Minute, "TZ=UTC 5-59/10 * * * *"},
+		{"TZ=UTC 45 9 * * *", 30 * time.Minute, "TZ=UTC 15 10 * * *"},
+		{"TZ=UTC 0 * * * *", 90 * time.Second, "TZ=UTC 1 * * * *"},
+		{"TZ=UTC 0 9 * * *", -10 * time.Hour, "TZ=UTC 0 23 * * *"},
+		{"TZ=UTC 30 23 * * MON-FRI", time.Hour, "TZ=UTC 30 0 * * TUE-SAT"},
+		{"TZ=UTC 30 0 * * SUN", -time.Hour, "TZ=UTC 30 23 * * SAT"},
+		{"TZ=UTC 0 9 1 * *", 2 * time.Hour, "TZ=UTC 0 11 1 * *"},
+		{"TZ=UTC 0 9 * * *", 7 * 24 * time.Hour, "TZ=UTC 0 9 * * *"},
+		{"TZ=UTC 0 9 * * *", 0, "TZ=UTC 0 9 * * *"},
+	}
+	for _, c := range tests {
+		shifted, err := mustParse(t, c.spec).(*SpecSchedule).Shift(c.d)
+		if err != nil {
+			t.Errorf("%s by %v: %v", c.spec, c.d, err)
+			continue
+		}
+		expected := mustParse(t, c.expected).(*SpecSchedule)
+		if c.d%time.Minute != 0 {
+			expected.Second = shifted.Second
+		}
+		if !shifted.Equal(expected) {
+			t.Errorf("%s by %v: %v", c.spec, c.d, shifted.Diff(expected))
+		}
+	}
+
+	// Activations move by exactly d.
+	sched := mustParse(t, "TZ=UTC 0 9 * * *").(*SpecSchedule)
+	shifted, _ := sched.Shift(30 * time.Minute)
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	if expected := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC); !shifted.Next(from).Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, shifted.Next(from))
+	}
+	sched = mustParse(t, "TZ=UTC */10 * * * *").(*SpecSchedule)
+	shifted, _ = sched.Shift(90 * time.Second)
+	for next, i := from, 0; i < 10; i++ {
+		next = shifted.Next(next)
+		if expected := sched.Next(next.Add(-90*time.Second - time.Nanosecond)).Add(90 * time.Second); !next.Equal(expected) {
+			t.Errorf("expected %v, got %v", expected, next)
+		}
+		if next.Second() != 30 {
+			t.Errorf("expected 30 seconds past the minute, got %v", next)
+		}
+	}
+
+	errors := []struct {
+		spec string
+		d    time.Duration
+	}{
+		{"0 9 * * *", 1500 * time.Microsecond},
+		{"0,45 9 * * *", 30 * time.Minute},
+		{"0 9,23 * * MON", 2 * time.Hour},
+		{"0 23 1 * *", 2 * time.Hour},
+		{"0 23 * JAN *", 2 * time.Hour},
+		{"0 23 * * 5L", 2 * time.Hour},
+	}
+	for _, c := range errors {
+		if _, err := mustParse(t, c.spec).(*SpecSchedule).Shift(c.d); err == nil {
+			t.Errorf("%s by %v: expected an error", c.spec, c.d)
+		}
+	}
+}
+
+func TestShiftMilliseconds(t *testing.T) {
+	tests := []struct {
+		spec     string
+		d        time.Duration
+		expected string
+	}{
+		{"TZ=UTC 0,250 0 0 9 * * *", 500 * time.Millisecond, "TZ=UTC 500,750 0 0 9 * * *"},
+		{"TZ=UTC 500 0 0 9 * * *", 700 * time.Millisecond, "TZ=UTC 200 1 0 9 * * *"},
+		{"TZ=UTC 0 0 0 9 * * *", -1500 * time.Millisecond, "TZ=UTC 500 58 59 8 * * *"},
+		{"TZ=UTC 250 0 0 9 * * *", time.Minute, "TZ=UTC 250 0 1 9 * * *"},
+	}
+	for _, c := range tests {
+		sched, err := millisecondParser.Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		shifted, err := sched.(*SpecSchedule).Shift(c.d)
+		if err != nil {
+			t.Errorf("%s by %v: %v", c.spec, c.d, err)
+			continue
+		}
+		expected, err := millisecondParser.Parse(c.expected)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !shifted.Equal(expected.(*SpecSchedule)) {
+			t.Errorf("%s by %v: %v", c.spec, c.d, shifted.Diff(expected.(*SpecSchedule)))
+		}
+	}
+
+	sched, err := millisecondParser.Parse("TZ=UTC 0,500 0 0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sched.(*SpecSchedule).Shift(700 * time.Millisecond); err == nil {
+		t.Error("expected an error for a shift moving only some activations into the next second")
+	}
+	if _, err := sched.(*SpecSchedule).Shift(time.Microsecond); err == nil {
+		t.Error("expected an error for a shift of less than a millisecond")
+	}
+}
@@ -0,0 +1,27 @@
+package cron
+
+// shorthands are the descriptors Shorthand returns, in order of preference
+// where two mean the same, as "@yearly" and "@annually" do.
+var shorthands = []string{"@yearly", "@monthly", "@weekly", "@daily", "@hourly", "@minutely", "@secondly"}
+
+// Shorthand returns the descriptor, such as "@daily", of which the schedule is
+// an exact copy, and true, or false if there is none. The schedule's location
+// is not considered, so "TZ=Asia/Tokyo 0 0 * * *" is "@daily" as well, while
+// any other difference, such as "0 0 1-31 * *" giving the days of the month
+// without a wildcard, is. Of descriptors that mean the same, the first in the
+// documentation is returned: "@yearly" rather than "@annually", and "@daily"
+// rather than "@midnight".
+func (s *SpecSchedule) Shorthand() (string, bool) {
+	for _, descriptor := range shorthands {
+		sched, err := standardParser.Parse(descriptor)
+		if err != nil {
+			continue
+		}
+		d := sched.(*SpecSchedule)
+		d.Location = s.Location
+		if s.Equal(d) {
+			return descriptor, true
+		}
+	}
+	return "", false
+}
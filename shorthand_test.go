@@ -0,0 +1,40 @@
+package cron
+
+import "testing"
+
+func TestShorthand(t *testing.T) {
+	tests := []struct {
+		parser   Parser
+		spec     string
+		expected string
+	}{
+		{secondParser, "0 0 0 * * *", "@daily"},
+		{standardParser, "0 0 * * *", "@daily"},
+		{standardParser, "@midnight", "@daily"},
+		{standardParser, "@annually", "@yearly"},
+		{standardParser, "0 0 1 jan *", "@yearly"},
+		{standardParser, "0 0 1 * *", "@monthly"},
+		{standardParser, "0 0 * * sun", "@weekly"},
+		{standardParser, "0 * * * *", "@hourly"},
+		{secondParser, "0 * * * * *", "@minutely"},
+		{secondParser, "* * * * * *", "@secondly"},
+		{standardParser, "TZ=Asia/Tokyo 0 0 * * *", "@daily"},
+		{standardParser, "0 12 * * *", ""},
+		{standardParser, "0 0 * * 1-5", ""},
+		{standardParser, "0 0 1-31 * *", ""},
+		{standardParser, "*/5 * * * *", ""},
+		{excludeParser, "0 0 * * * !2024-12-25", ""},
+		{NewParser(Millisecond | Second | Minute | Hour | Dom | Month | Dow), "500 0 0 0 * * *", ""},
+	}
+	for _, c := range tests {
+		sched, err := c.parser.Parse(c.spec)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", c.spec, err)
+			continue
+		}
+		actual, ok := sched.(*SpecSchedule).Shorthand()
+		if actual != c.expected || ok != (c.expected != "") {
+			t.Errorf("%s => expected %q, got %q, %v", c.spec, c.expected, actual, ok)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package cron
+
+import (
+	"math"
+	"time"
+)
+
+// SolarEvent is an event of the sun's day that a SolarSchedule runs at.
+type SolarEvent string
+
+const (
+	Sunrise SolarEvent = "sunrise"
+	Sunset  SolarEvent = "sunset"
+)
+
+// SolarResolver gives the times of solar events, so that SolarSchedule can be
+// used with whatever solar calculation the caller trusts.
+type SolarResolver interface {
+	// Resolve returns the time of the event at the given latitude and
+	// longitude, in degrees north and east, on the day around the solar noon
+	// nearest to noon UTC on the given date, which is midnight UTC. It
+	// returns false if the event doesn't happen that day, as in polar summer
+	// and winter.
+	Resolve(event SolarEvent, date time.Time, lat, lon float64) (time.Time, bool)
+}
+
+// SolarSchedule activates once a day at sunrise or sunset at a place, moved
+// by Offset, e.g. half an hour before sunset. The times come from its
+// Resolver, or from SimpleSolarResolver if that is nil. Days on which the
+// event doesn't happen are skipped.
+type SolarSchedule struct {
+	Event    SolarEvent
+	Offset   time.Duration
+	Lat, Lon float64
+
+	Resolver SolarResolver
+}
+
+// maxSolarDays is how many days SolarSchedule looks ahead or back for an event
+// before giving up: the longest polar night is half a year.
+const maxSolarDays = 366
+
+// Next returns the first activation after the given time, or the zero time if
+// the event doesn't happen in the year that follows.
+func (s SolarSchedule) Next(t time.Time) time.Time {
+	day := utcDate(t.Add(-s.Offset)).AddDate(0, 0, -1)
+	for i := 0; i < maxSolarDays; i++ {
+		if at, ok := s.activation(day.AddDate(0, 0, i)); ok && at.After(t) {
+			return at.In(t.Location())
+		}
+	}
+	return time.Time{}
+}
+
+// NextOK is like Next, but reports whether there is a next activation rather
+// than returning the zero time.
+func (s SolarSchedule) NextOK(t time.Time) (time.Time, bool) {
+	next := s.Next(t)
+	return next, !next.IsZero()
+}
+
+// Latest returns the latest activation at or before the given time, or the
+// zero time if the event didn't happen in the year before.
+func (s SolarSchedule) Latest(t time.Time) time.Time {
+	day := utcDate(t.Add(-s.Offset)).AddDate(0, 0, 1)
+	for i := 0; i < maxSolarDays; i++ {
+		if at, ok := s.activation(day.AddDate(0, 0, -i)); ok && !at.After(t) {
+			return at.In(t.Location())
+		}
+	}
+	return time.Time{}
+}
+
+// activation returns the activation of the day of the given date.
+func (s SolarSchedule) activation(date time.Time) (time.Time, bool) {
+	var r SolarResolver = SimpleSolarResolver{}
+	if s.Resolver != nil {
+		r = s.Resolver
+	}
+	at, ok := r.Resolve(s.Event, date, s.Lat, s.Lon)
+	return at.Add(s.Offset), ok
+}
+
+// utcDate returns midnight UTC on t's date in UTC.
+func utcDate(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// SimpleSolarResolver computes sunrise and sunset by the sunrise equation,
+// with the usual corrections for refraction and the size of the sun's disc.
+// It is accurate to a minute or two away from the poles, and ignores the
+// elevation of the place.
+type SimpleSolarResolver struct{}
+
+// Resolve returns the time of sunrise or sunset, as SolarResolver. It returns
+// false for any other event.
+func (SimpleSolarResolver) Resolve(event SolarEvent, date time.Time, lat, lon float64) (time.Time, bool) {
+	const (
+		j2000     = 2451545.0 // Julian date of 2000-01-01 12:00 UTC
+		unixJD    = 2440587.5 // Julian date of the Unix epoch
+		obliquity = 23.4397   // of the ecliptic, in degrees
+	)
+	sin := func(deg float64) float64 { return math.Sin(deg * math.Pi / 180) }
+	cos := func(deg float64) float64 { return math.Cos(deg * math.Pi / 180) }
+
+	// The mean solar noon at the longitude, in days since J2000.
+	n := math.Floor(float64(date.Unix())/86400+unixJD+0.5) - j2000
+	noon := n - lon/360
+
+	anomaly := math.Mod(357.5291+0.98560028*noon, 360)
+	center := 1.9148*sin(anomaly) + 0.02*sin(2*anomaly) + 0.0003*sin(3*anomaly)
+	longitude := math.Mod(anomaly+center+180+102.9372, 360)
+	transit := j2000 + noon + 0.0053*sin(anomaly) - 0.0069*sin(2*longitude)
+
+	sinDecl := sin(longitude) * sin(obliquity)
+	cosDecl := math.Sqrt(1 - sinDecl*sinDecl)
+	cosHour := (sin(-0.833) - sin(lat)*sinDecl) / (cos(lat) * cosDecl)
+	if cosHour < -1 || cosHour > 1 {
+		return time.Time{}, false // the sun doesn't rise or doesn't set
+	}
+	hour := math.Acos(cosHour) * 180 / math.Pi / 360
+
+	var jd float64
+	switch event {
+	case Sunrise:
+		jd = transit - hour
+	case Sunset:
+		jd = transit + hour
+	default:
+		return time.Time{}, false
+	}
+	secs := (jd - unixJD) * 86400
+	return time.Unix(0, int64(secs*1e9)).UTC().Round(time.Second), true
+}
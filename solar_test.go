@@ -0,0 +1,96 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// stubSolarResolver puts sunrise at 06:00 UTC, a minute later each day of
+// the month, except on the days it is told the sun doesn't rise.
+type stubSolarResolver struct {
+	dark map[int]bool
+}
+
+func (r stubSolarResolver) Resolve(event SolarEvent, date time.Time, lat, lon float64) (time.Time, bool) {
+	if event != Sunrise || r.dark[date.Day()] {
+		return time.Time{}, false
+	}
+	return date.Add(6*time.Hour + time.Duration(date.Day())*time.Minute), true
+}
+
+func TestSolarSchedule(t *testing.T) {
+	sched := SolarSchedule{
+		Event:    Sunrise,
+		Offset:   -30 * time.Minute,
+		Resolver: stubSolarResolver{dark: map[int]bool{4: true}},
+	}
+	at := func(value string) time.Time {
+		t, _ := time.Parse("2006-01-02 15:04", value)
+		return t
+	}
+	tests := []struct {
+		time, next, latest string
+	}{
+		{"2024-07-01 00:00", "2024-07-01 05:31", "2024-06-30 06:00"},
+		{"2024-07-01 05:31", "2024-07-02 05:32", "2024-07-01 05:31"},
+		{"2024-07-01 12:00", "2024-07-02 05:32", "2024-07-01 05:31"},
+		// No sunrise on the 4th.
+		{"2024-07-03 06:00", "2024-07-05 05:35", "2024-07-03 05:33"},
+		{"2024-07-04 23:59", "2024-07-05 05:35", "2024-07-03 05:33"},
+	}
+	for _, c := range tests {
+		if next := sched.Next(at(c.time)); !next.Equal(at(c.next)) {
+			t.Errorf("%s: expected next %v, got %v", c.time, at(c.next), next)
+		}
+		if latest := sched.Latest(at(c.time)); !latest.Equal(at(c.latest)) {
+			t.Errorf("%s: expected latest %v, got %v", c.time, at(c.latest), latest)
+		}
+	}
+
+	// An offset that moves the activation across midnight UTC.
+	late := SolarSchedule{Event: Sunrise, Offset: 20 * time.Hour, Resolver: stubSolarResolver{}}
+	if next := late.Next(at("2024-07-02 01:00")); !next.Equal(at("2024-07-02 02:01")) {
+		t.Errorf("expected the activation of the 1st, got %v", next)
+	}
+
+	// An event that never happens.
+	never := SolarSchedule{Event: Sunset, Resolver: stubSolarResolver{}}
+	if next, ok := never.NextOK(at("2024-07-01 00:00")); ok || !next.IsZero() {
+		t.Errorf("expected no activation, got %v", next)
+	}
+	if latest := never.Latest(at("2024-07-01 00:00")); !latest.IsZero() {
+		t.Errorf("expected no activation, got %v", latest)
+	}
+}
+
+func TestSimpleSolarResolver(t *testing.T) {
+	tests := []struct {
+		event    SolarEvent
+		date     string
+		lat, lon float64
+		expected string
+	}{
+		// London at midsummer and New York at midwinter.
+		{Sunrise, "2024-06-21", 51.5074, -0.1278, "2024-06-21T03:43:00Z"},
+		{Sunset, "2024-06-21", 51.5074, -0.1278, "2024-06-21T20:21:00Z"},
+		{Sunrise, "2024-12-21", 40.7128, -74.006, "2024-12-21T12:17:00Z"},
+		// Sydney's sunrise falls on the previous day in UTC.
+		{Sunrise, "2024-06-21", -33.8688, 151.2093, "2024-06-20T21:00:00Z"},
+		// Polar night in Svalbard.
+		{Sunrise, "2024-12-21", 78.2232, 15.6267, ""},
+	}
+	for _, c := range tests {
+		date, _ := time.Parse("2006-01-02", c.date)
+		at, ok := SimpleSolarResolver{}.Resolve(c.event, date, c.lat, c.lon)
+		if c.expected == "" {
+			if ok {
+				t.Errorf("%s %s: expected no event, got %v", c.event, c.date, at)
+			}
+			continue
+		}
+		expected, _ := time.Parse(time.RFC3339, c.expected)
+		if diff := at.Sub(expected); !ok || diff < -2*time.Minute || diff > 2*time.Minute {
+			t.Errorf("%s %s: expected about %v, got %v", c.event, c.date, expected, at)
+		}
+	}
+}
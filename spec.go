@@ -1,8 +1,13 @@
 package cron
 
 import (
+	"fmt"
 	"math/big"
+	"runtime"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -13,6 +18,19 @@ type SpecSchedule struct {
 
 	// Override location for this schedule.
 	Location *time.Location
+
+	// WeekOfYear restricts the schedule to the given ISO 8601 weeks, numbered
+	// from 1. If it is nil, every week is active.
+	WeekOfYear *big.Int
+
+	// Millisecond restricts the schedule to the given milliseconds of each
+	// second, from 0 to 999, as parsed with the Millisecond option. If it is
+	// nil, the schedule runs at the start of each second.
+	Millisecond *big.Int
+
+	excluded map[int]bool // dates not to run on, by dateKey
+	raw      string       // the spec it was parsed from, exactly as written
+	comment  string       // its trailing comment, with InlineComments
 }
 
 // bounds provides a range of acceptable values (plus a map of name to value).
@@ -49,15 +67,36 @@ var (
 		"oct": 10,
 		"nov": 11,
 		"dec": 12,
+
+		"january":   1,
+		"february":  2,
+		"march":     3,
+		"april":     4,
+		"june":      6,
+		"july":      7,
+		"august":    8,
+		"september": 9,
+		"october":   10,
+		"november":  11,
+		"december":  12,
 	}}
 	dow = bounds{0, 6, map[string]uint{
-		"sun":  0,
-		"mon":  1,
-		"tue":  2,
-		"wed":  3,
-		"thu":  4,
-		"fri":  5,
-		"sat":  6,
+		"sun": 0,
+		"mon": 1,
+		"tue": 2,
+		"wed": 3,
+		"thu": 4,
+		"fri": 5,
+		"sat": 6,
+
+		"sunday":    0,
+		"monday":    1,
+		"tuesday":   2,
+		"wednesday": 3,
+		"thursday":  4,
+		"friday":    5,
+		"saturday":  6,
+
 		"sunl": 49,
 		"monl": 50,
 		"tuel": 51,
@@ -74,6 +113,15 @@ var (
 		"6l":   55,
 	}}
 	years = bounds{0, maxYear - minYear, nil} // 1970~2099
+	weeks = bounds{1, 53, nil}
+
+	// mondayDow is the day of week field numbered from Monday, for parsers
+	// with WeekStartsMonday. Names mean the same days as in dow.
+	mondayDow = bounds{0, 6, nil}
+
+	// milliseconds is the leading field of parsers with the Millisecond
+	// option, which is not one of the places.
+	milliseconds = bounds{0, 999, nil}
 )
 
 func init() {
@@ -81,6 +129,60 @@ func init() {
 	for i := minYear; i <= maxYear; i++ {
 		years.names[strconv.Itoa(i)] = uint(i - minYear)
 	}
+
+	mondayDow.names = make(map[string]uint)
+	for name, v := range dow.names {
+		if name[0] < '0' || name[0] > '9' {
+			v = mondayDay(v)
+		}
+		mondayDow.names[name] = v
+	}
+}
+
+// twoDigitYears returns the bounds of the year field with two-digit years
+// added, those from pivot on being in the 1900s.
+func twoDigitYears(pivot int) bounds {
+	if pivot == 0 {
+		pivot = DefaultYearPivot
+	}
+	b := years
+	b.names = make(map[string]uint, len(years.names)+100)
+	for name, v := range years.names {
+		b.names[name] = v
+	}
+	for yy := 0; yy < 100; yy++ {
+		year := 2000 + yy
+		if yy >= pivot {
+			year = 1900 + yy
+		}
+		if year >= minYear && year <= maxYear {
+			b.names[fmt.Sprintf("%02d", yy)] = uint(year - minYear)
+		}
+	}
+	return b
+}
+
+// mondayDay converts a day of week (or last day of week) numbered from Sunday
+// to one numbered from Monday.
+func mondayDay(v uint) uint {
+	if v >= 49 {
+		return 49 + (v-49+6)%7
+	}
+	return (v + 6) % 7
+}
+
+// fromMondayDow converts day of week bits numbered from Monday to the usual
+// numbering from Sunday.
+func fromMondayDow(bits *big.Int) *big.Int {
+	sunday := new(big.Int).Set(bits)
+	for v := 0; v < 7; v++ {
+		sunday.SetBit(sunday, (v+1)%7, bits.Bit(v))
+		sunday.SetBit(sunday, 49+(v+1)%7, bits.Bit(49+v))
+		for n := 1; n <= 5; n++ {
+			sunday.SetBit(sunday, nthWeekdayBit(n, (v+1)%7), bits.Bit(nthWeekdayBit(n, v)))
+		}
+	}
+	return sunday
 }
 
 const (
@@ -90,9 +192,311 @@ const (
 	maxYear = 2099
 )
 
+// LocationName returns the name of the schedule's effective time zone: the
+// IANA name for a loaded zone, and "Local" for time.Local, which is also the
+// zone of a schedule with no location set.
+func (s *SpecSchedule) LocationName() string {
+	return s.EffectiveLocation().String()
+}
+
+// EffectiveLocation returns the location the schedule reads times in: its
+// Location, or time.Local if that is nil. A schedule in time.Local follows
+// each time it is given, reading it in that time's own location, so it is the
+// process's local zone only for times in time.Local.
+func (s *SpecSchedule) EffectiveLocation() *time.Location {
+	if s.Location == nil {
+		return time.Local
+	}
+	return s.Location
+}
+
+// InLocation returns a deep copy of the schedule interpreted in the given
+// location, in the manner of time.Time.In, leaving the original unchanged. A
+// nil loc means time.Local, as it does for the Location field.
+func (s *SpecSchedule) InLocation(loc *time.Location) *SpecSchedule {
+	if loc == nil {
+		loc = time.Local
+	}
+	c := s.clone()
+	c.Location = loc
+	if loc == s.EffectiveLocation() {
+		c.raw, c.comment = s.raw, s.comment // still what was parsed
+	}
+	return c
+}
+
+// Source returns the spec the schedule was parsed from, exactly as it was
+// written, or String for a schedule that was built rather than parsed.
+func (s *SpecSchedule) Source() string {
+	if s.raw != "" {
+		return s.raw
+	}
+	return s.String()
+}
+
+// Comment returns the text of the trailing comment of the spec the schedule
+// was parsed from, without the "#", if it was parsed with the InlineComments
+// option and had one. Like Source, it describes the schedule as parsed, so
+// copies changed from it, e.g. by Shift, have none.
+func (s *SpecSchedule) Comment() string {
+	return s.comment
+}
+
+// String returns the schedule as a spec with seconds and a year, such as
+// "0 30 9 * * 1-5 *", in the form ParseFlexible accepts. A location other than
+// time.Local is given by a TZ= prefix, and weeks of the year, if restricted,
+// by an eighth field as parsed with the Week option. Milliseconds, if set, are
+// given by a leading field as parsed with the Millisecond option, and excluded
+// dates by a trailing "!" list. Days required to match both day fields, as by
+// DomAndDow, are given by a leading "&" on the day of month. The ClampDom
+// parse option is not represented.
+func (s *SpecSchedule) String() string {
+	fields := s.fields()
+	if s.WeekOfYear == nil {
+		fields = fields[:7]
+	}
+	tokens := make([]string, 0, len(fields)+2)
+	if s.EffectiveLocation() != time.Local {
+		tokens = append(tokens, "TZ="+s.LocationName())
+	}
+	if s.Millisecond != nil {
+		tokens = append(tokens, FormatField(s.Millisecond, milliseconds.min, milliseconds.max, nil))
+	}
+	for i, field := range fields {
+		tokens = append(tokens, formatPlace(i, field))
+	}
+	if excluded := s.formatExcludedDates(); excluded != "" {
+		tokens = append(tokens, excluded)
+	}
+	return strings.Join(tokens, " ")
+}
+
+// formatPlace returns the token for the field at the given index of places.
+func formatPlace(i int, field *big.Int) string {
+	r := fieldBounds[i]
+	if places[i] != Dom && places[i] != Dow {
+		return FormatField(field, r.min, r.max, r.names)
+	}
+
+	and := ""
+	if places[i] == Dom && field.Bit(domAndDowBit) > 0 {
+		and = "&"
+	}
+	field, weekdays := splitWeekdays(places[i], field)
+	token := FormatField(field, r.min, r.max, r.names)
+	// A wildcard makes dayMatches require both day fields, so spell out
+	// every day if that isn't what was given.
+	if field.Bit(maxBits) == 0 && isAll(field, *r) {
+		token = fmt.Sprintf("%d-%d", r.min, r.max)
+	}
+	if token == "" {
+		return and + strings.Join(weekdays, ",")
+	}
+	return and + strings.Join(append([]string{token}, weekdays...), ",")
+}
+
+// splitWeekdays returns a copy of a day field without the bits FormatField
+// can't name, and the tokens for them: nearest weekdays ("15w", "lw") in the
+// day of month field, and nth weekdays ("mon#2") in the day of week field. The
+// ClampDom and DomAndDow flags are dropped, as they aren't days.
+func splitWeekdays(place ParseOption, field *big.Int) (*big.Int, []string) {
+	field = new(big.Int).Set(field)
+	var weekdays []string
+	if place == Dom {
+		for v := 29; v <= 31; v++ {
+			field.SetBit(field, domClampBit(v), 0)
+		}
+		field.SetBit(field, domAndDowBit, 0)
+		for v := 1; v <= 31; v++ {
+			if field.Bit(domWeekdayBit(v)) > 0 {
+				field.SetBit(field, domWeekdayBit(v), 0)
+				weekdays = append(weekdays, fmt.Sprintf("%dw", v))
+			}
+		}
+		if field.Bit(lastWeekdayBit) > 0 {
+			field.SetBit(field, lastWeekdayBit, 0)
+			weekdays = append(weekdays, "lw")
+		}
+		return field, weekdays
+	}
+	for n := 1; n <= 5; n++ {
+		for w := 0; w <= 6; w++ {
+			if field.Bit(nthWeekdayBit(n, w)) > 0 {
+				field.SetBit(field, nthWeekdayBit(n, w), 0)
+				weekdays = append(weekdays, fmt.Sprintf("%s#%d", weekdayName(w), n))
+			}
+		}
+	}
+	return field, weekdays
+}
+
+// weekdayName returns the three-letter name of day of week w, such as "mon".
+func weekdayName(w int) string {
+	return strings.ToLower(time.Weekday(w).String()[:3])
+}
+
+// Equal reports whether two schedules have the same fields and are in the
+// same location, as named by LocationName. Unset fields are empty, apart from
+// an unset milliseconds field, which is the same as millisecond 0. How the
+// schedules were written, as given by Source and Comment, is ignored.
+func (s *SpecSchedule) Equal(other *SpecSchedule) bool {
+	theirs := other.fields()
+	for i, field := range s.fields() {
+		if field.Cmp(theirs[i]) != 0 {
+			return false
+		}
+	}
+	return s.milliseconds().Cmp(other.milliseconds()) == 0 && s.LocationName() == other.LocationName() &&
+		s.formatExcludedDates() == other.formatExcludedDates()
+}
+
+// Diff describes each way in which the schedules differ, one line per field,
+// e.g. "minute: {0,30} vs {0,15}" or "location: UTC vs America/New_York". It
+// returns nil if the schedules are Equal.
+func (s *SpecSchedule) Diff(other *SpecSchedule) []string {
+	var diffs []string
+	theirs := other.fields()
+	for i, field := range s.fields() {
+		if field.Cmp(theirs[i]) == 0 {
+			continue
+		}
+		r := fieldBounds[i]
+		a, b := formatDiffPlace(i, field), formatDiffPlace(i, theirs[i])
+		if a == b { // they differ only in whether a wildcard was used
+			if field.Bit(maxBits) == 0 {
+				a = fmt.Sprintf("%d-%d", r.min, r.max)
+			} else {
+				b = fmt.Sprintf("%d-%d", r.min, r.max)
+			}
+		}
+		diffs = append(diffs, fmt.Sprintf("%s: {%s} vs {%s}", fieldNames[i], a, b))
+	}
+	if a, b := s.milliseconds(), other.milliseconds(); a.Cmp(b) != 0 {
+		r := milliseconds
+		diffs = append(diffs, fmt.Sprintf("millisecond: {%s} vs {%s}",
+			FormatField(a, r.min, r.max, nil), FormatField(b, r.min, r.max, nil)))
+	}
+	if a, b := s.LocationName(), other.LocationName(); a != b {
+		diffs = append(diffs, fmt.Sprintf("location: %s vs %s", a, b))
+	}
+	if a, b := s.formatExcludedDates(), other.formatExcludedDates(); a != b {
+		diffs = append(diffs, fmt.Sprintf("excluded: {%s} vs {%s}", strings.TrimPrefix(a, "!"), strings.TrimPrefix(b, "!")))
+	}
+	return diffs
+}
+
+// formatDiffPlace is formatPlace for Diff, which also gives the days clamped
+// by ClampDom, as they have no token.
+func formatDiffPlace(i int, field *big.Int) string {
+	token := formatPlace(i, field)
+	if places[i] != Dom {
+		return token
+	}
+	var clamped []string
+	for v := 29; v <= 31; v++ {
+		if field.Bit(domClampBit(v)) > 0 {
+			clamped = append(clamped, strconv.Itoa(v))
+		}
+	}
+	if len(clamped) > 0 {
+		token += " clamped " + strings.Join(clamped, ",")
+	}
+	return token
+}
+
+// milliseconds returns the schedule's milliseconds field, which is millisecond
+// 0 if it is unset.
+func (s *SpecSchedule) milliseconds() *big.Int {
+	if s.Millisecond == nil {
+		return big.NewInt(1)
+	}
+	return s.Millisecond
+}
+
+// subSecond reports whether the schedule runs at any millisecond but the
+// start of the second.
+func (s *SpecSchedule) subSecond() bool {
+	millis := s.milliseconds()
+	return countBits(millis, milliseconds) != 1 || millis.Bit(0) == 0
+}
+
+// fields returns the schedule's bit sets, in the order of places, with unset
+// fields as empty sets.
+func (s *SpecSchedule) fields() []*big.Int {
+	fields := []*big.Int{s.Second, s.Minute, s.Hour, s.Dom, s.Month, s.Dow, s.Year, s.WeekOfYear}
+	for i, field := range fields {
+		if field == nil {
+			fields[i] = new(big.Int)
+		}
+	}
+	if s.WeekOfYear == nil {
+		fields[7] = all(weeks)
+	}
+	return fields
+}
+
+// clone returns a deep copy of the schedule. The copy is a new schedule, not
+// what was parsed, so it has no Source or Comment of its own.
+func (s *SpecSchedule) clone() *SpecSchedule {
+	c := *s
+	c.raw, c.comment = "", ""
+	for _, field := range []**big.Int{&c.Second, &c.Minute, &c.Hour, &c.Dom, &c.Month, &c.Dow, &c.Year, &c.WeekOfYear, &c.Millisecond} {
+		if *field != nil {
+			*field = new(big.Int).Set(*field)
+		}
+	}
+	return &c
+}
+
+// TimesOfDay returns the times of day at which the schedule activates, as
+// offsets from midnight in ascending order. Date fields are ignored.
+//
+// Every combination of hour, minute and second is returned, so a schedule
+// that fires every second yields 86400 offsets.
+func (s *SpecSchedule) TimesOfDay() []time.Duration {
+	var times []time.Duration
+	for h := hours.min; h <= hours.max; h++ {
+		if s.Hour.Bit(int(h)) == 0 {
+			continue
+		}
+		for m := minutes.min; m <= minutes.max; m++ {
+			if s.Minute.Bit(int(m)) == 0 {
+				continue
+			}
+			for sec := seconds.min; sec <= seconds.max; sec++ {
+				if s.Second.Bit(int(sec)) == 0 {
+					continue
+				}
+				times = append(times, time.Duration(h)*time.Hour+
+					time.Duration(m)*time.Minute+
+					time.Duration(sec)*time.Second)
+			}
+		}
+	}
+	return times
+}
+
 // Next returns the next time this schedule is activated, greater than the given
 // time.  If no time can be found to satisfy the schedule, return the zero time.
 func (s *SpecSchedule) Next(t time.Time) time.Time {
+	if s.Millisecond != nil {
+		return s.nextMillisecond(t)
+	}
+	return s.nextSecond(t)
+}
+
+// nextSecond is Next for a schedule without a milliseconds field.
+func (s *SpecSchedule) nextSecond(t time.Time) time.Time {
+	next := s.nextSecondIn(t)
+	if next.IsZero() {
+		return next
+	}
+	return next.In(t.Location())
+}
+
+// nextSecondIn is nextSecond, but returns the activation in the schedule's
+// location, or t's for a schedule in time.Local.
+func (s *SpecSchedule) nextSecondIn(t time.Time) time.Time {
 	// General approach
 	//
 	// For Month, Day, Hour, Minute, Second:
@@ -103,16 +507,13 @@ func (s *SpecSchedule) Next(t time.Time) time.Time {
 	// values)
 
 	// Convert the given time into the schedule's timezone, if one is specified.
-	// Save the original timezone so we can convert back after we find a time.
 	// Note that schedules without a time zone specified (time.Local) are treated
 	// as local to the time provided.
-	origLocation := t.Location()
-	loc := s.Location
+	loc := s.EffectiveLocation()
 	if loc == time.Local {
 		loc = t.Location()
-	}
-	if s.Location != time.Local {
-		t = t.In(s.Location)
+	} else {
+		t = t.In(loc)
 	}
 
 	// Start at the earliest possible time (the upcoming second).
@@ -162,12 +563,23 @@ WRAP:
 	// NOTE: This causes issues for daylight savings regimes where midnight does
 	// not exist.  For example: Sao Paulo has DST that transforms midnight on
 	// 11/3 into 1am. Handle that by noticing when the Hour ends up != 0.
-	for !dayMatches(s, t) {
+	for !dayMatches(s, t) || !weekMatches(s, t) || s.excludes(t) {
 		if !added {
 			added = true
 			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
 		}
-		t = t.AddDate(0, 0, 1)
+		month := t.Month()
+		if weekMatches(s, t) {
+			t = t.AddDate(0, 0, 1)
+		} else {
+			// Skip to the next Monday, or the first of the month, whichever is
+			// sooner.
+			t = t.AddDate(0, 0, 7-(int(t.Weekday())+6)%7)
+			if t.Month() != month {
+				t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+				goto WRAP
+			}
+		}
 		// Notice if the hour is no longer midnight due to DST.
 		// Add an hour if it's 23, subtract an hour if it's 1.
 		if t.Hour() != 0 {
@@ -188,8 +600,17 @@ WRAP:
 			added = true
 			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
 		}
+		prev := t
 		t = t.Add(1 * time.Hour)
 
+		// After a half-hour DST change, move back to the top of the hour, if
+		// that exists and isn't the hour just tried.
+		if m := t.Minute(); m != 0 {
+			if top := t.Add(-time.Duration(m) * time.Minute); top.Hour() == t.Hour() && top.After(prev) {
+				t = top
+			}
+		}
+
 		if t.Hour() == 0 {
 			goto WRAP
 		}
@@ -200,9 +621,11 @@ WRAP:
 			added = true
 			t = t.Truncate(time.Minute)
 		}
+		hour := t.Hour()
 		t = t.Add(1 * time.Minute)
 
-		if t.Minute() == 0 {
+		// A half-hour DST change can move to another hour mid-way through.
+		if t.Minute() == 0 || t.Hour() != hour {
 			goto WRAP
 		}
 	}
@@ -219,13 +642,127 @@ WRAP:
 		}
 	}
 
-	return t.In(origLocation)
+	return t
+}
+
+// NextUnix is like Next, but returns the activation as seconds since the Unix
+// epoch, or -1 if there is none, for hot paths that don't need a time.Time.
+// It saves only converting the activation back to t's location, as neither it
+// nor Next allocates. Activations of a schedule with a milliseconds field are
+// truncated to the second.
+func (s *SpecSchedule) NextUnix(t time.Time) int64 {
+	var next time.Time
+	if s.Millisecond != nil {
+		next = s.nextMillisecond(t)
+	} else {
+		next = s.nextSecondIn(t)
+	}
+	if next.IsZero() {
+		return -1
+	}
+	return next.Unix()
+}
+
+// BatchNext returns the next activation of each of the given schedules after
+// t, so that result[i] = specs[i].Next(t). The schedules are evaluated
+// concurrently, using up to GOMAXPROCS goroutines, which helps when planning
+// thousands of schedules at once. Small batches are evaluated in place.
+func BatchNext(specs []*SpecSchedule, t time.Time) []time.Time {
+	result := make([]time.Time, len(specs))
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(specs) {
+		workers = len(specs)
+	}
+	if len(specs) <= 4 || workers < 2 {
+		for i, s := range specs {
+			result[i] = s.Next(t)
+		}
+		return result
+	}
+
+	var (
+		wg   sync.WaitGroup
+		next int64 = -1
+	)
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(specs) {
+					return
+				}
+				result[i] = specs[i].Next(t)
+			}
+		}()
+	}
+	wg.Wait()
+	return result
+}
+
+// NextOK is like Next, but reports false rather than returning the zero time
+// when no time can be found to satisfy the schedule.
+func (s *SpecSchedule) NextOK(t time.Time) (time.Time, bool) {
+	next := s.Next(t)
+	return next, !next.IsZero()
+}
+
+// NextWithSkew returns the next activation after t as seen by a clock that is
+// skew ahead (or, if negative, behind): the clock reads t+skew, the schedule is
+// evaluated against that reading, and the activation is converted back to
+// true time. It simulates clock drift without changing the system clock.
+func (s *SpecSchedule) NextWithSkew(t time.Time, skew time.Duration) time.Time {
+	next := s.Next(t.Add(skew))
+	if next.IsZero() {
+		return next
+	}
+	return next.Add(-skew)
+}
+
+// NextAtOrAfter is like Next, but returns t itself, rounded down to the
+// second, if the schedule activates then. (For a schedule with a milliseconds
+// field, t is rounded down to the millisecond.)
+func (s *SpecSchedule) NextAtOrAfter(t time.Time) time.Time {
+	return s.Next(t.Truncate(s.granularity()).Add(-time.Nanosecond))
+}
+
+// Matches reports whether the schedule activates at t, rounded down to the
+// second (or, for a schedule with a milliseconds field, the millisecond).
+func (s *SpecSchedule) Matches(t time.Time) bool {
+	if loc := s.EffectiveLocation(); loc != time.Local {
+		t = t.In(loc)
+	}
+	if s.Millisecond != nil && s.Millisecond.Bit(t.Nanosecond()/int(time.Millisecond)) == 0 {
+		return false
+	}
+	return t.Year() >= minYear && t.Year() <= maxYear && s.Year.Bit(t.Year()-minYear) > 0 &&
+		s.Month.Bit(int(t.Month())) > 0 && dayMatches(s, t) && weekMatches(s, t) && !s.excludes(t) &&
+		s.Hour.Bit(t.Hour()) > 0 && s.Minute.Bit(t.Minute()) > 0 && s.Second.Bit(t.Second()) > 0
+}
+
+// granularity returns the interval to which the schedule's activations are
+// aligned.
+func (s *SpecSchedule) granularity() time.Duration {
+	if s.Millisecond != nil {
+		return time.Millisecond
+	}
+	return time.Second
 }
 
 // Latest returns the latest activation time, include the given time.
-// This rounds so that the latest activation time will be on the second.
+// This rounds so that the latest activation time will be on the second, or
+// the millisecond for a schedule with a milliseconds field.
 // If no time can be found to satisfy the schedule, return the zero time.
 func (s *SpecSchedule) Latest(t time.Time) time.Time {
+	if s.Millisecond != nil {
+		return s.latestMillisecond(t)
+	}
+	return s.latestSecond(t)
+}
+
+// latestSecond is Latest for a schedule without a milliseconds field.
+func (s *SpecSchedule) latestSecond(t time.Time) time.Time {
 	// General approach
 	//
 	// For Month, Day, Hour, Minute, Second:
@@ -240,12 +777,11 @@ func (s *SpecSchedule) Latest(t time.Time) time.Time {
 	// Note that schedules without a time zone specified (time.Local) are treated
 	// as local to the time provided.
 	origLocation := t.Location()
-	loc := s.Location
+	loc := s.EffectiveLocation()
 	if loc == time.Local {
 		loc = t.Location()
-	}
-	if s.Location != time.Local {
-		t = t.In(s.Location)
+	} else {
+		t = t.In(loc)
 	}
 
 	// Rounds the given time down to the second.
@@ -282,7 +818,7 @@ WRAP:
 	// NOTE: This causes issues for daylight savings regimes where midnight does
 	// not exist.  For example: Sao Paulo has DST that transforms midnight on
 	// 11/3 into 1am. Handle that by noticing when the Hour ends up != 0.
-	for !dayMatches(s, t) {
+	for !dayMatches(s, t) || !weekMatches(s, t) || s.excludes(t) {
 		var needWrap bool
 		if t.Day() == 1 {
 			needWrap = true
@@ -309,8 +845,10 @@ WRAP:
 		if t.Minute() == 0 {
 			needWrap = true
 		}
+		hour := t.Hour()
 		t = t.Truncate(time.Minute).Add(-time.Second)
-		if needWrap {
+		// A half-hour DST change can move to another hour mid-way through.
+		if needWrap || t.Hour() != hour {
 			goto WRAP
 		}
 	}
@@ -329,6 +867,16 @@ WRAP:
 	return t.In(origLocation)
 }
 
+// weekMatches returns true if the schedule's week of year restriction, if any,
+// is satisfied by the given time.
+func weekMatches(s *SpecSchedule, t time.Time) bool {
+	if s.WeekOfYear == nil {
+		return true
+	}
+	_, week := t.ISOWeek()
+	return s.WeekOfYear.Bit(week) > 0
+}
+
 // dayMatches returns true if the schedule's day-of-week and day-of-month
 // restrictions are satisfied by the given time.
 func dayMatches(s *SpecSchedule, t time.Time) bool {
@@ -341,20 +889,108 @@ func dayMatches(s *SpecSchedule, t time.Time) bool {
 	if eom > 0 {
 		domMatch = domMatch || (1<<uint(t.Day())&eom > 0)
 	}
+	if !domMatch && t.Day() >= 28 {
+		domMatch = clampMatches(s, t)
+	}
+	if !domMatch {
+		domMatch = weekdayMatches(s, t)
+	}
 	if eowd > 0 {
 		dowMatch = dowMatch || (1<<uint(t.Day())&eowd > 0)
 	}
-	if s.Dom.Bit(maxBits) > 0 || s.Dow.Bit(maxBits) > 0 {
+	if !dowMatch {
+		dowMatch = s.Dow.Bit(nthWeekdayBit((t.Day()+6)/7, int(t.Weekday()))) > 0
+	}
+	if s.bothDays() {
 		return domMatch && dowMatch
 	}
 	return domMatch || dowMatch
 }
 
+// bothDays reports whether days must match both day fields rather than
+// either: if either field is "*", or DomAndDow was given.
+func (s *SpecSchedule) bothDays() bool {
+	return s.Dom.Bit(maxBits) > 0 || s.Dow.Bit(maxBits) > 0 || s.Dom.Bit(domAndDowBit) > 0
+}
+
+// domClampBit is the bit of the day of month field flagging that day v, from
+// 29 to 31, is clamped to the last day of shorter months (see ClampDom).
+func domClampBit(v int) int { return 32 + v }
+
+// domWeekdayBit is the bit of the day of month field for the weekday nearest
+// day v, from 1 to 31, as given by "15W".
+func domWeekdayBit(v int) int { return 64 + v }
+
+// lastWeekdayBit is the bit of the day of month field for the last weekday of
+// the month, as given by "LW".
+const lastWeekdayBit = 96
+
+// domAndDowBit is the bit of the day of month field flagging that days must
+// match the day of week field as well, as given by DomAndDow or "&".
+const domAndDowBit = 97
+
+// nthWeekdayBit is the bit of the day of week field for the nth, from 1 to 5,
+// of weekday w in the month, as given by "MON#2".
+func nthWeekdayBit(n, w int) int { return 8 + 7*(n-1) + w }
+
+// weekdayMatches returns true if t is the weekday nearest a day given as "15W"
+// in the schedule, or the last weekday of its month and "LW" is given. As in
+// Quartz, the nearest weekday is never in another month: the nearest to
+// Saturday the 1st is Monday the 3rd.
+func weekdayMatches(s *SpecSchedule, t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	day := t.Day()
+	last := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if s.Dom.Bit(lastWeekdayBit) > 0 && (day == last || t.Weekday() == time.Friday && day >= last-2) {
+		return true
+	}
+	for v := day - 2; v <= day+2; v++ {
+		if v < 1 || v > last || s.Dom.Bit(domWeekdayBit(v)) == 0 {
+			continue
+		}
+		nearest := v
+		switch time.Date(t.Year(), t.Month(), v, 0, 0, 0, 0, time.UTC).Weekday() {
+		case time.Saturday:
+			if nearest--; v == 1 {
+				nearest = 3
+			}
+		case time.Sunday:
+			if nearest++; v == last {
+				nearest = last - 2
+			}
+		}
+		if nearest == day {
+			return true
+		}
+	}
+	return false
+}
+
+// clampMatches returns true if t is the last day of its month, and the
+// schedule has a clamped day of month beyond it.
+func clampMatches(s *SpecSchedule, t time.Time) bool {
+	last := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if t.Day() != last {
+		return false
+	}
+	for v := last + 1; v <= 31; v++ {
+		if s.Dom.Bit(domClampBit(v)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // basically EOM(to EOM - 7) flag is stored in bits 55 - 48 of SpecSchedule's Dom
 // you just need to know what date of t's eom, and shift bits 55 - 48 (0x00FF_0000_0000_0000) to that position
+// Only bits 55 - 48 are read (see lastBits), so the ordinary days in the low bits of
+// the same field, such as mon in "mon,monl", never leak into the result.
 func eomBits(s *SpecSchedule, t time.Time) (uint, uint) {
-	bDow := byte(s.Dow.Bit(0x00FE000000000000) >> (6 * 8))
-	if s.Dom.Bit(0x00FF000000000000) == 0 && bDow == 0 {
+	bDom := lastBits(s.Dom)
+	bDow := lastBits(s.Dow) & 0xFE
+	if bDom == 0 && bDow == 0 {
 		return 0, 0
 	}
 	eom := byte(30)
@@ -392,5 +1028,15 @@ func eomBits(s *SpecSchedule, t time.Time) (uint, uint) {
 		}
 		dowBits = uint64(bDow) << (6 * 8)
 	}
-	return s.Dom.Bit(0x00FF000000000000) >> (uint64(55) - uint64(eom)), dowBits >> (uint64(55) - uint64(eom))
+	domBits := uint64(bDom) << (6 * 8)
+	return uint(domBits >> (uint64(55) - uint64(eom))), uint(dowBits >> (uint64(55) - uint64(eom)))
+}
+
+// lastBits returns bits 55 - 48 of the given field, where the L flags live.
+func lastBits(bits *big.Int) byte {
+	var b byte
+	for i := 0; i < 8; i++ {
+		b |= byte(bits.Bit(6*8+i)) << uint(i)
+	}
+	return b
 }
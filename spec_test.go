@@ -1,6 +1,11 @@
 package cron
 
 import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -83,6 +88,45 @@ func TestActivation(t *testing.T) {
 	}
 }
 
+// Ordinary and last weekdays in the same field must each match only their
+// own days.
+func TestWeekdayAndLastWeekday(t *testing.T) {
+	tests := []struct {
+		spec       string
+		days, last []time.Weekday
+	}{
+		{"0 0 0 * * mon,monl", []time.Weekday{time.Monday}, []time.Weekday{time.Monday}},
+		{"0 0 0 * * monl", nil, []time.Weekday{time.Monday}},
+		{"0 0 0 * * fri,fril", []time.Weekday{time.Friday}, []time.Weekday{time.Friday}},
+		{"0 0 0 * * sun,satl", []time.Weekday{time.Sunday}, []time.Weekday{time.Saturday}},
+		{"0 0 0 * * mon,tue,sunl,satl", []time.Weekday{time.Monday, time.Tuesday}, []time.Weekday{time.Sunday, time.Saturday}},
+		{"0 0 0 * * 1-5,0l", []time.Weekday{1, 2, 3, 4, 5}, []time.Weekday{time.Sunday}},
+	}
+
+	has := func(days []time.Weekday, d time.Weekday) bool {
+		for _, day := range days {
+			if day == d {
+				return true
+			}
+		}
+		return false
+	}
+	for _, c := range tests {
+		sched, err := secondParser.Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Two years take in every weekday as the last day of a month.
+		for day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local); day.Year() < 2022; day = day.AddDate(0, 0, 1) {
+			lastOfItsKind := day.AddDate(0, 0, 7).Month() != day.Month()
+			expected := has(c.days, day.Weekday()) || lastOfItsKind && has(c.last, day.Weekday())
+			if actual := sched.Next(day.Add(-time.Second)).Equal(day); actual != expected {
+				t.Errorf("%s on %s: expected %v, got %v", c.spec, day.Format("Mon Jan 2 2006"), expected, actual)
+			}
+		}
+	}
+}
+
 func TestNext(t *testing.T) {
 	var runs = []struct {
 		time, spec string
@@ -409,3 +453,665 @@ func TestSlash0NoHang(t *testing.T) {
 		t.Error("expected an error on 0 increment")
 	}
 }
+
+func TestLocationName(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		loc      *time.Location
+		expected string
+	}{
+		{tokyo, "Asia/Tokyo"},
+		{time.Local, "Local"},
+		{time.UTC, "UTC"},
+		{nil, "Local"},
+	}
+	for _, c := range tests {
+		s := &SpecSchedule{Location: c.loc}
+		if actual := s.LocationName(); actual != c.expected {
+			t.Errorf("expected %s, got %s", c.expected, actual)
+		}
+	}
+}
+
+func TestBatchNext(t *testing.T) {
+	var specs []*SpecSchedule
+	for i := 0; i < 100; i++ {
+		sched, err := secondParser.Parse(fmt.Sprintf("%d %d * * * *", i%60, (i*7)%60))
+		if err != nil {
+			t.Fatal(err)
+		}
+		specs = append(specs, sched.(*SpecSchedule))
+	}
+
+	now := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+	before := runtime.NumGoroutine()
+	for _, n := range []int{0, 1, 4, 5, 100} {
+		actual := BatchNext(specs[:n], now)
+		if len(actual) != n {
+			t.Fatalf("expected %d results, got %d", n, len(actual))
+		}
+		for i, s := range specs[:n] {
+			if expected := s.Next(now); !actual[i].Equal(expected) {
+				t.Errorf("%d of %d: expected %v, got %v", i, n, expected, actual[i])
+			}
+		}
+	}
+	// Workers may take a moment to exit after the last result is written.
+	after := runtime.NumGoroutine()
+	for deadline := time.Now().Add(time.Second); after > before && time.Now().Before(deadline); after = runtime.NumGoroutine() {
+		time.Sleep(time.Millisecond)
+	}
+	if after > before {
+		t.Errorf("expected no leaked goroutines, had %d and still have %d after a second", before, after)
+	}
+}
+
+func benchmarkSpecs(b *testing.B) []*SpecSchedule {
+	var specs []*SpecSchedule
+	for i := 0; i < 1000; i++ {
+		sched, err := secondParser.Parse(fmt.Sprintf("%d %d %d * * *", i%60, (i*7)%60, i%24))
+		if err != nil {
+			b.Fatal(err)
+		}
+		specs = append(specs, sched.(*SpecSchedule))
+	}
+	return specs
+}
+
+func BenchmarkBatchNext(b *testing.B) {
+	specs := benchmarkSpecs(b)
+	now := time.Now()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BatchNext(specs, now)
+	}
+}
+
+func BenchmarkSequentialNext(b *testing.B) {
+	specs := benchmarkSpecs(b)
+	now := time.Now()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, s := range specs {
+			s.Next(now)
+		}
+	}
+}
+
+func TestNextUnix(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	from := time.Date(2024, 3, 10, 1, 59, 30, 0, time.UTC)
+	for i := 0; i < 200; i++ {
+		spec := GenerateSpec(r, DefaultGenOpts())
+		sched, _, err := ParseFlexible(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := sched.(*SpecSchedule)
+		for _, t0 := range []time.Time{from, from.In(time.Local), from.AddDate(0, 7, 0)} {
+			expected := int64(-1)
+			if next := s.Next(t0); !next.IsZero() {
+				expected = next.Unix()
+			}
+			if got := s.NextUnix(t0); got != expected {
+				t.Errorf("%s from %v: expected %d, got %d", spec, t0, expected, got)
+			}
+		}
+	}
+	never := mustParse(t, "0 0 30 2 *").(*SpecSchedule)
+	if got := never.NextUnix(from); got != -1 {
+		t.Errorf("expected -1 for no activation, got %d", got)
+	}
+}
+
+func BenchmarkNextUnix(b *testing.B) {
+	sched, err := ParseStandard("TZ=America/New_York 0 9 * * mon-fri")
+	if err != nil {
+		b.Fatal(err)
+	}
+	s := sched.(*SpecSchedule)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.Run("NextUnix", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s.NextUnix(from)
+		}
+	})
+	b.Run("Next", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s.Next(from).Unix()
+		}
+	})
+}
+
+func TestSecondDescriptors(t *testing.T) {
+	tests := []struct {
+		spec, time string
+		expected   bool
+	}{
+		{"@secondly", "Mon Jul 9 15:00:00 2012", true},
+		{"@secondly", "Mon Jul 9 15:00:01 2012", true},
+		{"@secondly", "Mon Jul 9 15:37:59 2012", true},
+		{"@minutely", "Mon Jul 9 15:00:00 2012", true},
+		{"@minutely", "Mon Jul 9 15:37:00 2012", true},
+		{"@minutely", "Mon Jul 9 15:37:01 2012", false},
+		{"@minutely", "Mon Jul 9 15:37:59 2012", false},
+	}
+	for _, test := range tests {
+		sched, err := secondParser.Parse(test.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		expected := getTime(test.time)
+		actual := sched.Next(expected.Add(-1 * time.Second))
+		if test.expected != (actual == expected) {
+			t.Errorf("%s on %s: expected match %v, next was %v", test.spec, test.time, test.expected, actual)
+		}
+	}
+}
+
+func TestTimesOfDay(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected []time.Duration
+	}{
+		{"0 30 9 * * *", []time.Duration{9*time.Hour + 30*time.Minute}},
+		{"0 0 */6 * * *", []time.Duration{0, 6 * time.Hour, 12 * time.Hour, 18 * time.Hour}},
+		{"15 0 12 * * MON", []time.Duration{12*time.Hour + 15*time.Second}},
+	}
+	for _, c := range tests {
+		sched, err := secondParser.Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual := sched.(*SpecSchedule).TimesOfDay()
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => expected %v, got %v", c.spec, c.expected, actual)
+		}
+	}
+
+	sched, _ := secondParser.Parse("* * * * * *")
+	if n := len(sched.(*SpecSchedule).TimesOfDay()); n != 86400 {
+		t.Errorf("expected 86400 times of day, got %d", n)
+	}
+}
+
+func TestNextOK(t *testing.T) {
+	from := getTime("Mon Jul 9 23:35 2012")
+	tests := []struct {
+		spec string
+		ok   bool
+	}{
+		{"0 0 0 30 Feb ?", false},
+		{"0 0 0 31 Apr ?", false},
+		{"0 0 0 * * ?", true},
+		{"@every 1h", true},
+	}
+	for _, c := range tests {
+		sched, err := secondParser.Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next, ok := NextOK(sched, from)
+		if ok != c.ok {
+			t.Errorf("%s: expected ok to be %v, got %v", c.spec, c.ok, ok)
+		}
+		if ok == next.IsZero() || !next.Equal(sched.Next(from)) {
+			t.Errorf("%s: NextOK returned %v, Next returned %v", c.spec, next, sched.Next(from))
+		}
+	}
+
+	if _, ok := EveryNDays(from, 3, 9, 0, 0).NextOK(from); !ok {
+		t.Error("expected every 3 days to have a next activation")
+	}
+	never := ScheduleComposite{Schedules: []Schedule{Every(time.Hour), Every(time.Hour)}}
+	if _, ok := never.NextOK(from); ok {
+		t.Error("expected a composite with no common activation to report false")
+	}
+	if _, ok := NextOK(&ZeroSchedule{}, from); ok {
+		t.Error("expected a schedule without NextOK to report false for the zero time")
+	}
+}
+
+func TestInLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sched, err := ParseStandard("30 9 * * mon-fri")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sched.(*SpecSchedule)
+
+	if !s.InLocation(time.UTC).Equal(s.InLocation(time.UTC)) {
+		t.Error("expected copies in the same location to be equal")
+	}
+	utc, inNY := s.InLocation(time.UTC), s.InLocation(ny)
+	if utc.Equal(inNY) || utc.Location != time.UTC || inNY.Location != ny {
+		t.Errorf("expected copies in different locations, got %v and %v", utc.Location, inNY.Location)
+	}
+	for i, field := range utc.fields() {
+		if field.Cmp(inNY.fields()[i]) != 0 {
+			t.Errorf("expected %s fields to be equal", fieldNames[i])
+		}
+	}
+	if s.Location != time.Local {
+		t.Errorf("expected the original to be unchanged, got %v", s.Location)
+	}
+	if inNil := inNY.InLocation(nil); inNil.Location != time.Local || !inNil.Equal(s) {
+		t.Errorf("expected a nil location to mean time.Local, got %v", inNil.Location)
+	}
+
+	// The copy is deep.
+	utc.Minute.SetBit(utc.Minute, 0, 1)
+	if s.Minute.Bit(0) != 0 {
+		t.Error("expected changes to the copy to leave the original alone")
+	}
+}
+
+func TestNilLocation(t *testing.T) {
+	sched, err := excludeParser.Parse("TZ=UTC 30 9 * * * !2024-12-25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utc := sched.(*SpecSchedule)
+	bare := utc.clone()
+	bare.Location = nil
+	local := utc.InLocation(time.Local)
+
+	// A schedule without a location is one in time.Local, in every respect.
+	if bare.Equal(utc) || !bare.Equal(local) || bare.LocationName() != "Local" {
+		t.Errorf("expected a nil location to equal time.Local only, got %s", bare.LocationName())
+	}
+	if spec := bare.String(); strings.Contains(spec, "TZ=") {
+		t.Errorf("expected no TZ prefix, got %s", spec)
+	} else if reparsed, _, err := ParseFlexible(spec); err != nil || !reparsed.(*SpecSchedule).Equal(local) {
+		t.Errorf("expected %s to parse back to the same schedule, got %v", spec, err)
+	}
+	if got, expected := bare.ActivationsPerYear(2024), local.ActivationsPerYear(2024); got != expected {
+		t.Errorf("expected %d activations, got %d", expected, got)
+	}
+	if dates := bare.ExcludedDates(); len(dates) != 1 || dates[0].Location() != time.Local {
+		t.Errorf("expected Christmas in time.Local, got %v", dates)
+	}
+}
+
+func TestInLocationDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utc := mustParse(t, "TZ=UTC 30 9 * * *").(*SpecSchedule)
+	inNY := utc.InLocation(ny)
+	if utc.EffectiveLocation() != time.UTC || inNY.EffectiveLocation() != ny {
+		t.Errorf("expected UTC and New York, got %v and %v", utc.EffectiveLocation(), inNY.EffectiveLocation())
+	}
+
+	// New York springs forward on 2024-03-10, so the copy's activations move an
+	// hour earlier in UTC while the original's stay put.
+	from := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	for _, expected := range []struct {
+		utc, ny time.Time
+	}{
+		{time.Date(2024, 3, 8, 9, 30, 0, 0, time.UTC), time.Date(2024, 3, 8, 14, 30, 0, 0, time.UTC)},
+		{time.Date(2024, 3, 9, 9, 30, 0, 0, time.UTC), time.Date(2024, 3, 9, 14, 30, 0, 0, time.UTC)},
+		{time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC), time.Date(2024, 3, 10, 13, 30, 0, 0, time.UTC)},
+		{time.Date(2024, 3, 11, 9, 30, 0, 0, time.UTC), time.Date(2024, 3, 11, 13, 30, 0, 0, time.UTC)},
+	} {
+		next, nextNY := utc.Next(from), inNY.Next(from)
+		if !next.Equal(expected.utc) {
+			t.Errorf("original: expected %v, got %v", expected.utc, next)
+		}
+		if !nextNY.Equal(expected.ny) {
+			t.Errorf("copy: expected %v, got %v", expected.ny, nextNY)
+		}
+		if local := nextNY.In(ny); local.Hour() != 9 || local.Minute() != 30 {
+			t.Errorf("copy: expected 09:30 in New York, got %v", local)
+		}
+		from = expected.ny
+	}
+
+	// A schedule without a location is read as time.Local.
+	bare := utc.clone()
+	bare.Location = nil
+	if bare.EffectiveLocation() != time.Local {
+		t.Errorf("expected time.Local, got %v", bare.EffectiveLocation())
+	}
+	from = time.Date(2024, 3, 8, 0, 0, 0, 0, ny)
+	if next, expected := bare.Next(from), time.Date(2024, 3, 8, 9, 30, 0, 0, ny); !next.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, next)
+	}
+}
+
+func TestHalfHourDST(t *testing.T) {
+	lordHowe, err := time.LoadLocation("Australia/Lord_Howe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// On 2024-10-06, Lord Howe Island's clocks go from 2:00 to 2:30, so the
+	// minutes run on from 1:59 into hour 2.
+	day := func(d, hour, min int) time.Time {
+		return time.Date(2024, 10, d, hour, min, 0, 0, lordHowe)
+	}
+	next := mustParse(t, "TZ=Australia/Lord_Howe 30 1 * * *")
+	if got, expected := next.Next(day(6, 1, 45)), day(7, 1, 30); !got.Equal(expected) {
+		t.Errorf("next: expected %v, got %v", expected, got)
+	}
+	latest := mustParse(t, "TZ=Australia/Lord_Howe 24 2 * * *")
+	if got, expected := latest.Latest(day(6, 2, 35)), day(5, 2, 24); !got.Equal(expected) {
+		t.Errorf("latest: expected %v, got %v", expected, got)
+	}
+
+	// The hours after the change still start on the hour.
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, lordHowe)
+	for _, spec := range []string{"TZ=Australia/Lord_Howe 0 22 6 10 *", "TZ=Australia/Lord_Howe */23 22 6 10 *"} {
+		if got, expected := mustParse(t, spec).Next(from), day(6, 22, 0); !got.Equal(expected) {
+			t.Errorf("%s: expected %v, got %v", spec, expected, got)
+		}
+	}
+	// On 2024-04-07, the clocks go back from 2:00 to 1:30.
+	back := mustParse(t, "TZ=Australia/Lord_Howe 0 3 7 4 *")
+	if got, expected := back.Next(from.AddDate(0, -2, 0)), time.Date(2024, 4, 7, 3, 0, 0, 0, lordHowe); !got.Equal(expected) {
+		t.Errorf("back: expected %v, got %v", expected, got)
+	}
+	sched, _, err := ParseFlexible("TZ=Australia/Lord_Howe */11 */23 22 3 10-12 4 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckScheduleInvariants(sched, 7); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parse := func(spec string) *SpecSchedule {
+		sched, err := ParseStandard(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sched.(*SpecSchedule)
+	}
+
+	tests := []struct {
+		a, b     *SpecSchedule
+		expected []string
+	}{
+		{parse("0,30 * * * *"), parse("0,30 * * * *"), nil},
+		{parse("0,30 * * * *"), parse("0,15 * * * *"), []string{"minute: {0,30} vs {0,15}"}},
+		{parse("0 9 * * mon-fri"), parse("0 9 * * *"), []string{"dow: {1-5} vs {*}"}},
+		{parse("0 9 1-31 * *"), parse("0 9 * * *"), []string{"dom: {1-31} vs {*}"}},
+		{parse("0 9 * * *").InLocation(time.UTC), parse("0 9 * * *").InLocation(ny),
+			[]string{"location: UTC vs America/New_York"}},
+		{parse("0 9 * * *").InLocation(time.UTC), parse("0 10 L * *").InLocation(ny),
+			[]string{"hour: {9} vs {10}", "dom: {*} vs {l}", "location: UTC vs America/New_York"}},
+		{parse("0 9 31 * *"), parse("0 9 31 * *"), nil},
+		{parse("0 9 &1-7 * mon"), parse("0 9 1-7 * mon"), []string{"dom: {&1-7} vs {1-7}"}},
+		{parse("0 9 15w * mon#2"), parse("0 9 15 * mon"), []string{"dom: {15w} vs {15}", "dow: {mon#2} vs {1}"}},
+	}
+	clamped, err := NewParser(Minute | Hour | Dom | Month | Dow | ClampDom).Parse("0 9 31 * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests = append(tests, struct {
+		a, b     *SpecSchedule
+		expected []string
+	}{clamped.(*SpecSchedule), parse("0 9 31 * *"), []string{"dom: {31 clamped 31} vs {31}"}})
+	for _, c := range tests {
+		if actual := c.a.Diff(c.b); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("expected %q, got %q", c.expected, actual)
+		}
+	}
+}
+
+func TestWeekOfYear(t *testing.T) {
+	weekParser := NewParser(Minute | Hour | Dom | Month | Dow | Week)
+	sched, err := weekParser.Parse("0 9 * * * 1,26")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// In 2026, week 1 starts on Monday the 29th of December 2025, and week 53
+	// runs into 2027, whose week 1 starts on the 4th of January.
+	var expected []time.Time
+	for _, monday := range []time.Time{
+		time.Date(2025, 12, 29, 9, 0, 0, 0, time.Local),
+		time.Date(2026, 6, 22, 9, 0, 0, 0, time.Local),
+		time.Date(2027, 1, 4, 9, 0, 0, 0, time.Local),
+	} {
+		for d := 0; d < 7; d++ {
+			expected = append(expected, monday.AddDate(0, 0, d))
+		}
+	}
+	next := time.Date(2025, 12, 1, 0, 0, 0, 0, time.Local)
+	for _, e := range expected {
+		if next = sched.Next(next); !next.Equal(e) {
+			t.Fatalf("expected %v, got %v", e, next)
+		}
+	}
+
+	if latest, e := sched.Latest(time.Date(2026, 6, 1, 0, 0, 0, 0, time.Local)),
+		time.Date(2026, 1, 4, 9, 0, 0, 0, time.Local); !latest.Equal(e) {
+		t.Errorf("expected latest %v, got %v", e, latest)
+	}
+
+	if all, _ := weekParser.Parse("0 9 * * * *"); all.(*SpecSchedule).WeekOfYear != nil {
+		t.Error("expected every week to leave WeekOfYear unset")
+	}
+	if _, err := weekParser.Parse("0 9 * * * 54"); err == nil {
+		t.Error("expected week 54 to be rejected")
+	}
+}
+
+func TestNextWithSkew(t *testing.T) {
+	sched := mustParse(t, "TZ=UTC 0 * * * *").(*SpecSchedule)
+	tests := []struct {
+		t        time.Time
+		skew     time.Duration
+		expected time.Time
+	}{
+		// A clock 5s fast reaches the hour 5s early.
+		{time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC), 5 * time.Second, time.Date(2024, 1, 1, 9, 59, 55, 0, time.UTC)},
+		// A clock 5s slow reaches it 5s late.
+		{time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC), -5 * time.Second, time.Date(2024, 1, 1, 10, 0, 5, 0, time.UTC)},
+		// At 9:59:57 a clock 5s fast already reads past the hour.
+		{time.Date(2024, 1, 1, 9, 59, 57, 0, time.UTC), 5 * time.Second, time.Date(2024, 1, 1, 10, 59, 55, 0, time.UTC)},
+		// At 9:59:57 an accurate clock has yet to reach it.
+		{time.Date(2024, 1, 1, 9, 59, 57, 0, time.UTC), 0, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range tests {
+		if actual := sched.NextWithSkew(c.t, c.skew); !actual.Equal(c.expected) {
+			t.Errorf("%v with skew %v: expected %v, got %v", c.t, c.skew, c.expected, actual)
+		}
+	}
+
+	never := mustParse(t, "0 0 30 2 *").(*SpecSchedule)
+	if actual := never.NextWithSkew(time.Now(), time.Hour); !actual.IsZero() {
+		t.Errorf("expected the zero time, got %v", actual)
+	}
+}
+
+func TestSource(t *testing.T) {
+	for _, spec := range []string{
+		"0 9 * * MON-FRI",
+		"  0   9 * * monday-friday ",
+		"TZ=Asia/Tokyo 30 9 1,15 * *",
+		"@daily",
+	} {
+		sched, err := ParseStandard(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if source := sched.(*SpecSchedule).Source(); source != spec {
+			t.Errorf("expected the source %q, got %q", spec, source)
+		}
+	}
+
+	built := &SpecSchedule{
+		Second:   big.NewInt(1 << 0),
+		Minute:   big.NewInt(1 << 30),
+		Hour:     big.NewInt(1 << 9),
+		Dom:      all(dom),
+		Month:    all(months),
+		Dow:      getBits(1, 5, 1),
+		Year:     all(years),
+		Location: time.UTC,
+	}
+	if source, expected := built.Source(), "TZ=UTC 0 30 9 * * 1-5 *"; source != expected || source != built.String() {
+		t.Errorf("expected a built schedule's source to be %q, got %q", expected, source)
+	}
+
+	parsed, _ := ParseStandard("TZ=UTC 0 9 * * *")
+	if source := parsed.(*SpecSchedule).InLocation(time.UTC).Source(); source != "TZ=UTC 0 9 * * *" {
+		t.Errorf("expected the source to survive the same location, got %q", source)
+	}
+	if source := parsed.(*SpecSchedule).InLocation(time.Local).Source(); source != "0 0 9 * * * *" {
+		t.Errorf("expected the source to be canonical in another location, got %q", source)
+	}
+	spelled, _ := ParseStandard("TZ=UTC 00 09 * * *")
+	if !parsed.(*SpecSchedule).Equal(spelled.(*SpecSchedule)) {
+		t.Error("expected the source not to make equal schedules differ")
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		spec, expected string
+	}{
+		{"0 9 * * MON-FRI", "0 0 9 * * 1-5 *"},
+		{"*/15 9-17 * * *", "0 */15 9-17 * * * *"},
+		{"TZ=UTC 0 0 L * *", "TZ=UTC 0 0 0 l * * *"},
+		{"0 0 * * monl,fri", "0 0 0 * * 5,1l *"},
+		{"0 0 1-31 * MON", "0 0 0 1-31 * 1 *"},
+		{"0 0 1 * MON", "0 0 0 1 * 1 *"},
+		{"0 0 1 * ?", "0 0 0 1 * * *"},
+	}
+	for _, c := range tests {
+		sched := mustParse(t, c.spec).(*SpecSchedule)
+		if s := sched.String(); s != c.expected {
+			t.Errorf("%s: expected %q, got %q", c.spec, c.expected, s)
+		}
+
+		// The canonical form describes the same schedule.
+		reparsed, _, err := ParseFlexible(sched.String())
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		if diff := sched.Diff(reparsed.(*SpecSchedule)); diff != nil {
+			t.Errorf("%s: reparsed differently: %v", c.spec, diff)
+		}
+	}
+
+	years, err := quartzParser.Parse("0 0 0 1 1 ? 2025-2030/5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := years.(*SpecSchedule).String(); s != "0 0 0 1 1 * 2025,2030" {
+		t.Errorf("expected the years, got %q", s)
+	}
+}
+
+func TestNextAtOrAfter(t *testing.T) {
+	sched := mustParse(t, "TZ=UTC 30 9 * * *").(*SpecSchedule)
+	at := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		time, expected time.Time
+	}{
+		{at, at},
+		{at.Add(500 * time.Millisecond), at},
+		{at.Add(-time.Nanosecond), at},
+		{at.Add(time.Second), at.AddDate(0, 0, 1)},
+	}
+	for _, c := range tests {
+		if actual := sched.NextAtOrAfter(c.time); !actual.Equal(c.expected) {
+			t.Errorf("%v: expected %v, got %v", c.time, c.expected, actual)
+		}
+	}
+
+	millis, err := NewParser(Millisecond | Second | Minute | Hour | Dom | Month | Dow).Parse("TZ=UTC 500 0 30 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	half := at.Add(500 * time.Millisecond)
+	if actual := millis.(*SpecSchedule).NextAtOrAfter(half.Add(999 * time.Microsecond)); !actual.Equal(half) {
+		t.Errorf("expected %v, got %v", half, actual)
+	}
+	if actual := millis.(*SpecSchedule).NextAtOrAfter(at); !actual.Equal(half) {
+		t.Errorf("expected %v, got %v", half, actual)
+	}
+}
+
+// TestNextAtOrAfterProperties checks NextAtOrAfter against Next and Matches
+// for random schedules and times.
+func TestNextAtOrAfterProperties(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	parser := NewParser(Second | Minute | Hour | Dom | Month | Dow)
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 300; i++ {
+		spec := randomSpec(r)
+		sched, err := parser.Parse(spec)
+		if err != nil {
+			t.Fatalf("%s: %v", spec, err)
+		}
+		s := sched.(*SpecSchedule).InLocation([]*time.Location{time.UTC, kolkata}[i%2])
+		for j := 0; j < 20; j++ {
+			tm := start.Add(time.Duration(r.Int63n(int64(365 * 24 * time.Hour))))
+			if j%2 == 1 {
+				// Land on an activation, somewhere within its second.
+				if next := s.Next(tm); !next.IsZero() {
+					tm = next.Add(time.Duration(r.Int63n(int64(time.Second))))
+				}
+			}
+			at, next := s.NextAtOrAfter(tm), s.Next(tm)
+			if s.Matches(tm) {
+				if !at.Equal(tm.Truncate(time.Second)) {
+					t.Errorf("%s at %v: matches, but NextAtOrAfter is %v", spec, tm, at)
+				}
+			} else if !at.Equal(next) {
+				t.Errorf("%s at %v: doesn't match, but NextAtOrAfter is %v and Next %v", spec, tm, at, next)
+			}
+			if !next.IsZero() && !s.Matches(next) {
+				t.Errorf("%s: Next(%v) is %v, which doesn't match", spec, tm, next)
+			}
+			if !at.IsZero() && (!s.Matches(at) || at.Before(tm.Truncate(time.Second))) {
+				t.Errorf("%s: NextAtOrAfter(%v) is %v", spec, tm, at)
+			}
+		}
+	}
+}
+
+// randomSpec returns a random spec with seconds.
+func randomSpec(r *rand.Rand) string {
+	fields := make([]string, 0, 6)
+	for _, b := range []bounds{seconds, minutes, hours, dom, months, dow} {
+		lo, n := int(b.min), int(b.max-b.min+1)
+		switch r.Intn(5) {
+		case 0:
+			fields = append(fields, "*")
+		case 1:
+			fields = append(fields, fmt.Sprint(lo+r.Intn(n)))
+		case 2:
+			a := lo + r.Intn(n)
+			fields = append(fields, fmt.Sprintf("%d-%d", a, a+r.Intn(int(b.max)-a+1)))
+		case 3:
+			fields = append(fields, fmt.Sprintf("*/%d", 1+r.Intn(n)))
+		default:
+			fields = append(fields, fmt.Sprintf("%d,%d", lo+r.Intn(n), lo+r.Intn(n)))
+		}
+	}
+	return strings.Join(fields, " ")
+}
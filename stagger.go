@@ -0,0 +1,41 @@
+package cron
+
+import (
+	"hash/fnv"
+	"strconv"
+	"time"
+)
+
+// stagger puts off the entry's first run after the Cron was started, at
+// start, to its offset into the WithStartupStagger window, if it falls due
+// before then. Entries whose runs are at least the window apart are left
+// alone, as they can't pile up in it; only schedules known to have no state
+// are asked for a second activation to tell.
+func (c *Cron) stagger(e *Entry, start time.Time) {
+	e.Stagger = 0
+	if c.startupStagger <= 0 || e.Next.IsZero() || !e.Next.Before(start.Add(c.startupStagger)) {
+		return
+	}
+	if stateless(e.Schedule) {
+		if after := e.Schedule.Next(e.Next); after.IsZero() || after.Sub(e.Next) >= c.startupStagger {
+			return
+		}
+	}
+	if at := start.Add(staggerOffset(e, c.startupStagger)); at.After(e.Next) {
+		e.Stagger = at.Sub(e.Next)
+		e.Next = at
+		c.logger.Info("stagger", "entry", e.ID, "name", e.Name, "next", e.Next, "delay", e.Stagger)
+	}
+}
+
+// staggerOffset returns the entry's offset into the window, chosen by a hash
+// of its name, or of its ID if it has none.
+func staggerOffset(e *Entry, window time.Duration) time.Duration {
+	key := e.Name
+	if key == "" {
+		key = strconv.Itoa(int(e.ID))
+	}
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return time.Duration(h.Sum64() % uint64(window))
+}
@@ -0,0 +1,83 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartupStagger(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 5, 0, time.UTC)
+	window := 2 * time.Minute
+	specs := map[string]string{
+		"minutely": "0 * * * * ?",
+		"secondly": "* * * * * ?",
+		"hourly":   "0 0 * * * ?", // first due after the window
+		"daily":    "6 0 0 * * ?", // due in the window, but runs too seldom to pile up
+	}
+	newCron := func(names ...string) (*Cron, *fakeClock) {
+		cron, fc := newFakeCron(start, WithStartupStagger(window))
+		for _, name := range names {
+			cron.AddFunc(specs[name], func() {}, WithName(name))
+		}
+		cron.Start()
+		return cron, fc
+	}
+
+	cron, fc := newCron("minutely", "secondly", "hourly")
+	defer cron.Stop()
+	timer := <-fc.timers
+	expected := make(map[string]time.Time)
+	for _, e := range cron.Entries() {
+		next := e.Schedule.Next(start)
+		if e.Name != "hourly" {
+			if at := start.Add(staggerOffset(&e, window)); at.After(next) {
+				next = at
+			}
+		}
+		expected[e.Name] = next
+		if !e.Next.Equal(next) || e.Stagger != next.Sub(e.Schedule.Next(start)) {
+			t.Errorf("%s: expected next %v, got %v put off by %v", e.Name, next, e.Next, e.Stagger)
+		}
+	}
+	if expected["hourly"] != time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC) {
+		t.Errorf("expected the hourly entry to be unaffected, got %v", expected["hourly"])
+	}
+
+	// The offsets depend on the names alone.
+	other, otherClock := newCron("hourly", "secondly", "minutely")
+	<-otherClock.timers
+	for _, e := range other.Entries() {
+		if !e.Next.Equal(expected[e.Name]) {
+			t.Errorf("%s: expected the same next %v, got %v", e.Name, expected[e.Name], e.Next)
+		}
+	}
+	other.Stop()
+
+	// Entries that run no more than once a window aren't held back.
+	daily, dailyClock := newCron("daily")
+	<-dailyClock.timers
+	if e := daily.Entries()[0]; !e.Next.Equal(time.Date(2024, 1, 1, 0, 0, 6, 0, time.UTC)) || e.Stagger != 0 {
+		t.Errorf("expected the daily entry to be unaffected, got %v put off by %v", e.Next, e.Stagger)
+	}
+	daily.Stop()
+
+	// Entries held back aren't stalled.
+	fc.mu.Lock()
+	fc.now = start.Add(70 * time.Second)
+	fc.mu.Unlock()
+	if unhealthy := cron.Health().Unhealthy(); len(unhealthy) > 0 {
+		t.Errorf("expected every entry to be healthy, got %v", unhealthy)
+	}
+
+	// Runs after the first follow the schedule.
+	fc.advance(timer)
+	for _, e := range cron.Entries() {
+		if e.Prev.IsZero() {
+			continue
+		}
+		if !e.Prev.Equal(expected[e.Name]) || !e.Next.Equal(e.Schedule.Next(e.Prev)) {
+			t.Errorf("%s: expected a run at %v, then one at %v, got %v and %v",
+				e.Name, expected[e.Name], e.Schedule.Next(e.Prev), e.Prev, e.Next)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package cron
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// ToStandard returns the schedule as a standard 5-field spec, of minute, hour,
+// day of month, month and day of week, for systems that accept nothing else.
+// Whatever can't be expressed in those fields is dropped or widened, with a
+// Warning for each:
+//
+//   - a second other than 0 is dropped, so the spec runs at the start of the
+//     minute instead (WarnSecondsDropped);
+//   - years and weeks of the year are dropped (WarnYearsDropped and
+//     WarnWeeksDropped), as are excluded dates (WarnDatesDropped) and the
+//     location (WarnLocationDropped);
+//   - last days and weekdays of the month, and days clamped by ClampDom, are
+//     widened to every day they could fall on (WarnLastDaysWidened), as are
+//     nearest weekdays and nth weekdays of the month (WarnWeekdaysWidened);
+//   - days required to match both day fields, by DomAndDow, are widened to
+//     days matching either (WarnDomAndDowWidened).
+//
+// Apart from the dropped second, the result therefore runs at least whenever
+// the schedule does. It returns an error if the schedule runs at more than one
+// second of the minute, or at milliseconds past the second, or if a field is
+// empty.
+func (s *SpecSchedule) ToStandard() (string, []Warning, error) {
+	var warnings []Warning
+	warn := collect(&warnings)
+
+	fields := s.clone().fields()
+	for _, i := range []int{0, 1, 2, 4} { // the day fields are checked after widening
+		if countBits(fields[i], *fieldBounds[i]) == 0 {
+			return "", nil, fmt.Errorf("the %s field is empty", fieldNames[i])
+		}
+	}
+	if s.subSecond() {
+		return "", nil, fmt.Errorf("runs at milliseconds %s, which a standard spec can't express",
+			FormatField(s.milliseconds(), milliseconds.min, milliseconds.max, nil))
+	}
+	second := fields[0]
+	if n := countBits(second, seconds); n > 1 {
+		return "", nil, fmt.Errorf("runs at seconds %s, which a standard spec can't express",
+			FormatField(second, seconds.min, seconds.max, nil))
+	}
+	if second.Bit(0) == 0 {
+		warn(WarnSecondsDropped, SeverityWarning, "second", "runs at second 0 rather than second %s",
+			FormatField(second, seconds.min, seconds.max, nil))
+	}
+
+	s.widen(fields, warn)
+	if countBits(fields[3], *fieldBounds[3]) == 0 || countBits(fields[5], *fieldBounds[5]) == 0 {
+		return "", nil, fmt.Errorf("the day fields are empty")
+	}
+	if s.EffectiveLocation() != time.Local {
+		warn(WarnLocationDropped, SeverityInfo, "", "must be interpreted in %s", s.LocationName())
+	}
+
+	tokens := make([]string, 0, 5)
+	for i := 1; i <= 5; i++ {
+		tokens = append(tokens, formatPlace(i, fields[i]))
+	}
+	return strings.Join(tokens, " "), warnings, nil
+}
+
+// warnFunc records a Warning.
+type warnFunc func(code WarningCode, severity Severity, field, format string, args ...interface{})
+
+// collect returns a warnFunc that appends to warnings.
+func collect(warnings *[]Warning) warnFunc {
+	return func(code WarningCode, severity Severity, field, format string, args ...interface{}) {
+		*warnings = append(*warnings, Warning{
+			Code:     code,
+			Message:  fmt.Sprintf(format, args...),
+			Severity: severity,
+			Field:    field,
+		})
+	}
+}
+
+// widen changes the given copies of the schedule's fields to those of the
+// plain day fields, as described by ToStandard, and warns of the years, weeks
+// and dates that are dropped. Seconds and the location are left to the caller.
+func (s *SpecSchedule) widen(fields []*big.Int, warn warnFunc) {
+	dom, dow := fields[3], fields[5]
+	widened := false
+	for k := 0; k <= 7; k++ {
+		// The k'th day before the last, which is from the 28th to the 31st.
+		if dom.Bit(55-k) > 0 {
+			dom.SetBit(dom, 55-k, 0)
+			for d := 28 - k; d <= 31-k; d++ {
+				dom.SetBit(dom, d, 1)
+			}
+			widened = true
+		}
+	}
+	for v := 29; v <= 31; v++ {
+		if dom.Bit(domClampBit(v)) > 0 {
+			dom.SetBit(dom, domClampBit(v), 0)
+			for d := 28; d < v; d++ {
+				dom.SetBit(dom, d, 1)
+			}
+			widened = true
+		}
+	}
+	if widened {
+		warn(WarnLastDaysWidened, SeverityWarning, "dom",
+			"last days of the month are widened to every day they could fall on")
+	}
+	widened = false
+	for d := 0; d <= 6; d++ {
+		if dow.Bit(49+d) > 0 {
+			dow.SetBit(dow, 49+d, 0)
+			dow.SetBit(dow, d, 1)
+			widened = true
+		}
+	}
+	if widened {
+		warn(WarnLastDaysWidened, SeverityWarning, "dow",
+			"last weekdays of the month are widened to every such weekday")
+	}
+	widened = false
+	for v := 1; v <= 31; v++ {
+		// The nearest weekday is at most two days away.
+		if dom.Bit(domWeekdayBit(v)) > 0 {
+			dom.SetBit(dom, domWeekdayBit(v), 0)
+			for d := v - 2; d <= v+2; d++ {
+				if d >= 1 && d <= 31 {
+					dom.SetBit(dom, d, 1)
+				}
+			}
+			widened = true
+		}
+	}
+	if dom.Bit(lastWeekdayBit) > 0 {
+		dom.SetBit(dom, lastWeekdayBit, 0)
+		for d := 26; d <= 31; d++ {
+			dom.SetBit(dom, d, 1)
+		}
+		widened = true
+	}
+	for n := 1; n <= 5; n++ {
+		for w := 0; w <= 6; w++ {
+			if dow.Bit(nthWeekdayBit(n, w)) > 0 {
+				dow.SetBit(dow, nthWeekdayBit(n, w), 0)
+				dow.SetBit(dow, w, 1)
+				widened = true
+			}
+		}
+	}
+	if widened {
+		warn(WarnWeekdaysWidened, SeverityWarning, "",
+			"nearest and nth weekdays of the month are widened to every day they could fall on")
+	}
+	if dom.Bit(domAndDowBit) > 0 {
+		dom.SetBit(dom, domAndDowBit, 0)
+		warn(WarnDomAndDowWidened, SeverityWarning, "dow",
+			"runs on days matching either the day of month or the day of week, not both")
+	}
+	if !isAll(fields[6], years) {
+		warn(WarnYearsDropped, SeverityWarning, "year", "runs in every year, not only %s",
+			FormatField(fields[6], years.min, years.max, years.names))
+	}
+	if s.WeekOfYear != nil {
+		warn(WarnWeeksDropped, SeverityWarning, "week", "runs in every week of the year, not only %s",
+			FormatField(s.WeekOfYear, weeks.min, weeks.max, nil))
+	}
+	if len(s.excluded) > 0 {
+		warn(WarnDatesDropped, SeverityWarning, "", "runs on the excluded dates %s",
+			strings.TrimPrefix(s.formatExcludedDates(), "!"))
+	}
+}
@@ -0,0 +1,110 @@
+package cron
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToStandard(t *testing.T) {
+	flexible := func(spec string) *SpecSchedule {
+		sched, _, err := ParseFlexible(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sched.(*SpecSchedule)
+	}
+	weekParser := NewParser(Second | Minute | Hour | Dom | Month | Dow | Year | Week)
+	withParser := func(p Parser, spec string) *SpecSchedule {
+		sched, err := p.Parse(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sched.(*SpecSchedule)
+	}
+
+	tests := []struct {
+		sched    *SpecSchedule
+		expected string
+		codes    []WarningCode
+	}{
+		{flexible("30 9 * * 1-5"), "30 9 * * 1-5", nil},
+		{flexible("*/15 9-17 1,15 * *"), "*/15 9-17 1,15 * *", nil},
+		{flexible("0 30 9 * * *"), "30 9 * * *", nil},
+		{flexible("15 30 9 * * *"), "30 9 * * *", []WarningCode{WarnSecondsDropped}},
+		{flexible("0 30 9 * * * 2025-2027"), "30 9 * * *", []WarningCode{WarnYearsDropped}},
+		{flexible("0 0 L * *"), "0 0 28-31 * *", []WarningCode{WarnLastDaysWidened}},
+		{flexible("0 0 2L 2 *"), "0 0 26-29 2 *", []WarningCode{WarnLastDaysWidened}},
+		{flexible("0 0 * * 5L"), "0 0 * * 5", []WarningCode{WarnLastDaysWidened}},
+		{flexible("0 0 1 * monl"), "0 0 1 * 1", []WarningCode{WarnLastDaysWidened}},
+		{flexible("0 0 15w,lw * *"), "0 0 13-17,26-31 * *", []WarningCode{WarnWeekdaysWidened}},
+		{flexible("0 0 * * mon#2,5#1"), "0 0 * * 1,5", []WarningCode{WarnWeekdaysWidened}},
+		{flexible("0 0 1-31 * 1"), "0 0 1-31 * 1", nil},
+		{flexible("TZ=UTC 0 0 * * *"), "0 0 * * *", []WarningCode{WarnLocationDropped}},
+		{withParser(NewParser(Minute|Hour|Dom|Month|Dow|ClampDom), "0 0 31 * *"), "0 0 28-31 * *", []WarningCode{WarnLastDaysWidened}},
+		{withParser(NewParser(Minute|Hour|Dom|Month|Dow|DomAndDow), "0 0 1-7 * 1"), "0 0 1-7 * 1", []WarningCode{WarnDomAndDowWidened}},
+		{withParser(weekParser, "0 0 0 * * 1 * 1-26"), "0 0 * * 1", []WarningCode{WarnWeeksDropped}},
+		{flexible("0 0 * * 1-5 !2024-12-25"), "0 0 * * 1-5", []WarningCode{WarnDatesDropped}},
+	}
+
+	strict := NewParser(Minute | Hour | Dom | Month | Dow)
+	for _, c := range tests {
+		spec := c.sched.String()
+		actual, warnings, err := c.sched.ToStandard()
+		if err != nil {
+			t.Errorf("%s: %v", spec, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s: expected %q, got %q", spec, c.expected, actual)
+		}
+		var codes []WarningCode
+		for _, w := range warnings {
+			codes = append(codes, w.Code)
+		}
+		if !reflect.DeepEqual(codes, c.codes) {
+			t.Errorf("%s: expected warnings %v, got %v", spec, c.codes, warnings)
+		}
+
+		// The result must parse as a standard spec and run at least whenever
+		// the schedule does, apart from the dropped second.
+		parsed, err := strict.Parse(actual)
+		if err != nil {
+			t.Errorf("%s: %q doesn't parse: %v", spec, actual, err)
+			continue
+		}
+		loc := c.sched.Location
+		standard := parsed.(*SpecSchedule).InLocation(loc)
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, loc)
+		for next := c.sched.Next(from); !next.IsZero() && next.Year() < 2027; next = c.sched.Next(next) {
+			minute := next.Truncate(time.Minute)
+			if got := standard.Next(minute.Add(-time.Second)); !got.Equal(minute) {
+				t.Errorf("%s: %q misses %v", spec, actual, next)
+				break
+			}
+		}
+	}
+}
+
+func TestToStandardErrors(t *testing.T) {
+	for _, spec := range []string{"*/15 * * * * *", "0,30 0 0 * * *", "* * * * * *"} {
+		sched, _, err := ParseFlexible(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s, _, err := sched.(*SpecSchedule).ToStandard(); err == nil || !strings.Contains(err.Error(), "seconds") {
+			t.Errorf("%s: expected an error about seconds, got %q, %v", spec, s, err)
+		}
+	}
+	if _, _, err := (&SpecSchedule{}).ToStandard(); err == nil {
+		t.Error("expected an error for an empty schedule")
+	}
+	millis, err := millisecondParser.Parse("0,500 0 0 0 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, _, err := millis.(*SpecSchedule).ToStandard(); err == nil || !strings.Contains(err.Error(), "milliseconds") {
+		t.Errorf("expected an error about milliseconds, got %q, %v", s, err)
+	}
+}
@@ -0,0 +1,55 @@
+package cron
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// DefaultSampleWindowFactor is how many activations SampleNext draws from: a
+// sample of n is taken from the first DefaultSampleWindowFactor*n activations.
+const DefaultSampleWindowFactor = 10
+
+// SampleNext returns n activation times chosen uniformly at random from the
+// first DefaultSampleWindowFactor*n activations after from, in chronological
+// order. Fewer than n times are returned if the schedule runs out of
+// activations.
+func (s *SpecSchedule) SampleNext(from time.Time, n int) []time.Time {
+	return s.SampleNextWithRand(from, n, DefaultSampleWindowFactor, rand.New(rand.NewSource(time.Now().UnixNano())))
+}
+
+// SampleNextWithRand is like SampleNext, but draws from the first factor*n
+// activations, or DefaultSampleWindowFactor*n if factor is less than 1, and
+// from the given source of randomness so that the sample can be reproduced.
+// Each activation in the window is computed, so large factors on dense
+// schedules make sampling correspondingly expensive.
+func (s *SpecSchedule) SampleNextWithRand(from time.Time, n, factor int, r *rand.Rand) []time.Time {
+	if n <= 0 {
+		return nil
+	}
+	if factor < 1 {
+		factor = DefaultSampleWindowFactor
+	}
+
+	// Reservoir sampling (Algorithm R): keep the first n activations, then
+	// replace a random member with the i'th activation with probability n/i.
+	var (
+		sample = make([]time.Time, 0, n)
+		window = factor * n
+		t      = from
+	)
+	for i := 0; i < window; i++ {
+		t = s.Next(t)
+		if t.IsZero() {
+			break
+		}
+		if i < n {
+			sample = append(sample, t)
+		} else if j := r.Intn(i + 1); j < n {
+			sample[j] = t
+		}
+	}
+
+	sort.Slice(sample, func(i, j int) bool { return sample[i].Before(sample[j]) })
+	return sample
+}
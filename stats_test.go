@@ -0,0 +1,60 @@
+package cron
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSampleNext(t *testing.T) {
+	sched, err := ParseStandard("0 9 * * 1-5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := sched.(*SpecSchedule)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	a := spec.SampleNextWithRand(from, 50, DefaultSampleWindowFactor, rand.New(rand.NewSource(1)))
+	b := spec.SampleNextWithRand(from, 50, DefaultSampleWindowFactor, rand.New(rand.NewSource(1)))
+	if len(a) != 50 {
+		t.Fatalf("expected 50 samples, got %d", len(a))
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Error("expected the same sample for the same seed")
+	}
+	for i := 1; i < len(a); i++ {
+		if !a[i-1].Before(a[i]) {
+			t.Errorf("expected samples in order, got %v before %v", a[i-1], a[i])
+		}
+	}
+
+	counts := make(map[time.Weekday]int)
+	for _, t := range spec.SampleNextWithRand(from, 1000, 0, rand.New(rand.NewSource(2))) {
+		counts[t.Weekday()]++
+	}
+	if len(counts) != 5 {
+		t.Fatalf("expected samples on five weekdays, got %v", counts)
+	}
+	for day, count := range counts {
+		if day == time.Saturday || day == time.Sunday {
+			t.Errorf("unexpected sample on %v", day)
+		}
+		if count < 150 || count > 250 {
+			t.Errorf("expected roughly 200 samples on %v, got %d", day, count)
+		}
+	}
+
+	// A factor of 1 leaves only the first n activations to choose from.
+	first := spec.SampleNextWithRand(from, 5, 1, rand.New(rand.NewSource(3)))
+	for i, next := 0, from; i < 5; i++ {
+		next = spec.Next(next)
+		if i >= len(first) || !first[i].Equal(next) {
+			t.Fatalf("expected the first 5 activations, got %v", first)
+		}
+	}
+
+	if got := spec.SampleNext(from, 0); got != nil {
+		t.Errorf("expected no samples, got %v", got)
+	}
+}
@@ -0,0 +1,40 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// Stream returns a channel that receives the schedule's activations after the
+// given time, in order. Activations are computed as fast as they are
+// consumed, not in real time. The channel is closed when the context is
+// cancelled or the schedule has no more activations.
+func (s *SpecSchedule) Stream(ctx context.Context, from time.Time) <-chan time.Time {
+	ch := make(chan time.Time)
+	go func() {
+		defer close(ch)
+		for next := s.Next(from); !next.IsZero(); next = s.Next(next) {
+			select {
+			case ch <- next:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// AfterFunc waits for the schedule's next activation after the given time,
+// and then calls f in its own goroutine, as time.AfterFunc does. The
+// activation is measured against the wall clock, so one already past runs at
+// once. Only that one activation is handled; to run f at every activation,
+// call AfterFunc again from f, or add a job to a Cron instead. The returned
+// Timer can cancel the call with its Stop method. If the schedule has no
+// activation after from, f is never called and AfterFunc returns nil.
+func (s *SpecSchedule) AfterFunc(from time.Time, f func()) *time.Timer {
+	next := s.Next(from)
+	if next.IsZero() {
+		return nil
+	}
+	return time.AfterFunc(time.Until(next), f)
+}
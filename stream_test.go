@@ -0,0 +1,82 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStream(t *testing.T) {
+	sched := mustParse(t, "TZ=UTC 0 * * * *").(*SpecSchedule)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := sched.Stream(ctx, from)
+	for i := 1; i <= 5; i++ {
+		if got, want := <-ch, from.Add(time.Duration(i)*time.Hour); !got.Equal(want) {
+			t.Errorf("activation %d: got %v, want %v", i, got, want)
+		}
+	}
+	cancel()
+
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("channel not closed after cancel")
+		}
+	}
+}
+
+func TestStreamExhausted(t *testing.T) {
+	s, err := quartzParser.Parse("TZ=UTC 0 0 0 1 1 ? 2026")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sched := s.(*SpecSchedule)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var got []time.Time
+	for next := range sched.Stream(context.Background(), from) {
+		got = append(got, next)
+	}
+	if len(got) != 1 || !got[0].Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %v, want a single activation on 2026-01-01", got)
+	}
+}
+
+func TestAfterFunc(t *testing.T) {
+	sched, err := secondParser.Parse("* * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ran := make(chan time.Time, 1)
+	start := time.Now()
+	timer := sched.(*SpecSchedule).AfterFunc(start, func() { ran <- time.Now() })
+	select {
+	case at := <-ran:
+		// Allow for the wall clock and the timer's monotonic clock to differ.
+		if expected := start.Truncate(time.Second).Add(time.Second); at.Before(expected.Add(-10 * time.Millisecond)) {
+			t.Errorf("expected f to run at %v, ran at %v", expected, at)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected f to run within a second")
+	}
+	if timer.Stop() {
+		t.Error("expected the timer to have fired")
+	}
+
+	stopped := sched.(*SpecSchedule).AfterFunc(time.Now().Add(time.Hour), func() { t.Error("expected f not to run") })
+	if !stopped.Stop() {
+		t.Error("expected to stop the timer before it fired")
+	}
+
+	never := mustParse(t, "0 0 30 2 *").(*SpecSchedule)
+	if timer := never.AfterFunc(time.Now(), func() {}); timer != nil {
+		t.Error("expected no timer for a schedule that never runs")
+	}
+}
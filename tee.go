@@ -0,0 +1,60 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// TeeSchedule activates as its primary schedule does, and alongside keeps
+// track of when each of a number of observer schedules would next activate,
+// e.g. to confirm that a new schedule lines up with the one it replaces. The
+// observers never affect the activations.
+type TeeSchedule struct {
+	primary   Schedule
+	observers []Schedule
+
+	mu      sync.Mutex
+	cursors []time.Time // each observer's next activation, as of the last Next
+}
+
+// Tee returns a TeeSchedule that activates as primary does and follows the
+// given observers.
+func Tee(primary Schedule, observers ...Schedule) *TeeSchedule {
+	return &TeeSchedule{
+		primary:   primary,
+		observers: observers,
+		cursors:   make([]time.Time, len(observers)),
+	}
+}
+
+// Next returns the primary schedule's next activation, and advances each
+// observer's cursor to its own next activation after t.
+func (s *TeeSchedule) Next(t time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, o := range s.observers {
+		s.cursors[i] = o.Next(t)
+	}
+	return s.primary.Next(t)
+}
+
+// NextOK is like Next, but reports whether the primary schedule has a next
+// activation.
+func (s *TeeSchedule) NextOK(t time.Time) (time.Time, bool) {
+	next := s.Next(t)
+	return next, !next.IsZero()
+}
+
+// Latest returns the primary schedule's latest activation at or before t.
+func (s *TeeSchedule) Latest(t time.Time) time.Time {
+	return s.primary.Latest(t)
+}
+
+// ObserverNext returns the i'th observer's next activation after the time last
+// given to Next, or the zero time if Next hasn't been called or the observer
+// has no such activation.
+func (s *TeeSchedule) ObserverNext(i int) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursors[i]
+}
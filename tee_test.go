@@ -0,0 +1,64 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTeeSchedule(t *testing.T) {
+	primary := mustParse(t, "TZ=UTC 0 * * * *")
+	quarter := mustParse(t, "TZ=UTC */15 * * * *")
+	daily := mustParse(t, "TZ=UTC 30 9 * * *")
+	tee := Tee(primary, quarter, daily)
+
+	start := time.Date(2024, 1, 1, 9, 10, 0, 0, time.UTC)
+	if !tee.ObserverNext(0).IsZero() {
+		t.Errorf("expected no cursor before Next, got %v", tee.ObserverNext(0))
+	}
+
+	tests := []struct {
+		from                         time.Time
+		next, quarterNext, dailyNext time.Time
+	}{
+		{start, at(10, 0), at(9, 15), at(9, 30)},
+		{at(9, 15), at(10, 0), at(9, 30), at(9, 30)},
+		{at(9, 30), at(10, 0), at(9, 45), time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)},
+		{at(10, 0), at(11, 0), at(10, 15), time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)},
+	}
+	for _, c := range tests {
+		if next := tee.Next(c.from); !next.Equal(c.next) {
+			t.Errorf("from %v: expected %v, got %v", c.from, c.next, next)
+		}
+		if got := tee.ObserverNext(0); !got.Equal(c.quarterNext) {
+			t.Errorf("from %v: expected observer 0 at %v, got %v", c.from, c.quarterNext, got)
+		}
+		if got := tee.ObserverNext(1); !got.Equal(c.dailyNext) {
+			t.Errorf("from %v: expected observer 1 at %v, got %v", c.from, c.dailyNext, got)
+		}
+	}
+
+	if latest := tee.Latest(at(10, 30)); !latest.Equal(at(10, 0)) {
+		t.Errorf("expected the primary's latest activation, got %v", latest)
+	}
+}
+
+func TestTeeScheduleObserversDontAffectNext(t *testing.T) {
+	primary := mustParse(t, "TZ=UTC 0 9 * * *")
+	tee := Tee(primary, Every(time.Second), mustParse(t, "TZ=UTC 0 0 30 2 *"))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		next, expected := tee.Next(start), primary.Next(start)
+		if !next.Equal(expected) {
+			t.Fatalf("expected %v, got %v", expected, next)
+		}
+		if !tee.ObserverNext(1).IsZero() {
+			t.Errorf("expected an observer with no activations to have a zero cursor, got %v", tee.ObserverNext(1))
+		}
+		start = next
+	}
+}
+
+// at returns the given time on 2024-01-01, in UTC.
+func at(hour, minute int) time.Time {
+	return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+}
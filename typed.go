@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// TypedJob is a Job that passes a payload to a function on each run. The
+// payload is kept on the entry, so it can be inspected through Entries.
+type TypedJob[T any] struct {
+	Payload T
+	Fn      func(ctx context.Context, payload T) error
+}
+
+// AddTyped adds a TypedJob to the Cron to be run on the given schedule. The
+// payload is reported by the entry's Payload field, and each run's error is
+// passed to the job's SelfScheduler, if any.
+func AddTyped[T any](c *Cron, spec string, payload T, fn func(ctx context.Context, payload T) error, opts ...EntryOption) (EntryID, error) {
+	return c.AddJob(spec, &TypedJob[T]{Payload: payload, Fn: fn}, opts...)
+}
+
+// Run calls the job's function with its payload.
+func (j *TypedJob[T]) Run() { j.RunErr() }
+
+// RunErr calls the job's function with its payload, and returns its error.
+func (j *TypedJob[T]) RunErr() error {
+	return j.RunContext(context.Background())
+}
+
+// RunContext is like RunErr, but passes the function the given context.
+func (j *TypedJob[T]) RunContext(ctx context.Context) error {
+	return j.Fn(ctx, j.Payload)
+}
+
+// MarshalJSON encodes the job's payload. A payload that implements
+// json.Marshaler controls its own encoding.
+func (j *TypedJob[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Payload)
+}
+
+// UnmarshalJSON decodes the job's payload, leaving its function unchanged.
+func (j *TypedJob[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &j.Payload)
+}
+
+// payloader is implemented by jobs that carry a payload for their entry.
+type payloader interface {
+	payload() interface{}
+}
+
+func (j *TypedJob[T]) payload() interface{} { return j.Payload }
@@ -0,0 +1,53 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type report struct {
+	Name string `json:"name"`
+	Rows int    `json:"rows"`
+}
+
+func TestAddTyped(t *testing.T) {
+	var got []report
+	cron := New(WithChain())
+	id, err := AddTyped(cron, "@hourly", report{"sales", 10}, func(ctx context.Context, r report) error {
+		got = append(got, r)
+		return errors.New("failed")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := cron.Entry(id)
+	if p, ok := entry.Payload.(report); !ok || p.Name != "sales" {
+		t.Errorf("expected the payload on the entry, got %#v", entry.Payload)
+	}
+	entry.WrappedJob.Run()
+	if len(got) != 1 || got[0].Rows != 10 {
+		t.Errorf("expected the job to be given its payload, got %v", got)
+	}
+	if err := entry.runs.err(); err == nil || err.Error() != "failed" {
+		t.Errorf("expected the job's error to be recorded, got %v", err)
+	}
+
+	job := entry.Job.(*TypedJob[report])
+	data, err := json.Marshal(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"name":"sales","rows":10}` {
+		t.Errorf("unexpected encoding: %s", data)
+	}
+	restored := &TypedJob[report]{Fn: job.Fn}
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Payload != job.Payload {
+		t.Errorf("expected %v, got %v", job.Payload, restored.Payload)
+	}
+}
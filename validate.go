@@ -0,0 +1,122 @@
+package cron
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// ErrorCode identifies the kind of a FieldError, so that callers can handle
+// each kind without matching messages.
+type ErrorCode string
+
+const (
+	// ErrBitOutOfRange means a field has a bit set that stands for no value
+	// of the field, such as minute 75.
+	ErrBitOutOfRange ErrorCode = "bit-out-of-range"
+
+	// ErrNoActiveBits means a field is nil or has no values set, so the
+	// schedule never runs.
+	ErrNoActiveBits ErrorCode = "no-active-bits"
+
+	// ErrInvalidSentinel means the bit that marks a field given as "*" is set
+	// although the field doesn't hold every value.
+	ErrInvalidSentinel ErrorCode = "invalid-sentinel"
+)
+
+// FieldError describes a problem found in a field of a SpecSchedule.
+type FieldError struct {
+	// Field is the name of the offending field (e.g. "minute", "dow").
+	Field string
+
+	// Value is the offending bit position, or empty if the problem concerns
+	// the field as a whole.
+	Value string
+
+	Message string
+	Code    ErrorCode
+}
+
+func (e FieldError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("%s field: %s", e.Field, e.Message)
+	}
+	return fmt.Sprintf("%s field bit %s: %s", e.Field, e.Value, e.Message)
+}
+
+// ValidateAll checks the fields of a schedule built by hand, or changed after
+// parsing, and returns every problem it finds, in field order, or nil if
+// there are none. Schedules returned by the parsers are always valid.
+func (s *SpecSchedule) ValidateAll() []FieldError {
+	var errs []FieldError
+	if s.Millisecond != nil {
+		errs = append(errs, validateField("millisecond", 0, s.Millisecond, milliseconds)...)
+	}
+	for i, field := range []*big.Int{s.Second, s.Minute, s.Hour, s.Dom, s.Month, s.Dow, s.Year, s.WeekOfYear} {
+		if field == nil && places[i] == Week { // every week
+			continue
+		}
+		errs = append(errs, validateField(fieldNames[i], places[i], field, *fieldBounds[i])...)
+	}
+	return errs
+}
+
+// Validate is like ValidateAll, but returns only the first problem, or nil.
+func (s *SpecSchedule) Validate() error {
+	if errs := s.ValidateAll(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// validateField returns the problems with the field at the given place, in
+// the bounds r.
+func validateField(name string, place ParseOption, field *big.Int, r bounds) []FieldError {
+	if field == nil {
+		return []FieldError{{Field: name, Message: "is nil", Code: ErrNoActiveBits}}
+	}
+	var errs []FieldError
+	active := false
+	for bit := 0; bit < field.BitLen(); bit++ {
+		switch {
+		case field.Bit(bit) == 0 || bit == maxBits || place == Dom && bit == domAndDowBit:
+		case bit >= int(r.min) && bit <= int(r.max) || specialBit(place, bit):
+			active = true
+		default:
+			errs = append(errs, FieldError{
+				Field:   name,
+				Value:   strconv.Itoa(bit),
+				Message: fmt.Sprintf("is outside the range %d-%d", r.min, r.max),
+				Code:    ErrBitOutOfRange,
+			})
+		}
+	}
+	if !active {
+		errs = append(errs, FieldError{Field: name, Message: "has no values set", Code: ErrNoActiveBits})
+	}
+	if field.Bit(maxBits) > 0 && !isAll(field, r) {
+		errs = append(errs, FieldError{
+			Field:   name,
+			Value:   strconv.Itoa(maxBits),
+			Message: "marks the field as * but not every value is set",
+			Code:    ErrInvalidSentinel,
+		})
+	}
+	return errs
+}
+
+// specialBit reports whether a bit outside the range of the field at the
+// given place has a meaning there: the last days, clamped days and nearest
+// weekdays of the day of month field, and the last and nth weekdays of the
+// day of week field.
+func specialBit(place ParseOption, bit int) bool {
+	switch place {
+	case Dom:
+		return bit >= 48 && bit <= 55 ||
+			bit >= domClampBit(29) && bit <= domClampBit(31) ||
+			bit >= domWeekdayBit(1) && bit <= lastWeekdayBit
+	case Dow:
+		return bit >= 49 && bit <= 55 || bit >= nthWeekdayBit(1, 0) && bit <= nthWeekdayBit(5, 6)
+	}
+	return false
+}
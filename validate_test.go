@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidateAll(t *testing.T) {
+	s := mustParse(t, "0 9 * * mon-fri").(*SpecSchedule).clone()
+	s.Minute.SetBit(s.Minute, 75, 1)                  // out of range
+	s.Hour = new(big.Int)                             // empty
+	s.Month = new(big.Int).SetBit(new(big.Int), 1, 1) // only January, but marked *
+	s.Month.SetBit(s.Month, maxBits, 1)
+
+	expected := []FieldError{
+		{Field: "minute", Value: "75", Message: "is outside the range 0-59", Code: ErrBitOutOfRange},
+		{Field: "hour", Message: "has no values set", Code: ErrNoActiveBits},
+		{Field: "month", Value: "160", Message: "marks the field as * but not every value is set", Code: ErrInvalidSentinel},
+	}
+	if errs := s.ValidateAll(); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, got %v", expected, errs)
+	}
+
+	err := s.Validate()
+	var fieldErr FieldError
+	if !errors.As(err, &fieldErr) || fieldErr != expected[0] {
+		t.Errorf("expected %v, got %v", expected[0], err)
+	}
+	if msg := err.Error(); msg != "minute field bit 75: is outside the range 0-59" {
+		t.Errorf("unexpected message %q", msg)
+	}
+
+	s.Millisecond = new(big.Int)
+	s.Dow = nil
+	if errs := s.ValidateAll(); len(errs) != 5 || errs[0].Field != "millisecond" || errs[4].Field != "dow" || errs[4].Message != "is nil" {
+		t.Errorf("expected errors for the millisecond and dow fields too, got %v", errs)
+	}
+}
+
+func TestValidateParsed(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		spec := GenerateSpec(r, DefaultGenOpts())
+		sched, _, err := ParseFlexible(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sched.(*SpecSchedule).Validate(); err != nil {
+			t.Errorf("%q: %v", spec, err)
+		}
+	}
+
+	for _, sched := range []*SpecSchedule{
+		mustParse(t, "0 0 15w,lw,l,3l * *").(*SpecSchedule),
+		mustParse(t, "0 0 * * mon#2,5l").(*SpecSchedule),
+		MustParseWithLocation("0 0 31 * *", time.UTC),
+		FromRobfig(RobfigSpec{Second: 1, Minute: 1, Hour: 1, Dom: 1 << 1, Month: 1 << 1, Dow: 1<<63 | 0x7F}),
+	} {
+		if err := sched.Validate(); err != nil {
+			t.Errorf("%v: %v", sched, err)
+		}
+	}
+	clamped, err := NewParser(Minute | Hour | Dom | Month | Dow | ClampDom | DomAndDow).Parse("0 0 31 * mon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := clamped.(*SpecSchedule).Validate(); err != nil {
+		t.Errorf("%v: %v", clamped, err)
+	}
+}
@@ -0,0 +1,104 @@
+package cron
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrStopped is returned by Wait if the Cron is stopped, or was never started,
+// and by RunWithContext if the Cron is stopped before its context is done.
+var ErrStopped = errors.New("cron: stopped")
+
+// Wait blocks until every entry is exhausted, i.e. its schedule has no further
+// activations or it has been removed, and every invocation has finished. It is
+// meant for batch workloads in which every entry runs a bounded number of
+// times; entries added while waiting are waited for too.
+//
+// It returns every error from a failed run (a FallibleJob's error, or a panic)
+// while it waited, joined with errors.Join, or nil if there were
+// none. If the Cron is stopped first, ErrStopped is joined with them. If ctx is
+// done first, it returns ctx.Err(). Wait may be called by several goroutines
+// at once.
+func (c *Cron) Wait(ctx context.Context) error {
+	from := c.startWaiting()
+	defer c.stopWaiting()
+	for {
+		changed := c.changes()
+		c.runningMu.Lock()
+		running := c.running
+		c.runningMu.Unlock()
+		if !running {
+			return c.joinRunErrs(from, ErrStopped)
+		}
+		if c.exhausted() {
+			return c.joinRunErrs(from, nil)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// exhausted reports whether no entry has an activation or invocation to come.
+func (c *Cron) exhausted() bool {
+	for _, e := range c.Entries() {
+		if !e.Next.IsZero() || e.Running > 0 || e.runs.pending() {
+			return false
+		}
+	}
+	return true
+}
+
+// changes returns a channel that is closed on the next call to notify.
+func (c *Cron) changes() <-chan struct{} {
+	c.waitMu.Lock()
+	defer c.waitMu.Unlock()
+	return c.changed
+}
+
+// notify wakes up callers of Wait to check whether the entries are exhausted.
+func (c *Cron) notify() {
+	c.waitMu.Lock()
+	defer c.waitMu.Unlock()
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// startWaiting counts a caller of Wait, so that errors from failed runs are
+// kept for it, and returns the number kept already.
+func (c *Cron) startWaiting() int {
+	c.waitMu.Lock()
+	defer c.waitMu.Unlock()
+	c.waiters++
+	return len(c.runErrs)
+}
+
+// stopWaiting counts a caller of Wait out, dropping the errors kept once no
+// one is waiting for them, so they don't pile up over the Cron's lifetime.
+func (c *Cron) stopWaiting() {
+	c.waitMu.Lock()
+	defer c.waitMu.Unlock()
+	c.waiters--
+	if c.waiters == 0 {
+		c.runErrs = nil
+	}
+}
+
+// addRunErr keeps the error from a failed run, if Wait is waiting for it.
+func (c *Cron) addRunErr(err error) {
+	c.waitMu.Lock()
+	defer c.waitMu.Unlock()
+	if c.waiters > 0 {
+		c.runErrs = append(c.runErrs, err)
+	}
+}
+
+// joinRunErrs returns err joined with the errors from failed runs, from the
+// given one on.
+func (c *Cron) joinRunErrs(from int, err error) error {
+	c.waitMu.Lock()
+	defer c.waitMu.Unlock()
+	return errors.Join(append([]error{err}, c.runErrs[from:]...)...)
+}
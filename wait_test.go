@@ -0,0 +1,106 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// timesSchedule activates at the given times only.
+type timesSchedule []time.Time
+
+func (s timesSchedule) Next(t time.Time) time.Time {
+	for _, next := range s {
+		if next.After(t) {
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+func (s timesSchedule) NextOK(t time.Time) (time.Time, bool) {
+	next := s.Next(t)
+	return next, !next.IsZero()
+}
+
+func (s timesSchedule) Latest(t time.Time) time.Time { return time.Time{} }
+
+type failingJob struct{ err error }
+
+func (j failingJob) Run()          { j.RunErr() }
+func (j failingJob) RunErr() error { return j.err }
+
+func TestWait(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := newFakeClock(start)
+	cron := New(WithLocation(time.UTC))
+	cron.clock = fc
+	failed := errors.New("failed")
+	cron.Schedule(timesSchedule{start.Add(time.Second), start.Add(2 * time.Second)}, failingJob{failed})
+	cron.Schedule(timesSchedule{start.Add(time.Second)}, failingJob{})
+	cron.Start()
+	defer cron.Stop()
+
+	results := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() { results <- cron.Wait(context.Background()) }()
+	}
+	// Errors are only kept while someone waits for them.
+	waitFor(t, func() bool {
+		cron.waitMu.Lock()
+		defer cron.waitMu.Unlock()
+		return cron.waiters == 2
+	})
+
+	timer := fc.advance(<-fc.timers)
+	select {
+	case err := <-results:
+		t.Fatal("expected Wait to block while runs remain, got", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	fc.advance(timer)
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-results:
+			if !errors.Is(err, failed) || errors.Is(err, ErrStopped) {
+				t.Errorf("expected the failed runs, got %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected Wait to return once the entries were exhausted")
+		}
+	}
+	cron.waitMu.Lock()
+	defer cron.waitMu.Unlock()
+	if cron.runErrs != nil {
+		t.Errorf("expected the errors to be dropped once no one waits, got %v", cron.runErrs)
+	}
+}
+
+func TestWaitStopped(t *testing.T) {
+	cron := New()
+	if err := cron.Wait(context.Background()); !errors.Is(err, ErrStopped) {
+		t.Errorf("expected ErrStopped before starting, got %v", err)
+	}
+
+	cron.AddFunc("@hourly", func() {})
+	cron.Start()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := cron.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected the context's error, got %v", err)
+	}
+
+	result := make(chan error)
+	go func() { result <- cron.Wait(context.Background()) }()
+	time.Sleep(10 * time.Millisecond)
+	cron.Stop()
+	select {
+	case err := <-result:
+		if !errors.Is(err, ErrStopped) {
+			t.Errorf("expected ErrStopped, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Stop to unblock Wait")
+	}
+}